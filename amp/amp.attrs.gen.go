@@ -0,0 +1,246 @@
+// Code generated by amp-gen-attrs. DO NOT EDIT.
+// source: amp.proto
+
+package amp
+
+import (
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+// RegisterAmpTypes registers each message type in amp.proto as a prototype under AttrSpec.
+func RegisterAmpTypes(reg Registry) {
+	reg.RegisterPrototype(AttrSpec, &Login{}, "Login")
+	reg.RegisterPrototype(AttrSpec, &LoginChallenge{}, "LoginChallenge")
+	reg.RegisterPrototype(AttrSpec, &LoginResponse{}, "LoginResponse")
+	reg.RegisterPrototype(AttrSpec, &LoginCheckpoint{}, "LoginCheckpoint")
+	reg.RegisterPrototype(AttrSpec, &PinRequest{}, "PinRequest")
+	reg.RegisterPrototype(AttrSpec, &CancelRequest{}, "CancelRequest")
+	reg.RegisterPrototype(AttrSpec, &LaunchURL{}, "LaunchURL")
+	reg.RegisterPrototype(AttrSpec, &RegisterDefs{}, "RegisterDefs")
+	reg.RegisterPrototype(AttrSpec, &AttrSchema{}, "AttrSchema")
+	reg.RegisterPrototype(AttrSpec, &FieldSchema{}, "FieldSchema")
+	reg.RegisterPrototype(AttrSpec, &AppSchema{}, "AppSchema")
+	reg.RegisterPrototype(AttrSpec, &AppReloaded{}, "AppReloaded")
+	reg.RegisterPrototype(AttrSpec, &DeltaResync{}, "DeltaResync")
+	reg.RegisterPrototype(AttrSpec, &FlowCredit{}, "FlowCredit")
+	reg.RegisterPrototype(AttrSpec, &TagUID{}, "TagUID")
+	reg.RegisterPrototype(AttrSpec, &Tag{}, "Tag")
+	reg.RegisterPrototype(AttrSpec, &CryptoKey{}, "CryptoKey")
+	reg.RegisterPrototype(AttrSpec, &Err{}, "Err")
+}
+
+func (v *Login) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *Login) TagSpec() tag.Spec {
+	return AttrSpec.With("Login")
+}
+
+func (v *Login) New() tag.Value {
+	return &Login{}
+}
+
+func (v *LoginChallenge) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *LoginChallenge) TagSpec() tag.Spec {
+	return AttrSpec.With("LoginChallenge")
+}
+
+func (v *LoginChallenge) New() tag.Value {
+	return &LoginChallenge{}
+}
+
+func (v *LoginResponse) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *LoginResponse) TagSpec() tag.Spec {
+	return AttrSpec.With("LoginResponse")
+}
+
+func (v *LoginResponse) New() tag.Value {
+	return &LoginResponse{}
+}
+
+func (v *LoginCheckpoint) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *LoginCheckpoint) TagSpec() tag.Spec {
+	return AttrSpec.With("LoginCheckpoint")
+}
+
+func (v *LoginCheckpoint) New() tag.Value {
+	return &LoginCheckpoint{}
+}
+
+func (v *PinRequest) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *PinRequest) TagSpec() tag.Spec {
+	return AttrSpec.With("PinRequest")
+}
+
+func (v *PinRequest) New() tag.Value {
+	return &PinRequest{}
+}
+
+func (v *CancelRequest) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *CancelRequest) TagSpec() tag.Spec {
+	return AttrSpec.With("CancelRequest")
+}
+
+func (v *CancelRequest) New() tag.Value {
+	return &CancelRequest{}
+}
+
+func (v *LaunchURL) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *LaunchURL) TagSpec() tag.Spec {
+	return AttrSpec.With("LaunchURL")
+}
+
+func (v *LaunchURL) New() tag.Value {
+	return &LaunchURL{}
+}
+
+func (v *RegisterDefs) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *RegisterDefs) TagSpec() tag.Spec {
+	return AttrSpec.With("RegisterDefs")
+}
+
+func (v *RegisterDefs) New() tag.Value {
+	return &RegisterDefs{}
+}
+
+func (v *AttrSchema) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *AttrSchema) TagSpec() tag.Spec {
+	return AttrSpec.With("AttrSchema")
+}
+
+func (v *AttrSchema) New() tag.Value {
+	return &AttrSchema{}
+}
+
+func (v *FieldSchema) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *FieldSchema) TagSpec() tag.Spec {
+	return AttrSpec.With("FieldSchema")
+}
+
+func (v *FieldSchema) New() tag.Value {
+	return &FieldSchema{}
+}
+
+func (v *AppSchema) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *AppSchema) TagSpec() tag.Spec {
+	return AttrSpec.With("AppSchema")
+}
+
+func (v *AppSchema) New() tag.Value {
+	return &AppSchema{}
+}
+
+func (v *AppReloaded) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *AppReloaded) TagSpec() tag.Spec {
+	return AttrSpec.With("AppReloaded")
+}
+
+func (v *AppReloaded) New() tag.Value {
+	return &AppReloaded{}
+}
+
+func (v *DeltaResync) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *DeltaResync) TagSpec() tag.Spec {
+	return AttrSpec.With("DeltaResync")
+}
+
+func (v *DeltaResync) New() tag.Value {
+	return &DeltaResync{}
+}
+
+func (v *FlowCredit) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *FlowCredit) TagSpec() tag.Spec {
+	return AttrSpec.With("FlowCredit")
+}
+
+func (v *FlowCredit) New() tag.Value {
+	return &FlowCredit{}
+}
+
+func (v *TagUID) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *TagUID) TagSpec() tag.Spec {
+	return AttrSpec.With("TagUID")
+}
+
+func (v *TagUID) New() tag.Value {
+	return &TagUID{}
+}
+
+func (v *Tag) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *Tag) TagSpec() tag.Spec {
+	return AttrSpec.With("Tag")
+}
+
+func (v *Tag) New() tag.Value {
+	return &Tag{}
+}
+
+func (v *CryptoKey) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *CryptoKey) TagSpec() tag.Spec {
+	return AttrSpec.With("CryptoKey")
+}
+
+func (v *CryptoKey) New() tag.Value {
+	return &CryptoKey{}
+}
+
+func (v *Err) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *Err) TagSpec() tag.Spec {
+	return AttrSpec.With("Err")
+}
+
+func (v *Err) New() tag.Value {
+	return &Err{}
+}
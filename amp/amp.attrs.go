@@ -1,5 +1,7 @@
 package amp
 
+//go:generate go run github.com/art-media-platform/amp-sdk-go/cmd/amp-gen-attrs -proto amp.proto -spec AttrSpec -skip TxEnvelope
+
 import (
 	"time"
 
@@ -16,22 +18,7 @@ var (
 )
 
 func RegisterBuiltinTypes(reg Registry) error {
-
-	prototypes := []tag.Value{
-		&Err{},
-		&Tag{},
-		&LaunchURL{},
-		&Login{},
-		&LoginChallenge{},
-		&LoginResponse{},
-		&LoginCheckpoint{},
-		&PinRequest{},
-	}
-
-	for _, pi := range prototypes {
-		reg.RegisterPrototype(AttrSpec, pi, "")
-	}
-
+	RegisterAmpTypes(reg)
 	return nil
 }
 
@@ -60,18 +47,6 @@ func ErrorToValue(v error) tag.Value {
 	return artErr
 }
 
-func (v *TagUID) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *TagUID) TagSpec() tag.Spec {
-	return AttrSpec.With("TagUID")
-}
-
-func (v *TagUID) New() tag.Value {
-	return &TagUID{}
-}
-
 func (v *TagUID) SetFromTime(t time.Time) {
 	tag := tag.FromTime(t, false)
 	v.ID_0 = int64(tag[0])
@@ -79,18 +54,6 @@ func (v *TagUID) SetFromTime(t time.Time) {
 	v.ID_2 = tag[2]
 }
 
-func (v *Tag) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *Tag) TagSpec() tag.Spec {
-	return AttrSpec.With("Tag")
-}
-
-func (v *Tag) New() tag.Value {
-	return &Tag{}
-}
-
 func (v *Tag) TagID() tag.ID {
 	return [3]uint64{
 		uint64(v.TagID_0),
@@ -105,90 +68,6 @@ func (v *Tag) SetTagID(tagID tag.ID) {
 	v.TagID_2 = tagID[2]
 }
 
-func (v *Err) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *Err) TagSpec() tag.Spec {
-	return AttrSpec.With("Err")
-}
-
-func (v *Err) New() tag.Value {
-	return &Err{}
-}
-
-func (v *LaunchURL) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *LaunchURL) TagSpec() tag.Spec {
-	return AttrSpec.With("LaunchURL")
-}
-
-func (v *LaunchURL) New() tag.Value {
-	return &LaunchURL{}
-}
-
-func (v *Login) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *Login) TagSpec() tag.Spec {
-	return AttrSpec.With("Login")
-}
-
-func (v *Login) New() tag.Value {
-	return &Login{}
-}
-
-func (v *LoginChallenge) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *LoginChallenge) TagSpec() tag.Spec {
-	return AttrSpec.With("LoginChallenge")
-}
-
-func (v *LoginChallenge) New() tag.Value {
-	return &LoginChallenge{}
-}
-
-func (v *LoginResponse) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *LoginResponse) TagSpec() tag.Spec {
-	return AttrSpec.With("LoginResponse")
-}
-
-func (v *LoginResponse) New() tag.Value {
-	return &LoginResponse{}
-}
-
-func (v *LoginCheckpoint) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *LoginCheckpoint) TagSpec() tag.Spec {
-	return AttrSpec.With("LoginCheckpoint")
-}
-
-func (v *LoginCheckpoint) New() tag.Value {
-	return &LoginCheckpoint{}
-}
-
-func (v *PinRequest) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *PinRequest) TagSpec() tag.Spec {
-	return AttrSpec.With("PinRequest")
-}
-
-func (v *PinRequest) New() tag.Value {
-	return &PinRequest{}
-}
-
 func (v *PinRequest) SetTargetID(id tag.ID) {
 	if v.PinTarget == nil {
 		v.PinTarget = &Tag{}
@@ -210,6 +89,16 @@ func (v *PinRequest) TargetID() tag.ID {
 	}
 }
 
+// SetTimeout sets TimeoutMs from the given duration, where a timeout <= 0 means none.
+func (v *PinRequest) SetTimeout(timeout time.Duration) {
+	v.TimeoutMs = max(0, timeout.Milliseconds())
+}
+
+// Timeout returns TimeoutMs as a duration, or 0 if there is no timeout.
+func (v *PinRequest) Timeout() time.Duration {
+	return time.Duration(max(0, v.TimeoutMs)) * time.Millisecond
+}
+
 /*
 func (v *Request) AttrsToPin() map[tag.ID]struct{} {
 	pinAttrs := make(map[tag.ID]struct{}, len(v.PinAttrs))
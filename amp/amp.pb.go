@@ -84,18 +84,21 @@ type TxOpCode int32
 const (
 	TxOpCode_Nil           TxOpCode = 0
 	TxOpCode_UpsertElement TxOpCode = 2
+	TxOpCode_UpsertDelta   TxOpCode = 3
 	TxOpCode_DeleteElement TxOpCode = 4
 )
 
 var TxOpCode_name = map[int32]string{
 	0: "TxOpCode_Nil",
 	2: "TxOpCode_UpsertElement",
+	3: "TxOpCode_UpsertDelta",
 	4: "TxOpCode_DeleteElement",
 }
 
 var TxOpCode_value = map[string]int32{
 	"TxOpCode_Nil":           0,
 	"TxOpCode_UpsertElement": 2,
+	"TxOpCode_UpsertDelta":   3,
 	"TxOpCode_DeleteElement": 4,
 }
 
@@ -188,6 +191,31 @@ func (SelectOp) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_7e479d288f92766f, []int{3}
 }
 
+// TxPriority is the scheduling class of a TxMsg, determining the order in which outbound txs are sent -- see amp.TxScheduler
+type TxPriority int32
+
+const (
+	TxPriority_Interactive TxPriority = 0
+	TxPriority_Control     TxPriority = 1
+	TxPriority_Bulk        TxPriority = 2
+)
+
+var TxPriority_name = map[int32]string{
+	0: "TxPriority_Interactive",
+	1: "TxPriority_Control",
+	2: "TxPriority_Bulk",
+}
+
+var TxPriority_value = map[string]int32{
+	"TxPriority_Interactive": 0,
+	"TxPriority_Control":     1,
+	"TxPriority_Bulk":        2,
+}
+
+func (TxPriority) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{4}
+}
+
 // OpStatus allows a sender to express the status of a request.
 type OpStatus int32
 
@@ -223,7 +251,7 @@ var OpStatus_value = map[string]int32{
 }
 
 func (OpStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{4}
+	return fileDescriptor_7e479d288f92766f, []int{5}
 }
 
 type StateSync int32
@@ -253,7 +281,7 @@ var StateSync_value = map[string]int32{
 }
 
 func (StateSync) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{5}
+	return fileDescriptor_7e479d288f92766f, []int{6}
 }
 
 type Enable int32
@@ -280,7 +308,7 @@ var Enable_value = map[string]int32{
 }
 
 func (Enable) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{6}
+	return fileDescriptor_7e479d288f92766f, []int{7}
 }
 
 type UrlScheme int32
@@ -325,7 +353,7 @@ var UrlScheme_value = map[string]int32{
 }
 
 func (UrlScheme) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{7}
+	return fileDescriptor_7e479d288f92766f, []int{8}
 }
 
 type Metric int32
@@ -361,7 +389,7 @@ var Metric_value = map[string]int32{
 }
 
 func (Metric) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{8}
+	return fileDescriptor_7e479d288f92766f, []int{9}
 }
 
 // CryptoKitID identifies an encryption suite that implements ski.CryptoKit
@@ -392,7 +420,7 @@ var CryptoKitID_value = map[string]int32{
 }
 
 func (CryptoKitID) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{9}
+	return fileDescriptor_7e479d288f92766f, []int{10}
 }
 
 // ErrCode expresses status and error codes.
@@ -424,6 +452,8 @@ const (
 	ErrCode_SpaceNotFound           ErrCode = 5032
 	ErrCode_StorageFailure          ErrCode = 5033
 	ErrCode_AppNotFound             ErrCode = 5034
+	ErrCode_AppConflict             ErrCode = 5035
+	ErrCode_DependencyFailed        ErrCode = 5036
 	ErrCode_MalformedTx             ErrCode = 5040
 	ErrCode_BadSchema               ErrCode = 5052
 	ErrCode_DataFailure             ErrCode = 5053
@@ -461,6 +491,8 @@ var ErrCode_name = map[int32]string{
 	5032: "ErrCode_SpaceNotFound",
 	5033: "ErrCode_StorageFailure",
 	5034: "ErrCode_AppNotFound",
+	5035: "ErrCode_AppConflict",
+	5036: "ErrCode_DependencyFailed",
 	5040: "ErrCode_MalformedTx",
 	5052: "ErrCode_BadSchema",
 	5053: "ErrCode_DataFailure",
@@ -498,6 +530,8 @@ var ErrCode_value = map[string]int32{
 	"ErrCode_SpaceNotFound":           5032,
 	"ErrCode_StorageFailure":          5033,
 	"ErrCode_AppNotFound":             5034,
+	"ErrCode_AppConflict":             5035,
+	"ErrCode_DependencyFailed":        5036,
 	"ErrCode_MalformedTx":             5040,
 	"ErrCode_BadSchema":               5052,
 	"ErrCode_DataFailure":             5053,
@@ -510,7 +544,7 @@ var ErrCode_value = map[string]int32{
 }
 
 func (ErrCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{10}
+	return fileDescriptor_7e479d288f92766f, []int{11}
 }
 
 type LogLevel int32
@@ -534,13 +568,15 @@ var LogLevel_value = map[string]int32{
 }
 
 func (LogLevel) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{11}
+	return fileDescriptor_7e479d288f92766f, []int{12}
 }
 
 // TxEnvelope contains information for a TxMsg
 type TxEnvelope struct {
 	// communicates request status / completion.
 	Status OpStatus `protobuf:"varint,2,opt,name=Status,proto3,enum=amp.OpStatus" json:"Status,omitempty"`
+	// scheduling class of this tx -- see amp.TxScheduler
+	Priority TxPriority `protobuf:"varint,3,opt,name=Priority,proto3,enum=amp.TxPriority" json:"Priority,omitempty"`
 	// The number of TxOps in this TxMsg.
 	OpCount uint64 `protobuf:"varint,4,opt,name=OpCount,proto3" json:"OpCount,omitempty"`
 	// A universally unique tag assigned when this Tx was created.
@@ -598,6 +634,13 @@ func (m *TxEnvelope) GetStatus() OpStatus {
 	return OpStatus_NotStarted
 }
 
+func (m *TxEnvelope) GetPriority() TxPriority {
+	if m != nil {
+		return m.Priority
+	}
+	return TxPriority_Interactive
+}
+
 func (m *TxEnvelope) GetOpCount() uint64 {
 	if m != nil {
 		return m.OpCount
@@ -961,6 +1004,8 @@ type PinRequest struct {
 	PinAttrs []*Tag `protobuf:"bytes,4,rep,name=PinAttrs,proto3" json:"PinAttrs,omitempty"`
 	// Options for this request.
 	StateSync StateSync `protobuf:"varint,6,opt,name=StateSync,proto3,enum=amp.StateSync" json:"StateSync,omitempty"`
+	// If > 0, the host closes this request once this many milliseconds have passed since it was received -- see amp.Request.Deadline
+	TimeoutMs int64 `protobuf:"varint,8,opt,name=TimeoutMs,proto3" json:"TimeoutMs,omitempty"`
 }
 
 func (m *PinRequest) Reset()      { *m = PinRequest{} }
@@ -1016,6 +1061,58 @@ func (m *PinRequest) GetStateSync() StateSync {
 	return StateSync_None
 }
 
+func (m *PinRequest) GetTimeoutMs() int64 {
+	if m != nil {
+		return m.TimeoutMs
+	}
+	return 0
+}
+
+// CancelRequest is a meta attribute that cancels the in-flight request having the ContextID of the tx carrying it -- see amp.NewCancelTx()
+// The host closes the matching Pin, which then completes with amp.ErrRequestClosed.
+type CancelRequest struct {
+	Reason string `protobuf:"bytes,1,opt,name=Reason,proto3" json:"Reason,omitempty"`
+}
+
+func (m *CancelRequest) Reset()      { *m = CancelRequest{} }
+func (*CancelRequest) ProtoMessage() {}
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{6}
+}
+func (m *CancelRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CancelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CancelRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CancelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelRequest.Merge(m, src)
+}
+func (m *CancelRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CancelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelRequest proto.InternalMessageInfo
+
+func (m *CancelRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 // LaunchURL is used as a meta attribute handle a URL, such as an oauth request (host to client) or an oauth response (client to host).
 type LaunchURL struct {
 	URL string `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
@@ -1024,7 +1121,7 @@ type LaunchURL struct {
 func (m *LaunchURL) Reset()      { *m = LaunchURL{} }
 func (*LaunchURL) ProtoMessage() {}
 func (*LaunchURL) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{6}
+	return fileDescriptor_7e479d288f92766f, []int{7}
 }
 func (m *LaunchURL) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1060,25 +1157,24 @@ func (m *LaunchURL) GetURL() string {
 	return ""
 }
 
-// TagUID is a minimal wrapper for a tag.ID
-type TagUID struct {
-	ID_0 int64  `protobuf:"varint,2,opt,name=ID_0,json=ID0,proto3" json:"ID_0,omitempty"`
-	ID_1 uint64 `protobuf:"fixed64,3,opt,name=ID_1,json=ID1,proto3" json:"ID_1,omitempty"`
-	ID_2 uint64 `protobuf:"fixed64,4,opt,name=ID_2,json=ID2,proto3" json:"ID_2,omitempty"`
-	UID  string `protobuf:"bytes,6,opt,name=UID,proto3" json:"UID,omitempty"`
+// RegisterDefs is a meta attribute that communicates the apps and attr specs known to an amp.Registry.
+// A host sends this to a client so it can validate attributes and present UI for them -- see amp.ExportSchema()
+type RegisterDefs struct {
+	Attrs []*AttrSchema `protobuf:"bytes,1,rep,name=Attrs,proto3" json:"Attrs,omitempty"`
+	Apps  []*AppSchema  `protobuf:"bytes,2,rep,name=Apps,proto3" json:"Apps,omitempty"`
 }
 
-func (m *TagUID) Reset()      { *m = TagUID{} }
-func (*TagUID) ProtoMessage() {}
-func (*TagUID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{7}
+func (m *RegisterDefs) Reset()      { *m = RegisterDefs{} }
+func (*RegisterDefs) ProtoMessage() {}
+func (*RegisterDefs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{8}
 }
-func (m *TagUID) XXX_Unmarshal(b []byte) error {
+func (m *RegisterDefs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TagUID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RegisterDefs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TagUID.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RegisterDefs.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1088,81 +1184,120 @@ func (m *TagUID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *TagUID) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TagUID.Merge(m, src)
+func (m *RegisterDefs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterDefs.Merge(m, src)
 }
-func (m *TagUID) XXX_Size() int {
+func (m *RegisterDefs) XXX_Size() int {
 	return m.Size()
 }
-func (m *TagUID) XXX_DiscardUnknown() {
-	xxx_messageInfo_TagUID.DiscardUnknown(m)
+func (m *RegisterDefs) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterDefs.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TagUID proto.InternalMessageInfo
+var xxx_messageInfo_RegisterDefs proto.InternalMessageInfo
 
-func (m *TagUID) GetID_0() int64 {
+func (m *RegisterDefs) GetAttrs() []*AttrSchema {
 	if m != nil {
-		return m.ID_0
+		return m.Attrs
 	}
-	return 0
+	return nil
 }
 
-func (m *TagUID) GetID_1() uint64 {
+func (m *RegisterDefs) GetApps() []*AppSchema {
 	if m != nil {
-		return m.ID_1
+		return m.Apps
 	}
-	return 0
+	return nil
 }
 
-func (m *TagUID) GetID_2() uint64 {
+// AttrSchema describes a registered attr spec and the element type it maps to.
+type AttrSchema struct {
+	Spec     *Tag           `protobuf:"bytes,1,opt,name=Spec,proto3" json:"Spec,omitempty"`
+	TypeName string         `protobuf:"bytes,2,opt,name=TypeName,proto3" json:"TypeName,omitempty"`
+	GoType   string         `protobuf:"bytes,3,opt,name=GoType,proto3" json:"GoType,omitempty"`
+	Fields   []*FieldSchema `protobuf:"bytes,4,rep,name=Fields,proto3" json:"Fields,omitempty"`
+}
+
+func (m *AttrSchema) Reset()      { *m = AttrSchema{} }
+func (*AttrSchema) ProtoMessage() {}
+func (*AttrSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{9}
+}
+func (m *AttrSchema) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AttrSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AttrSchema.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AttrSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttrSchema.Merge(m, src)
+}
+func (m *AttrSchema) XXX_Size() int {
+	return m.Size()
+}
+func (m *AttrSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttrSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttrSchema proto.InternalMessageInfo
+
+func (m *AttrSchema) GetSpec() *Tag {
 	if m != nil {
-		return m.ID_2
+		return m.Spec
 	}
-	return 0
+	return nil
 }
 
-func (m *TagUID) GetUID() string {
+func (m *AttrSchema) GetTypeName() string {
 	if m != nil {
-		return m.UID
+		return m.TypeName
 	}
 	return ""
 }
 
-// Tag is a multi-use workhorse. All fields are optional and whose meaning is contextual.
-//
-// Often used to reference an asset, a Link can reference any resource, a show, project, episode, or XR beacon.
-// The tagging naming convention describes a semi-ordered list of UTF tags.
-//      As tags first appear when going from left to right in the list, they are considered "more significant" or "higher priority" than tags that appear later.
-//      It is up to amp-search-dev-tag-specification to order search results based on tag filters (case sensitive, time ranges, or any UTF8 enum identifier)
-//      By convention, tags are case sensitive by default, however there are many filter presets -- This is how people "type or speak search"
-//      "Two tag rule" -- if you can think of two or more other tags in an order ranking, then do that instead.
-type Tag struct {
-	TagID_0     int64   `protobuf:"varint,2,opt,name=TagID_0,json=TagID0,proto3" json:"TagID_0,omitempty"`
-	TagID_1     uint64  `protobuf:"fixed64,3,opt,name=TagID_1,json=TagID1,proto3" json:"TagID_1,omitempty"`
-	TagID_2     uint64  `protobuf:"fixed64,4,opt,name=TagID_2,json=TagID2,proto3" json:"TagID_2,omitempty"`
-	UID         string  `protobuf:"bytes,6,opt,name=UID,proto3" json:"UID,omitempty"`
-	Text        string  `protobuf:"bytes,7,opt,name=Text,proto3" json:"Text,omitempty"`
-	Ordering    float64 `protobuf:"fixed64,9,opt,name=Ordering,proto3" json:"Ordering,omitempty"`
-	URL         string  `protobuf:"bytes,13,opt,name=URL,proto3" json:"URL,omitempty"`
-	ContentType string  `protobuf:"bytes,14,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
-	Metric      Metric  `protobuf:"varint,26,opt,name=Metric,proto3,enum=amp.Metric" json:"Metric,omitempty"`
-	SizeX       uint64  `protobuf:"varint,27,opt,name=SizeX,proto3" json:"SizeX,omitempty"`
-	SizeY       uint64  `protobuf:"varint,28,opt,name=SizeY,proto3" json:"SizeY,omitempty"`
-	SizeZ       uint64  `protobuf:"varint,29,opt,name=SizeZ,proto3" json:"SizeZ,omitempty"`
-	Tags        []*Tag  `protobuf:"bytes,32,rep,name=Tags,proto3" json:"Tags,omitempty"`
+func (m *AttrSchema) GetGoType() string {
+	if m != nil {
+		return m.GoType
+	}
+	return ""
 }
 
-func (m *Tag) Reset()      { *m = Tag{} }
-func (*Tag) ProtoMessage() {}
-func (*Tag) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{8}
+func (m *AttrSchema) GetFields() []*FieldSchema {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
 }
-func (m *Tag) XXX_Unmarshal(b []byte) error {
+
+// FieldSchema describes a single field of an element type.
+type FieldSchema struct {
+	Name     string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Number   int32  `protobuf:"varint,2,opt,name=Number,proto3" json:"Number,omitempty"`
+	Type     string `protobuf:"bytes,3,opt,name=Type,proto3" json:"Type,omitempty"`
+	TypeName string `protobuf:"bytes,4,opt,name=TypeName,proto3" json:"TypeName,omitempty"`
+	Repeated bool   `protobuf:"varint,5,opt,name=Repeated,proto3" json:"Repeated,omitempty"`
+}
+
+func (m *FieldSchema) Reset()      { *m = FieldSchema{} }
+func (*FieldSchema) ProtoMessage() {}
+func (*FieldSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{10}
+}
+func (m *FieldSchema) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Tag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FieldSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Tag.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FieldSchema.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1172,125 +1307,156 @@ func (m *Tag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Tag) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Tag.Merge(m, src)
+func (m *FieldSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldSchema.Merge(m, src)
 }
-func (m *Tag) XXX_Size() int {
+func (m *FieldSchema) XXX_Size() int {
 	return m.Size()
 }
-func (m *Tag) XXX_DiscardUnknown() {
-	xxx_messageInfo_Tag.DiscardUnknown(m)
+func (m *FieldSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldSchema.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Tag proto.InternalMessageInfo
+var xxx_messageInfo_FieldSchema proto.InternalMessageInfo
 
-func (m *Tag) GetTagID_0() int64 {
+func (m *FieldSchema) GetName() string {
 	if m != nil {
-		return m.TagID_0
+		return m.Name
 	}
-	return 0
+	return ""
 }
 
-func (m *Tag) GetTagID_1() uint64 {
+func (m *FieldSchema) GetNumber() int32 {
 	if m != nil {
-		return m.TagID_1
+		return m.Number
 	}
 	return 0
 }
 
-func (m *Tag) GetTagID_2() uint64 {
+func (m *FieldSchema) GetType() string {
 	if m != nil {
-		return m.TagID_2
+		return m.Type
 	}
-	return 0
+	return ""
 }
 
-func (m *Tag) GetUID() string {
+func (m *FieldSchema) GetTypeName() string {
 	if m != nil {
-		return m.UID
+		return m.TypeName
 	}
 	return ""
 }
 
-func (m *Tag) GetText() string {
+func (m *FieldSchema) GetRepeated() bool {
 	if m != nil {
-		return m.Text
+		return m.Repeated
 	}
-	return ""
+	return false
 }
 
-func (m *Tag) GetOrdering() float64 {
-	if m != nil {
-		return m.Ordering
-	}
-	return 0
+// AppSchema describes a registered amp.App.
+type AppSchema struct {
+	Spec         *Tag     `protobuf:"bytes,1,opt,name=Spec,proto3" json:"Spec,omitempty"`
+	Desc         string   `protobuf:"bytes,2,opt,name=Desc,proto3" json:"Desc,omitempty"`
+	Version      string   `protobuf:"bytes,3,opt,name=Version,proto3" json:"Version,omitempty"`
+	Invocations  []string `protobuf:"bytes,4,rep,name=Invocations,proto3" json:"Invocations,omitempty"`
+	Dependencies []*Tag   `protobuf:"bytes,5,rep,name=Dependencies,proto3" json:"Dependencies,omitempty"`
+	ContentTypes []string `protobuf:"bytes,6,rep,name=ContentTypes,proto3" json:"ContentTypes,omitempty"`
 }
 
-func (m *Tag) GetURL() string {
-	if m != nil {
-		return m.URL
+func (m *AppSchema) Reset()      { *m = AppSchema{} }
+func (*AppSchema) ProtoMessage() {}
+func (*AppSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{11}
+}
+func (m *AppSchema) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AppSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AppSchema.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return ""
+}
+func (m *AppSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppSchema.Merge(m, src)
+}
+func (m *AppSchema) XXX_Size() int {
+	return m.Size()
+}
+func (m *AppSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppSchema.DiscardUnknown(m)
 }
 
-func (m *Tag) GetContentType() string {
+var xxx_messageInfo_AppSchema proto.InternalMessageInfo
+
+func (m *AppSchema) GetSpec() *Tag {
 	if m != nil {
-		return m.ContentType
+		return m.Spec
 	}
-	return ""
+	return nil
 }
 
-func (m *Tag) GetMetric() Metric {
+func (m *AppSchema) GetDesc() string {
 	if m != nil {
-		return m.Metric
+		return m.Desc
 	}
-	return Metric_Nil
+	return ""
 }
 
-func (m *Tag) GetSizeX() uint64 {
+func (m *AppSchema) GetVersion() string {
 	if m != nil {
-		return m.SizeX
+		return m.Version
 	}
-	return 0
+	return ""
 }
 
-func (m *Tag) GetSizeY() uint64 {
+func (m *AppSchema) GetInvocations() []string {
 	if m != nil {
-		return m.SizeY
+		return m.Invocations
 	}
-	return 0
+	return nil
 }
 
-func (m *Tag) GetSizeZ() uint64 {
+func (m *AppSchema) GetDependencies() []*Tag {
 	if m != nil {
-		return m.SizeZ
+		return m.Dependencies
 	}
-	return 0
+	return nil
 }
 
-func (m *Tag) GetTags() []*Tag {
+func (m *AppSchema) GetContentTypes() []string {
 	if m != nil {
-		return m.Tags
+		return m.ContentTypes
 	}
 	return nil
 }
 
-type CryptoKey struct {
-	CryptoKitID CryptoKitID `protobuf:"varint,1,opt,name=CryptoKitID,proto3,enum=amp.CryptoKitID" json:"CryptoKitID,omitempty"`
-	KeyBytes    []byte      `protobuf:"bytes,4,opt,name=KeyBytes,proto3" json:"KeyBytes,omitempty"`
+// AppReloaded is a meta attribute sent to a session when a running app is replaced or removed -- see amp.ReloadApp()
+// A client should re-issue any pins it holds to this app since they have been closed.
+type AppReloaded struct {
+	Spec        *Tag   `protobuf:"bytes,1,opt,name=Spec,proto3" json:"Spec,omitempty"`
+	PrevVersion string `protobuf:"bytes,2,opt,name=PrevVersion,proto3" json:"PrevVersion,omitempty"`
+	Version     string `protobuf:"bytes,3,opt,name=Version,proto3" json:"Version,omitempty"`
 }
 
-func (m *CryptoKey) Reset()      { *m = CryptoKey{} }
-func (*CryptoKey) ProtoMessage() {}
-func (*CryptoKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{9}
+func (m *AppReloaded) Reset()      { *m = AppReloaded{} }
+func (*AppReloaded) ProtoMessage() {}
+func (*AppReloaded) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{12}
 }
-func (m *CryptoKey) XXX_Unmarshal(b []byte) error {
+func (m *AppReloaded) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CryptoKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AppReloaded) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CryptoKey.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AppReloaded.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1300,53 +1466,56 @@ func (m *CryptoKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *CryptoKey) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CryptoKey.Merge(m, src)
+func (m *AppReloaded) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppReloaded.Merge(m, src)
 }
-func (m *CryptoKey) XXX_Size() int {
+func (m *AppReloaded) XXX_Size() int {
 	return m.Size()
 }
-func (m *CryptoKey) XXX_DiscardUnknown() {
-	xxx_messageInfo_CryptoKey.DiscardUnknown(m)
+func (m *AppReloaded) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppReloaded.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CryptoKey proto.InternalMessageInfo
+var xxx_messageInfo_AppReloaded proto.InternalMessageInfo
 
-func (m *CryptoKey) GetCryptoKitID() CryptoKitID {
+func (m *AppReloaded) GetSpec() *Tag {
 	if m != nil {
-		return m.CryptoKitID
+		return m.Spec
 	}
-	return CryptoKit_Nil
+	return nil
 }
 
-func (m *CryptoKey) GetKeyBytes() []byte {
+func (m *AppReloaded) GetPrevVersion() string {
 	if m != nil {
-		return m.KeyBytes
+		return m.PrevVersion
 	}
-	return nil
+	return ""
 }
 
-// Err is a general purpose error / warning / log message.
-type Err struct {
-	// Identifies the type of error.
-	Code ErrCode `protobuf:"varint,1,opt,name=Code,proto3,enum=amp.ErrCode" json:"Code,omitempty"`
-	// Severity level
-	Level LogLevel `protobuf:"varint,2,opt,name=Level,proto3,enum=amp.LogLevel" json:"Level,omitempty"`
-	// human-readable info
-	Msg string `protobuf:"bytes,4,opt,name=Msg,proto3" json:"Msg,omitempty"`
+func (m *AppReloaded) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
 }
 
-func (m *Err) Reset()      { *m = Err{} }
-func (*Err) ProtoMessage() {}
-func (*Err) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7e479d288f92766f, []int{10}
+// DeltaResync is a meta attribute sent by the receiver of TxOpCode_UpsertDelta ops whose base revision it does not have -- see amp.ValueCache
+// The sender responds by sending the full value of each listed element.
+type DeltaResync struct {
+	Elements []uint64 `protobuf:"fixed64,1,rep,packed,name=Elements,proto3" json:"Elements,omitempty"`
 }
-func (m *Err) XXX_Unmarshal(b []byte) error {
+
+func (m *DeltaResync) Reset()      { *m = DeltaResync{} }
+func (*DeltaResync) ProtoMessage() {}
+func (*DeltaResync) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{13}
+}
+func (m *DeltaResync) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Err) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DeltaResync) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Err.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DeltaResync.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1356,866 +1525,951 @@ func (m *Err) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Err) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Err.Merge(m, src)
+func (m *DeltaResync) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeltaResync.Merge(m, src)
 }
-func (m *Err) XXX_Size() int {
+func (m *DeltaResync) XXX_Size() int {
 	return m.Size()
 }
-func (m *Err) XXX_DiscardUnknown() {
-	xxx_messageInfo_Err.DiscardUnknown(m)
+func (m *DeltaResync) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeltaResync.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Err proto.InternalMessageInfo
+var xxx_messageInfo_DeltaResync proto.InternalMessageInfo
 
-func (m *Err) GetCode() ErrCode {
+func (m *DeltaResync) GetElements() []uint64 {
 	if m != nil {
-		return m.Code
+		return m.Elements
 	}
-	return ErrCode_NoErr
+	return nil
 }
 
-func (m *Err) GetLevel() LogLevel {
-	if m != nil {
-		return m.Level
+// FlowCredit is a control meta attribute granting the peer additional bytes of interactive and bulk txs it may send -- see amp.TxScheduler
+type FlowCredit struct {
+	Bytes uint64 `protobuf:"varint,1,opt,name=Bytes,proto3" json:"Bytes,omitempty"`
+}
+
+func (m *FlowCredit) Reset()      { *m = FlowCredit{} }
+func (*FlowCredit) ProtoMessage() {}
+func (*FlowCredit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{14}
+}
+func (m *FlowCredit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FlowCredit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FlowCredit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return LogLevel_Error
+}
+func (m *FlowCredit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlowCredit.Merge(m, src)
+}
+func (m *FlowCredit) XXX_Size() int {
+	return m.Size()
+}
+func (m *FlowCredit) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlowCredit.DiscardUnknown(m)
 }
 
-func (m *Err) GetMsg() string {
+var xxx_messageInfo_FlowCredit proto.InternalMessageInfo
+
+func (m *FlowCredit) GetBytes() uint64 {
 	if m != nil {
-		return m.Msg
+		return m.Bytes
 	}
-	return ""
+	return 0
 }
 
-func init() {
-	proto.RegisterEnum("amp.Const", Const_name, Const_value)
-	proto.RegisterEnum("amp.TxOpCode", TxOpCode_name, TxOpCode_value)
-	proto.RegisterEnum("amp.TxField", TxField_name, TxField_value)
-	proto.RegisterEnum("amp.SelectOp", SelectOp_name, SelectOp_value)
-	proto.RegisterEnum("amp.OpStatus", OpStatus_name, OpStatus_value)
-	proto.RegisterEnum("amp.StateSync", StateSync_name, StateSync_value)
-	proto.RegisterEnum("amp.Enable", Enable_name, Enable_value)
-	proto.RegisterEnum("amp.UrlScheme", UrlScheme_name, UrlScheme_value)
-	proto.RegisterEnum("amp.Metric", Metric_name, Metric_value)
-	proto.RegisterEnum("amp.CryptoKitID", CryptoKitID_name, CryptoKitID_value)
-	proto.RegisterEnum("amp.ErrCode", ErrCode_name, ErrCode_value)
-	proto.RegisterEnum("amp.LogLevel", LogLevel_name, LogLevel_value)
-	proto.RegisterType((*TxEnvelope)(nil), "amp.TxEnvelope")
-	proto.RegisterType((*Login)(nil), "amp.Login")
-	proto.RegisterType((*LoginChallenge)(nil), "amp.LoginChallenge")
-	proto.RegisterType((*LoginResponse)(nil), "amp.LoginResponse")
-	proto.RegisterType((*LoginCheckpoint)(nil), "amp.LoginCheckpoint")
-	proto.RegisterType((*PinRequest)(nil), "amp.PinRequest")
-	proto.RegisterType((*LaunchURL)(nil), "amp.LaunchURL")
-	proto.RegisterType((*TagUID)(nil), "amp.TagUID")
-	proto.RegisterType((*Tag)(nil), "amp.Tag")
-	proto.RegisterType((*CryptoKey)(nil), "amp.CryptoKey")
-	proto.RegisterType((*Err)(nil), "amp.Err")
+// TagUID is a minimal wrapper for a tag.ID
+type TagUID struct {
+	ID_0 int64  `protobuf:"varint,2,opt,name=ID_0,json=ID0,proto3" json:"ID_0,omitempty"`
+	ID_1 uint64 `protobuf:"fixed64,3,opt,name=ID_1,json=ID1,proto3" json:"ID_1,omitempty"`
+	ID_2 uint64 `protobuf:"fixed64,4,opt,name=ID_2,json=ID2,proto3" json:"ID_2,omitempty"`
+	UID  string `protobuf:"bytes,6,opt,name=UID,proto3" json:"UID,omitempty"`
 }
 
-func init() { proto.RegisterFile("amp/amp.proto", fileDescriptor_7e479d288f92766f) }
-
-var fileDescriptor_7e479d288f92766f = []byte{
-	// 2038 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x98, 0x5b, 0x6f, 0x1b, 0xc7,
-	0x15, 0xc7, 0xb5, 0xa2, 0x44, 0x89, 0xa3, 0x8b, 0x47, 0x63, 0xc9, 0xde, 0x38, 0x32, 0x23, 0x30,
-	0x4e, 0x29, 0x10, 0x71, 0x6c, 0xd2, 0xcd, 0x43, 0x1f, 0x25, 0x92, 0xb6, 0x89, 0xe8, 0x86, 0x25,
-	0xe5, 0x36, 0x2e, 0x10, 0x62, 0xcc, 0x3d, 0x5c, 0x2e, 0xbc, 0x9c, 0xd9, 0xce, 0x0e, 0x55, 0xd2,
-	0x4f, 0x7d, 0x29, 0x90, 0xde, 0xd3, 0x3c, 0x14, 0x28, 0xd0, 0x4b, 0x5a, 0xa0, 0x6d, 0x9a, 0xa7,
-	0x7e, 0x80, 0xa6, 0x05, 0x5a, 0x14, 0x08, 0x5a, 0x14, 0xf0, 0x63, 0x90, 0xa7, 0x5a, 0x7e, 0xe9,
-	0x43, 0x8b, 0xfa, 0x23, 0x14, 0x33, 0x7b, 0xe1, 0x2e, 0xad, 0xb7, 0x33, 0xbf, 0xff, 0x99, 0x33,
-	0x73, 0xce, 0xcc, 0x9c, 0xa5, 0x84, 0xd6, 0xe8, 0xd0, 0xbf, 0x45, 0x87, 0xfe, 0x5b, 0xbe, 0xe0,
-	0x92, 0x93, 0x1c, 0x1d, 0xfa, 0xa5, 0x9f, 0xe6, 0x10, 0xea, 0x8c, 0x9b, 0xec, 0x0c, 0x3c, 0xee,
-	0x03, 0x79, 0x03, 0xe5, 0xdb, 0x92, 0xca, 0x51, 0x60, 0xce, 0xef, 0x18, 0xbb, 0xeb, 0xb5, 0xb5,
-	0xb7, 0x94, 0xff, 0xb1, 0x1f, 0x42, 0x2b, 0x12, 0x89, 0x89, 0x96, 0x8e, 0xfd, 0x3a, 0x1f, 0x31,
-	0x69, 0x2e, 0xec, 0x18, 0xbb, 0x0b, 0x56, 0x3c, 0x24, 0xaf, 0xa1, 0x95, 0x7b, 0xc0, 0x20, 0x70,
-	0x83, 0x56, 0xa3, 0x7b, 0xdb, 0x5c, 0xdc, 0x31, 0x76, 0x73, 0x16, 0x4a, 0xd0, 0xed, 0xac, 0x43,
-	0xd5, 0xcc, 0xef, 0x18, 0xbb, 0xf9, 0x94, 0x43, 0x35, 0xeb, 0x50, 0x33, 0x97, 0x66, 0x1c, 0x6a,
-	0xca, 0xa1, 0xce, 0x99, 0x84, 0xb1, 0xd4, 0x4b, 0xa0, 0x70, 0x89, 0x04, 0xdd, 0xce, 0x3a, 0x54,
-	0xcd, 0x95, 0x30, 0x42, 0x82, 0xaa, 0x59, 0x87, 0x9a, 0xb9, 0x3a, 0xe3, 0x50, 0x23, 0x3b, 0x28,
-	0x7f, 0x57, 0xf0, 0x61, 0xab, 0x61, 0xae, 0xef, 0x18, 0xbb, 0x2b, 0xb5, 0x65, 0x5d, 0x86, 0x0e,
-	0x75, 0xac, 0x88, 0x93, 0x6d, 0xb4, 0xd0, 0xe1, 0xad, 0x86, 0x79, 0x69, 0x46, 0xd7, 0x54, 0xab,
-	0xd4, 0x09, 0x4c, 0xfc, 0x92, 0x4a, 0x9d, 0x80, 0x7c, 0x09, 0x15, 0xa2, 0xb5, 0xea, 0x7b, 0xe6,
-	0xc6, 0x8c, 0xcb, 0x54, 0x2a, 0xfd, 0xcf, 0x40, 0x8b, 0x07, 0xdc, 0x71, 0x19, 0xd9, 0x46, 0x85,
-	0xd3, 0x00, 0xc4, 0x01, 0x7d, 0x04, 0x9e, 0x69, 0xec, 0x18, 0xbb, 0x05, 0x6b, 0x0a, 0x48, 0x09,
-	0x2d, 0xa9, 0xc1, 0x69, 0xab, 0xa1, 0x4f, 0x2d, 0x1d, 0x2d, 0x16, 0x54, 0x84, 0x06, 0x9c, 0xb9,
-	0x3d, 0x50, 0x5e, 0x8b, 0x61, 0x84, 0x04, 0x90, 0x1d, 0xb4, 0x12, 0x0e, 0xc2, 0x15, 0xf2, 0x5a,
-	0x4f, 0x23, 0x72, 0x0d, 0x2d, 0xdf, 0xe7, 0x81, 0xdc, 0xb3, 0x6d, 0x61, 0x2e, 0x6b, 0x39, 0x19,
-	0x13, 0x12, 0x65, 0x5b, 0xd0, 0x3c, 0xcc, 0xf1, 0xcb, 0x08, 0xd5, 0x07, 0xd0, 0x7b, 0xec, 0x73,
-	0x97, 0x49, 0x5d, 0xe1, 0x95, 0xda, 0xa6, 0xde, 0x96, 0xce, 0x68, 0xaa, 0x59, 0x29, 0xbf, 0xd2,
-	0x0d, 0xb4, 0x1e, 0xc9, 0xd4, 0xf3, 0x80, 0x39, 0xa0, 0x62, 0xdf, 0xa7, 0xc1, 0x40, 0x27, 0xbd,
-	0x6a, 0x69, 0xbb, 0x74, 0x07, 0xad, 0x69, 0x2f, 0x0b, 0x02, 0x9f, 0xb3, 0x00, 0x48, 0x09, 0xad,
-	0x2a, 0x21, 0x1e, 0x47, 0xce, 0x19, 0x56, 0xfa, 0xa7, 0x81, 0x2e, 0xcd, 0x2c, 0xad, 0x8a, 0xd2,
-	0xe1, 0x8f, 0x81, 0x75, 0x26, 0x3e, 0xc4, 0x65, 0x4d, 0x80, 0x2a, 0xca, 0x5e, 0xaf, 0x07, 0x41,
-	0xa0, 0x91, 0x2e, 0x6d, 0xc1, 0x4a, 0x23, 0xb5, 0xae, 0x05, 0x7d, 0x01, 0xc1, 0x20, 0x74, 0xc9,
-	0x69, 0x97, 0x0c, 0x23, 0x57, 0x50, 0xbe, 0x39, 0xf6, 0x5d, 0x31, 0xd1, 0x2f, 0x25, 0x67, 0x45,
-	0xa3, 0xa4, 0x68, 0x28, 0x55, 0x34, 0x73, 0x7a, 0x90, 0x2b, 0x1a, 0x27, 0xc7, 0x87, 0x51, 0xee,
-	0xd4, 0x6a, 0xe9, 0x3a, 0x16, 0x2c, 0x65, 0x96, 0xde, 0x37, 0x10, 0x3a, 0x51, 0x35, 0xf8, 0xc6,
-	0x08, 0x02, 0xa9, 0xee, 0xd4, 0x89, 0xcb, 0x3a, 0x54, 0x38, 0x20, 0x5f, 0xba, 0x05, 0x53, 0x89,
-	0xdc, 0x40, 0xcb, 0x27, 0x2e, 0xdb, 0x93, 0x52, 0x04, 0xe6, 0xc2, 0x4e, 0x2e, 0xe3, 0x96, 0x28,
-	0xe4, 0x4d, 0x54, 0x50, 0x2f, 0x1d, 0xda, 0x13, 0xd6, 0xd3, 0xb7, 0x61, 0xbd, 0xb6, 0xae, 0xdd,
-	0x12, 0x6a, 0x4d, 0x1d, 0x4a, 0xd7, 0x51, 0xe1, 0x80, 0x8e, 0x58, 0x6f, 0x70, 0x6a, 0x1d, 0x84,
-	0x3b, 0x3d, 0x88, 0xaa, 0xa9, 0xcc, 0x52, 0x1b, 0xe5, 0x3b, 0xd4, 0x51, 0x59, 0x6c, 0xa0, 0x05,
-	0xfd, 0x64, 0xe7, 0x75, 0x25, 0x72, 0xea, 0xad, 0x86, 0xa8, 0xaa, 0x4b, 0x97, 0x57, 0xa8, 0x1a,
-	0xa1, 0x9a, 0xae, 0x97, 0x46, 0x35, 0x1d, 0xb4, 0xd5, 0x88, 0xee, 0xa5, 0x32, 0x4b, 0xff, 0x98,
-	0x47, 0xb9, 0x0e, 0x75, 0xc8, 0x55, 0xb4, 0xd4, 0xa1, 0x4e, 0x2a, 0x6a, 0x5e, 0x0f, 0x6f, 0x4f,
-	0x85, 0x38, 0x76, 0x28, 0x54, 0xa7, 0x42, 0xbc, 0x42, 0x28, 0x5c, 0xb0, 0x88, 0x3e, 0x23, 0x18,
-	0x4b, 0xdd, 0x83, 0xd4, 0x19, 0xc1, 0x58, 0xaa, 0x87, 0x70, 0x2c, 0x6c, 0x10, 0x2e, 0x73, 0xf4,
-	0x85, 0x37, 0xac, 0x64, 0x1c, 0xe7, 0xbe, 0x96, 0xe4, 0xae, 0xee, 0x90, 0x7e, 0xcf, 0x4c, 0xea,
-	0x3b, 0xb6, 0x1e, 0xde, 0xa1, 0x14, 0x22, 0xaf, 0xa3, 0xfc, 0x21, 0x48, 0xe1, 0xf6, 0xcc, 0x6b,
-	0xba, 0xce, 0x2b, 0xba, 0xce, 0x21, 0xb2, 0x22, 0x89, 0x6c, 0xa2, 0xc5, 0xb6, 0xfb, 0x04, 0xbe,
-	0x66, 0xbe, 0xaa, 0xbb, 0x6d, 0x38, 0x88, 0xe9, 0xbb, 0xe6, 0xf6, 0x94, 0xbe, 0x1b, 0xd3, 0x87,
-	0xe6, 0xf5, 0x29, 0x7d, 0x98, 0x74, 0xa4, 0x9d, 0x99, 0x33, 0xd7, 0xb4, 0xf4, 0x75, 0x54, 0xa8,
-	0x8b, 0x89, 0x2f, 0xf9, 0x3b, 0x30, 0x21, 0x35, 0xb4, 0x12, 0x0d, 0x5c, 0xd9, 0x6a, 0xe8, 0x93,
-	0x5c, 0xaf, 0x61, 0x3d, 0x23, 0xc5, 0xad, 0xb4, 0x93, 0xaa, 0xca, 0x3b, 0x30, 0xd9, 0x9f, 0x48,
-	0x08, 0x74, 0x55, 0x57, 0xad, 0x64, 0x5c, 0x7a, 0x0f, 0xe5, 0x9a, 0x42, 0x90, 0x1d, 0xb4, 0x50,
-	0xe7, 0x36, 0x44, 0xf1, 0x56, 0x75, 0xbc, 0xa6, 0x10, 0x8a, 0x59, 0x5a, 0x21, 0xaf, 0xa3, 0xc5,
-	0x03, 0x38, 0x03, 0x2f, 0xf3, 0xed, 0x39, 0xe0, 0x8e, 0x86, 0x56, 0xa8, 0xa9, 0x1a, 0x1f, 0x06,
-	0x8e, 0x5e, 0xa4, 0x60, 0x29, 0xb3, 0xf2, 0x91, 0x81, 0x16, 0xeb, 0x9c, 0x05, 0x92, 0xac, 0x23,
-	0xa4, 0x8d, 0x6e, 0x03, 0xfa, 0x01, 0x9e, 0x23, 0xd7, 0x91, 0x99, 0x8c, 0xe9, 0xc8, 0x93, 0x6d,
-	0x10, 0xaa, 0xa3, 0x9d, 0x70, 0x21, 0xf1, 0x67, 0xbb, 0xe4, 0x2a, 0xba, 0x1c, 0xca, 0x9d, 0xf1,
-	0x7d, 0xa0, 0x36, 0x88, 0xae, 0xaa, 0x15, 0xc6, 0xe4, 0x1a, 0xba, 0x32, 0x23, 0x3c, 0x00, 0x11,
-	0xb8, 0x9c, 0xe1, 0x3b, 0x64, 0x1b, 0x6d, 0xcd, 0x68, 0x87, 0x54, 0x3c, 0x06, 0x81, 0x5f, 0x7c,
-	0xf1, 0xed, 0x1c, 0xd9, 0x42, 0x38, 0x54, 0x5b, 0xec, 0x8c, 0xf7, 0xa8, 0x54, 0x73, 0x3e, 0xbd,
-	0x5e, 0xe9, 0xa0, 0xe5, 0xce, 0x58, 0x7d, 0x22, 0x6d, 0x20, 0x18, 0xad, 0xc6, 0x76, 0xf7, 0xc8,
-	0xf5, 0xf0, 0x9c, 0x5a, 0x2e, 0x21, 0xa7, 0x7e, 0x00, 0x42, 0x36, 0x3d, 0x18, 0x02, 0x93, 0x78,
-	0x3e, 0xa3, 0x35, 0xc0, 0x03, 0x09, 0xb1, 0xb6, 0x50, 0x79, 0x3a, 0x8f, 0x96, 0x3a, 0xe3, 0xbb,
-	0x2e, 0x78, 0x36, 0xb9, 0x84, 0x56, 0x22, 0x33, 0x0a, 0xba, 0x89, 0x70, 0x0c, 0xea, 0xe0, 0x79,
-	0xea, 0x85, 0x60, 0xe3, 0x02, 0x5a, 0xc5, 0xf3, 0x17, 0xd0, 0x1a, 0xce, 0xa5, 0xa9, 0xea, 0x0a,
-	0x3a, 0xc2, 0xc2, 0x05, 0xb4, 0x8a, 0x17, 0x2f, 0xa0, 0x35, 0x9c, 0x4f, 0xd3, 0x96, 0x84, 0xa1,
-	0x8e, 0xb0, 0x74, 0x01, 0xad, 0xe2, 0xe5, 0x0b, 0x68, 0x0d, 0x17, 0xd2, 0xb4, 0x69, 0xbb, 0xfa,
-	0x83, 0x8f, 0xd1, 0x05, 0xb4, 0x8a, 0x57, 0x2e, 0xa0, 0x35, 0xbc, 0x4a, 0xb6, 0xd0, 0x46, 0x52,
-	0x98, 0xd1, 0x50, 0x1b, 0x01, 0x5e, 0x4b, 0xe3, 0x43, 0x3a, 0x8e, 0xb0, 0x59, 0x39, 0x40, 0xcb,
-	0x6d, 0xf0, 0xa0, 0x27, 0x8f, 0x7d, 0x15, 0x2f, 0xb6, 0xbb, 0x47, 0x30, 0x92, 0x82, 0x46, 0x75,
-	0x4d, 0x68, 0x8b, 0xf5, 0xbc, 0x91, 0x0d, 0x61, 0x5d, 0x13, 0xda, 0x1c, 0x87, 0x74, 0xbe, 0x72,
-	0x86, 0x96, 0xe3, 0x9f, 0x4e, 0xea, 0xb2, 0xc5, 0x76, 0xf7, 0x88, 0xcb, 0xb6, 0xa4, 0x42, 0x82,
-	0x1d, 0x06, 0x4c, 0x04, 0xd5, 0x4e, 0x5d, 0xe6, 0x60, 0x83, 0x6c, 0xa0, 0xb5, 0x84, 0xee, 0x8f,
-	0x82, 0x09, 0x9e, 0x27, 0x97, 0xd1, 0xa5, 0x8c, 0x23, 0xd8, 0x38, 0x97, 0x81, 0x75, 0x8f, 0x07,
-	0x60, 0xe3, 0x37, 0x2a, 0x56, 0xaa, 0x7d, 0x13, 0x82, 0xd6, 0x93, 0x41, 0xf7, 0x88, 0x33, 0xc0,
-	0x73, 0xe4, 0x15, 0xb4, 0x35, 0x65, 0x7a, 0xda, 0x31, 0x53, 0x36, 0x36, 0xc8, 0x15, 0x44, 0xa6,
-	0xd2, 0x21, 0x75, 0x99, 0xa4, 0x2e, 0xc3, 0xf3, 0x95, 0xf7, 0x50, 0xbe, 0xc9, 0xe8, 0x23, 0x0f,
-	0xd4, 0x86, 0x43, 0xab, 0x7b, 0x40, 0x55, 0x1b, 0x3b, 0xee, 0xf7, 0xf1, 0x9c, 0xda, 0x48, 0x96,
-	0x32, 0x6c, 0xa4, 0xe0, 0x5e, 0x4f, 0xba, 0x67, 0x70, 0xcc, 0xc2, 0xdb, 0x96, 0x85, 0xfd, 0x3e,
-	0xce, 0x55, 0xbe, 0x30, 0x50, 0xe1, 0x54, 0x78, 0xed, 0xde, 0x00, 0x86, 0xa0, 0xd2, 0x4f, 0x06,
-	0xd3, 0x57, 0x32, 0x45, 0xa7, 0x4c, 0x40, 0x8f, 0x3b, 0xcc, 0x7d, 0x02, 0x36, 0x36, 0x54, 0x8e,
-	0x53, 0xed, 0xbe, 0x94, 0x3e, 0x9e, 0xcf, 0xb2, 0x06, 0x95, 0x14, 0xe7, 0xb2, 0xec, 0xae, 0xeb,
-	0x01, 0x5e, 0xc8, 0x2e, 0xb5, 0x37, 0xf4, 0xf1, 0x52, 0xd6, 0xad, 0xe5, 0xf7, 0x03, 0xbc, 0x31,
-	0xcb, 0x58, 0x80, 0x89, 0xca, 0x64, 0xca, 0x0e, 0xa9, 0xc3, 0x40, 0xe2, 0xcb, 0xd9, 0x80, 0xf7,
-	0x5c, 0x89, 0x37, 0x2b, 0x7f, 0x33, 0xe2, 0x2e, 0xaf, 0x7a, 0x54, 0x68, 0x45, 0x69, 0x6d, 0xa1,
-	0x8d, 0x68, 0x7c, 0x2c, 0xe4, 0x80, 0x9f, 0xb8, 0x63, 0xf0, 0xb0, 0x31, 0x8b, 0x0f, 0x41, 0x82,
-	0x08, 0xdb, 0x41, 0x06, 0xbb, 0x9e, 0xe7, 0x0e, 0xb5, 0x96, 0x53, 0x87, 0x9a, 0xd6, 0x8e, 0x28,
-	0xe3, 0xa1, 0xb4, 0x40, 0xb6, 0x91, 0x19, 0x49, 0xf7, 0x61, 0x7c, 0x4f, 0xb8, 0x76, 0x6a, 0xe2,
-	0x22, 0xd9, 0x45, 0x37, 0x22, 0xb5, 0x23, 0xa8, 0x0f, 0x4f, 0x78, 0x83, 0xdb, 0xd0, 0xa3, 0x03,
-	0xb0, 0x05, 0x67, 0x29, 0xcf, 0x7c, 0xe5, 0x27, 0x46, 0xe6, 0xdb, 0xa0, 0x52, 0x4d, 0x86, 0x51,
-	0x3e, 0xdb, 0xc8, 0x9c, 0xa2, 0x36, 0xf4, 0x04, 0xc8, 0x7d, 0x3e, 0xee, 0x1e, 0xd1, 0xba, 0x87,
-	0x6d, 0xdd, 0x59, 0x13, 0x75, 0x2f, 0x98, 0x0c, 0x0f, 0x03, 0x27, 0xd4, 0x20, 0xab, 0xb5, 0x5d,
-	0x87, 0xb9, 0x2c, 0xd2, 0xfa, 0xa4, 0x88, 0x5e, 0x79, 0x59, 0x6b, 0x36, 0x6a, 0x6f, 0xbf, 0x5d,
-	0xfd, 0x0a, 0xfe, 0xbb, 0x51, 0xf9, 0x70, 0x09, 0x2d, 0x45, 0x1f, 0x13, 0xb5, 0xa9, 0xc8, 0xec,
-	0x1e, 0xf1, 0xa6, 0x10, 0x78, 0x8e, 0x5c, 0x45, 0x24, 0x46, 0xa7, 0x8c, 0xd1, 0x21, 0xd8, 0x8a,
-	0xbf, 0x5f, 0x26, 0x26, 0xba, 0x1c, 0x0b, 0x2d, 0x26, 0x41, 0x30, 0xea, 0x29, 0xe5, 0x3b, 0x65,
-	0x72, 0x0d, 0x6d, 0x4d, 0xa7, 0x04, 0x23, 0xdf, 0xe7, 0xea, 0xbd, 0x1e, 0xfb, 0xf8, 0xbb, 0x33,
-	0x9a, 0x3b, 0xf4, 0xc3, 0x8e, 0x0c, 0x36, 0xfe, 0x5e, 0x99, 0x6c, 0xa2, 0x4b, 0xb1, 0xd6, 0x71,
-	0x87, 0xc0, 0x47, 0x12, 0x7f, 0xbf, 0x4c, 0x5e, 0x41, 0x9b, 0x31, 0x6d, 0x0f, 0x46, 0x52, 0xba,
-	0xcc, 0x69, 0xf0, 0x6f, 0x32, 0xfc, 0x83, 0x8c, 0x74, 0xc4, 0x65, 0x9d, 0x33, 0x06, 0x3d, 0x15,
-	0xeb, 0x87, 0xe5, 0xf4, 0xb6, 0xf7, 0x46, 0x72, 0x70, 0x97, 0xba, 0x1e, 0xd8, 0xf8, 0x47, 0x99,
-	0x6d, 0xeb, 0xdf, 0xb4, 0x91, 0xf2, 0x41, 0x99, 0xbc, 0x8a, 0xae, 0x24, 0x0b, 0x41, 0xa0, 0xbe,
-	0x59, 0xfa, 0xf7, 0x26, 0xd8, 0xf8, 0xc7, 0x65, 0xf5, 0x75, 0x4a, 0x2d, 0x65, 0x01, 0xb5, 0x27,
-	0xf8, 0xc3, 0x32, 0xd9, 0x46, 0x57, 0x63, 0x1c, 0xfd, 0x9c, 0x3c, 0xe2, 0xf2, 0x2e, 0x1f, 0x31,
-	0x1b, 0xff, 0x2c, 0x93, 0x6c, 0xa4, 0x46, 0x7d, 0xe6, 0xe7, 0x99, 0x0d, 0xee, 0x53, 0x3b, 0x92,
-	0xf1, 0x2f, 0x32, 0x42, 0x8b, 0x9d, 0x51, 0xcf, 0xb5, 0x4f, 0xad, 0x16, 0xfe, 0x65, 0x66, 0x0b,
-	0xfb, 0xd4, 0x7e, 0x40, 0xbd, 0x11, 0xe0, 0x8f, 0x2e, 0xf2, 0xef, 0x50, 0x07, 0xff, 0x2a, 0x93,
-	0xcf, 0x54, 0x68, 0xfb, 0xd0, 0xc3, 0xbf, 0xce, 0x94, 0x4e, 0x7d, 0x75, 0x92, 0x5d, 0xff, 0x26,
-	0x93, 0xd3, 0x11, 0x97, 0x03, 0x97, 0x39, 0x1d, 0x5e, 0xe7, 0xc3, 0xa1, 0x2b, 0xf1, 0x6f, 0x33,
-	0x13, 0x43, 0x18, 0x15, 0xf0, 0x77, 0x99, 0x74, 0xdb, 0x3e, 0xed, 0x41, 0x12, 0xf4, 0xe3, 0x6c,
-	0x71, 0x25, 0x17, 0xd4, 0x01, 0x35, 0x6f, 0x24, 0x00, 0xff, 0x3e, 0x73, 0x26, 0x7b, 0xbe, 0x9f,
-	0x4c, 0xfb, 0x24, 0xa3, 0x1c, 0x52, 0xaf, 0xcf, 0xc5, 0x10, 0xec, 0xce, 0x18, 0xff, 0xa1, 0x4c,
-	0xae, 0xa0, 0x8d, 0x54, 0x35, 0x74, 0xcb, 0xa0, 0xf8, 0x8f, 0x99, 0x19, 0xaa, 0x73, 0xc5, 0xab,
-	0x7c, 0x9a, 0x99, 0xd1, 0x1c, 0xab, 0x3b, 0xa9, 0xae, 0xeb, 0x9f, 0x32, 0xfc, 0x24, 0xb9, 0x0f,
-	0x7f, 0xce, 0x66, 0x0a, 0x9e, 0x97, 0x6c, 0xeb, 0x2f, 0x99, 0x45, 0x4e, 0x04, 0x3f, 0x73, 0x6d,
-	0x10, 0x2a, 0xd8, 0x5f, 0xcb, 0xe4, 0x35, 0x74, 0x2d, 0x56, 0x1e, 0xb8, 0xdc, 0xa3, 0x12, 0x82,
-	0x3d, 0xdf, 0x07, 0x66, 0x1f, 0x33, 0x6f, 0x82, 0xff, 0x53, 0x26, 0x37, 0xd0, 0x6b, 0xd3, 0x53,
-	0x09, 0x46, 0xfd, 0xbe, 0xdb, 0x73, 0x81, 0xc9, 0x13, 0x10, 0x43, 0x57, 0x5f, 0xba, 0x00, 0xff,
-	0xb7, 0x5c, 0x69, 0xa0, 0xe5, 0xf8, 0xd7, 0x9b, 0x6a, 0x9f, 0xb1, 0xdd, 0x6d, 0x0a, 0xc1, 0xd5,
-	0xab, 0xdc, 0xd0, 0x7f, 0xc7, 0x85, 0xec, 0xab, 0x54, 0xa8, 0x6f, 0x43, 0x1a, 0xb5, 0x58, 0x9f,
-	0xe3, 0x85, 0xfd, 0xc1, 0xd3, 0x67, 0xc5, 0xb9, 0xcf, 0x9f, 0x15, 0xe7, 0x5e, 0x3c, 0x2b, 0x1a,
-	0xdf, 0x3a, 0x2f, 0x1a, 0x1f, 0x9f, 0x17, 0x8d, 0xcf, 0xce, 0x8b, 0xc6, 0xd3, 0xf3, 0xa2, 0xf1,
-	0xaf, 0xf3, 0xa2, 0xf1, 0xef, 0xf3, 0xe2, 0xdc, 0x8b, 0xf3, 0xa2, 0xf1, 0xc1, 0xf3, 0xe2, 0xdc,
-	0xd3, 0xe7, 0xc5, 0xb9, 0xcf, 0x9f, 0x17, 0xe7, 0x1e, 0xbe, 0xe9, 0xb8, 0x72, 0x30, 0x7a, 0xf4,
-	0x56, 0x8f, 0x0f, 0x6f, 0x51, 0x21, 0x6f, 0x0e, 0xc1, 0x76, 0xe9, 0x4d, 0xdf, 0xa3, 0x52, 0xd5,
-	0xff, 0x16, 0x1d, 0xfa, 0x37, 0x03, 0xfb, 0xf1, 0x4d, 0x87, 0x2b, 0xf3, 0x93, 0xf9, 0xdc, 0xde,
-	0xe1, 0xc9, 0xa3, 0xbc, 0xfe, 0xbf, 0xc8, 0x9d, 0xff, 0x07, 0x00, 0x00, 0xff, 0xff, 0x4b, 0xe5,
-	0x5b, 0x63, 0x28, 0x11, 0x00, 0x00,
+func (m *TagUID) Reset()      { *m = TagUID{} }
+func (*TagUID) ProtoMessage() {}
+func (*TagUID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{15}
 }
-
-func (x Const) String() string {
-	s, ok := Const_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+func (m *TagUID) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (x TxOpCode) String() string {
-	s, ok := TxOpCode_name[int32(x)]
-	if ok {
-		return s
+func (m *TagUID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TagUID.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return strconv.Itoa(int(x))
 }
-func (x TxField) String() string {
-	s, ok := TxField_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+func (m *TagUID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagUID.Merge(m, src)
 }
-func (x SelectOp) String() string {
-	s, ok := SelectOp_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+func (m *TagUID) XXX_Size() int {
+	return m.Size()
 }
-func (x OpStatus) String() string {
-	s, ok := OpStatus_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+func (m *TagUID) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagUID.DiscardUnknown(m)
 }
-func (x StateSync) String() string {
-	s, ok := StateSync_name[int32(x)]
-	if ok {
-		return s
+
+var xxx_messageInfo_TagUID proto.InternalMessageInfo
+
+func (m *TagUID) GetID_0() int64 {
+	if m != nil {
+		return m.ID_0
 	}
-	return strconv.Itoa(int(x))
+	return 0
 }
-func (x Enable) String() string {
-	s, ok := Enable_name[int32(x)]
-	if ok {
-		return s
+
+func (m *TagUID) GetID_1() uint64 {
+	if m != nil {
+		return m.ID_1
 	}
-	return strconv.Itoa(int(x))
+	return 0
 }
-func (x UrlScheme) String() string {
-	s, ok := UrlScheme_name[int32(x)]
-	if ok {
-		return s
+
+func (m *TagUID) GetID_2() uint64 {
+	if m != nil {
+		return m.ID_2
 	}
-	return strconv.Itoa(int(x))
+	return 0
 }
-func (x Metric) String() string {
-	s, ok := Metric_name[int32(x)]
-	if ok {
-		return s
+
+func (m *TagUID) GetUID() string {
+	if m != nil {
+		return m.UID
 	}
-	return strconv.Itoa(int(x))
+	return ""
 }
-func (x CryptoKitID) String() string {
-	s, ok := CryptoKitID_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+
+// Tag is a multi-use workhorse. All fields are optional and whose meaning is contextual.
+//
+// Often used to reference an asset, a Link can reference any resource, a show, project, episode, or XR beacon.
+// The tagging naming convention describes a semi-ordered list of UTF tags.
+//
+//	As tags first appear when going from left to right in the list, they are considered "more significant" or "higher priority" than tags that appear later.
+//	It is up to amp-search-dev-tag-specification to order search results based on tag filters (case sensitive, time ranges, or any UTF8 enum identifier)
+//	By convention, tags are case sensitive by default, however there are many filter presets -- This is how people "type or speak search"
+//	"Two tag rule" -- if you can think of two or more other tags in an order ranking, then do that instead.
+type Tag struct {
+	TagID_0     int64   `protobuf:"varint,2,opt,name=TagID_0,json=TagID0,proto3" json:"TagID_0,omitempty"`
+	TagID_1     uint64  `protobuf:"fixed64,3,opt,name=TagID_1,json=TagID1,proto3" json:"TagID_1,omitempty"`
+	TagID_2     uint64  `protobuf:"fixed64,4,opt,name=TagID_2,json=TagID2,proto3" json:"TagID_2,omitempty"`
+	UID         string  `protobuf:"bytes,6,opt,name=UID,proto3" json:"UID,omitempty"`
+	Text        string  `protobuf:"bytes,7,opt,name=Text,proto3" json:"Text,omitempty"`
+	Ordering    float64 `protobuf:"fixed64,9,opt,name=Ordering,proto3" json:"Ordering,omitempty"`
+	URL         string  `protobuf:"bytes,13,opt,name=URL,proto3" json:"URL,omitempty"`
+	ContentType string  `protobuf:"bytes,14,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	Metric      Metric  `protobuf:"varint,26,opt,name=Metric,proto3,enum=amp.Metric" json:"Metric,omitempty"`
+	SizeX       uint64  `protobuf:"varint,27,opt,name=SizeX,proto3" json:"SizeX,omitempty"`
+	SizeY       uint64  `protobuf:"varint,28,opt,name=SizeY,proto3" json:"SizeY,omitempty"`
+	SizeZ       uint64  `protobuf:"varint,29,opt,name=SizeZ,proto3" json:"SizeZ,omitempty"`
+	Tags        []*Tag  `protobuf:"bytes,32,rep,name=Tags,proto3" json:"Tags,omitempty"`
 }
-func (x ErrCode) String() string {
-	s, ok := ErrCode_name[int32(x)]
-	if ok {
-		return s
+
+func (m *Tag) Reset()      { *m = Tag{} }
+func (*Tag) ProtoMessage() {}
+func (*Tag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{16}
+}
+func (m *Tag) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Tag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Tag.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return strconv.Itoa(int(x))
 }
-func (x LogLevel) String() string {
-	s, ok := LogLevel_name[int32(x)]
-	if ok {
-		return s
+func (m *Tag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tag.Merge(m, src)
+}
+func (m *Tag) XXX_Size() int {
+	return m.Size()
+}
+func (m *Tag) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tag proto.InternalMessageInfo
+
+func (m *Tag) GetTagID_0() int64 {
+	if m != nil {
+		return m.TagID_0
 	}
-	return strconv.Itoa(int(x))
+	return 0
 }
-func (this *TxEnvelope) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *Tag) GetTagID_1() uint64 {
+	if m != nil {
+		return m.TagID_1
 	}
+	return 0
+}
 
-	that1, ok := that.(*TxEnvelope)
-	if !ok {
-		that2, ok := that.(TxEnvelope)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Status != that1.Status {
-		return false
-	}
-	if this.OpCount != that1.OpCount {
-		return false
-	}
-	if this.GenesisID_0 != that1.GenesisID_0 {
-		return false
+func (m *Tag) GetTagID_2() uint64 {
+	if m != nil {
+		return m.TagID_2
 	}
-	if this.GenesisID_1 != that1.GenesisID_1 {
-		return false
+	return 0
+}
+
+func (m *Tag) GetUID() string {
+	if m != nil {
+		return m.UID
 	}
-	if this.GenesisID_2 != that1.GenesisID_2 {
-		return false
+	return ""
+}
+
+func (m *Tag) GetText() string {
+	if m != nil {
+		return m.Text
 	}
-	if this.ContextID_0 != that1.ContextID_0 {
-		return false
+	return ""
+}
+
+func (m *Tag) GetOrdering() float64 {
+	if m != nil {
+		return m.Ordering
 	}
-	if this.ContextID_1 != that1.ContextID_1 {
-		return false
+	return 0
+}
+
+func (m *Tag) GetURL() string {
+	if m != nil {
+		return m.URL
 	}
-	if this.ContextID_2 != that1.ContextID_2 {
-		return false
+	return ""
+}
+
+func (m *Tag) GetContentType() string {
+	if m != nil {
+		return m.ContentType
 	}
-	if !this.FromID.Equal(that1.FromID) {
-		return false
+	return ""
+}
+
+func (m *Tag) GetMetric() Metric {
+	if m != nil {
+		return m.Metric
 	}
-	if !this.ToID.Equal(that1.ToID) {
-		return false
+	return Metric_Nil
+}
+
+func (m *Tag) GetSizeX() uint64 {
+	if m != nil {
+		return m.SizeX
 	}
-	if !this.Tags.Equal(that1.Tags) {
-		return false
+	return 0
+}
+
+func (m *Tag) GetSizeY() uint64 {
+	if m != nil {
+		return m.SizeY
 	}
-	if !this.ContextCA.Equal(that1.ContextCA) {
-		return false
+	return 0
+}
+
+func (m *Tag) GetSizeZ() uint64 {
+	if m != nil {
+		return m.SizeZ
 	}
-	return true
+	return 0
 }
-func (this *Login) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *Tag) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
 	}
+	return nil
+}
 
-	that1, ok := that.(*Login)
-	if !ok {
-		that2, ok := that.(Login)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type CryptoKey struct {
+	CryptoKitID CryptoKitID `protobuf:"varint,1,opt,name=CryptoKitID,proto3,enum=amp.CryptoKitID" json:"CryptoKitID,omitempty"`
+	KeyBytes    []byte      `protobuf:"bytes,4,opt,name=KeyBytes,proto3" json:"KeyBytes,omitempty"`
+}
+
+func (m *CryptoKey) Reset()      { *m = CryptoKey{} }
+func (*CryptoKey) ProtoMessage() {}
+func (*CryptoKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{17}
+}
+func (m *CryptoKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CryptoKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CryptoKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.UserLabel != that1.UserLabel {
-		return false
-	}
-	if !this.UserUID.Equal(that1.UserUID) {
-		return false
-	}
-	if this.DeviceUID != that1.DeviceUID {
-		return false
-	}
-	if this.DeviceLabel != that1.DeviceLabel {
-		return false
-	}
-	if this.HostAddr != that1.HostAddr {
-		return false
-	}
-	if this.Tags != that1.Tags {
-		return false
-	}
-	if !this.Checkpoint.Equal(that1.Checkpoint) {
-		return false
+}
+func (m *CryptoKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CryptoKey.Merge(m, src)
+}
+func (m *CryptoKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *CryptoKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_CryptoKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CryptoKey proto.InternalMessageInfo
+
+func (m *CryptoKey) GetCryptoKitID() CryptoKitID {
+	if m != nil {
+		return m.CryptoKitID
 	}
-	return true
+	return CryptoKit_Nil
 }
-func (this *LoginChallenge) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *CryptoKey) GetKeyBytes() []byte {
+	if m != nil {
+		return m.KeyBytes
 	}
+	return nil
+}
 
-	that1, ok := that.(*LoginChallenge)
-	if !ok {
-		that2, ok := that.(LoginChallenge)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// Err is a general purpose error / warning / log message.
+type Err struct {
+	// Identifies the type of error.
+	Code ErrCode `protobuf:"varint,1,opt,name=Code,proto3,enum=amp.ErrCode" json:"Code,omitempty"`
+	// Severity level
+	Level LogLevel `protobuf:"varint,2,opt,name=Level,proto3,enum=amp.LogLevel" json:"Level,omitempty"`
+	// human-readable info
+	Msg string `protobuf:"bytes,4,opt,name=Msg,proto3" json:"Msg,omitempty"`
+}
+
+func (m *Err) Reset()      { *m = Err{} }
+func (*Err) ProtoMessage() {}
+func (*Err) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e479d288f92766f, []int{18}
+}
+func (m *Err) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Err) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Err.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !bytes.Equal(this.Hash, that1.Hash) {
-		return false
+}
+func (m *Err) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Err.Merge(m, src)
+}
+func (m *Err) XXX_Size() int {
+	return m.Size()
+}
+func (m *Err) XXX_DiscardUnknown() {
+	xxx_messageInfo_Err.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Err proto.InternalMessageInfo
+
+func (m *Err) GetCode() ErrCode {
+	if m != nil {
+		return m.Code
 	}
-	return true
+	return ErrCode_NoErr
 }
-func (this *LoginResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *Err) GetLevel() LogLevel {
+	if m != nil {
+		return m.Level
 	}
+	return LogLevel_Error
+}
 
-	that1, ok := that.(*LoginResponse)
-	if !ok {
-		that2, ok := that.(LoginResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !bytes.Equal(this.HashResponse, that1.HashResponse) {
-		return false
+func (m *Err) GetMsg() string {
+	if m != nil {
+		return m.Msg
 	}
-	return true
+	return ""
 }
-func (this *LoginCheckpoint) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*LoginCheckpoint)
-	if !ok {
-		that2, ok := that.(LoginCheckpoint)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.TokenType != that1.TokenType {
-		return false
-	}
-	if this.AccessToken != that1.AccessToken {
-		return false
+func init() {
+	proto.RegisterEnum("amp.Const", Const_name, Const_value)
+	proto.RegisterEnum("amp.TxOpCode", TxOpCode_name, TxOpCode_value)
+	proto.RegisterEnum("amp.TxField", TxField_name, TxField_value)
+	proto.RegisterEnum("amp.SelectOp", SelectOp_name, SelectOp_value)
+	proto.RegisterEnum("amp.TxPriority", TxPriority_name, TxPriority_value)
+	proto.RegisterEnum("amp.OpStatus", OpStatus_name, OpStatus_value)
+	proto.RegisterEnum("amp.StateSync", StateSync_name, StateSync_value)
+	proto.RegisterEnum("amp.Enable", Enable_name, Enable_value)
+	proto.RegisterEnum("amp.UrlScheme", UrlScheme_name, UrlScheme_value)
+	proto.RegisterEnum("amp.Metric", Metric_name, Metric_value)
+	proto.RegisterEnum("amp.CryptoKitID", CryptoKitID_name, CryptoKitID_value)
+	proto.RegisterEnum("amp.ErrCode", ErrCode_name, ErrCode_value)
+	proto.RegisterEnum("amp.LogLevel", LogLevel_name, LogLevel_value)
+	proto.RegisterType((*TxEnvelope)(nil), "amp.TxEnvelope")
+	proto.RegisterType((*Login)(nil), "amp.Login")
+	proto.RegisterType((*LoginChallenge)(nil), "amp.LoginChallenge")
+	proto.RegisterType((*LoginResponse)(nil), "amp.LoginResponse")
+	proto.RegisterType((*LoginCheckpoint)(nil), "amp.LoginCheckpoint")
+	proto.RegisterType((*PinRequest)(nil), "amp.PinRequest")
+	proto.RegisterType((*CancelRequest)(nil), "amp.CancelRequest")
+	proto.RegisterType((*LaunchURL)(nil), "amp.LaunchURL")
+	proto.RegisterType((*RegisterDefs)(nil), "amp.RegisterDefs")
+	proto.RegisterType((*AttrSchema)(nil), "amp.AttrSchema")
+	proto.RegisterType((*FieldSchema)(nil), "amp.FieldSchema")
+	proto.RegisterType((*AppSchema)(nil), "amp.AppSchema")
+	proto.RegisterType((*AppReloaded)(nil), "amp.AppReloaded")
+	proto.RegisterType((*DeltaResync)(nil), "amp.DeltaResync")
+	proto.RegisterType((*FlowCredit)(nil), "amp.FlowCredit")
+	proto.RegisterType((*TagUID)(nil), "amp.TagUID")
+	proto.RegisterType((*Tag)(nil), "amp.Tag")
+	proto.RegisterType((*CryptoKey)(nil), "amp.CryptoKey")
+	proto.RegisterType((*Err)(nil), "amp.Err")
+}
+
+func init() { proto.RegisterFile("amp/amp.proto", fileDescriptor_7e479d288f92766f) }
+
+var fileDescriptor_7e479d288f92766f = []byte{
+	// 2434 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x58, 0xcd, 0x8f, 0x23, 0x47,
+	0x15, 0x9f, 0xb6, 0x3d, 0x1e, 0xbb, 0x3c, 0x33, 0x5b, 0x53, 0x3b, 0xbb, 0xdb, 0x99, 0xcc, 0x3a,
+	0x23, 0x67, 0x83, 0x07, 0x93, 0x4d, 0x76, 0x1c, 0x72, 0xe0, 0xe8, 0xb1, 0x67, 0x77, 0xad, 0xcc,
+	0x97, 0xda, 0x9e, 0x40, 0x82, 0x94, 0x51, 0xad, 0xfb, 0xb9, 0xa7, 0xb5, 0xed, 0xaa, 0xa6, 0xba,
+	0x3c, 0xb1, 0x73, 0xe2, 0x12, 0x29, 0x7c, 0x07, 0x0e, 0x9c, 0xf8, 0x08, 0x48, 0x84, 0x10, 0x38,
+	0xf0, 0x07, 0x10, 0x90, 0x40, 0xa0, 0x88, 0x08, 0x69, 0x8f, 0x51, 0x4e, 0x64, 0x72, 0xe1, 0x00,
+	0x22, 0x7f, 0x02, 0xaa, 0xea, 0x0f, 0x77, 0x3b, 0x83, 0x72, 0xab, 0xf7, 0xfb, 0xbd, 0xaa, 0x57,
+	0xef, 0xd5, 0x7b, 0xaf, 0xaa, 0x1b, 0xad, 0xd0, 0x91, 0xff, 0x2c, 0x1d, 0xf9, 0xcf, 0xf8, 0x82,
+	0x4b, 0x4e, 0xf2, 0x74, 0xe4, 0xd7, 0xfe, 0x96, 0x47, 0xa8, 0x3f, 0xd9, 0x63, 0xe7, 0xe0, 0x71,
+	0x1f, 0xc8, 0x53, 0xa8, 0xd8, 0x93, 0x54, 0x8e, 0x03, 0x33, 0xb7, 0x65, 0x6c, 0xaf, 0x36, 0x57,
+	0x9e, 0x51, 0xfa, 0x47, 0x7e, 0x08, 0x5a, 0x11, 0x49, 0xbe, 0x84, 0x4a, 0xc7, 0xc2, 0xe5, 0xc2,
+	0x95, 0x53, 0x33, 0xaf, 0x15, 0xaf, 0x68, 0xc5, 0xfe, 0x24, 0x86, 0xad, 0x44, 0x81, 0x98, 0x68,
+	0xe9, 0xc8, 0x6f, 0xf3, 0x31, 0x93, 0x66, 0x61, 0xcb, 0xd8, 0x2e, 0x58, 0xb1, 0x48, 0x9e, 0x40,
+	0x95, 0x7b, 0xc0, 0x20, 0x70, 0x83, 0x6e, 0xe7, 0xf4, 0x8e, 0xb9, 0xb8, 0x65, 0x6c, 0xe7, 0x2d,
+	0x94, 0x40, 0x77, 0xb2, 0x0a, 0x3b, 0x66, 0x71, 0xcb, 0xd8, 0x2e, 0xa6, 0x14, 0x76, 0xb2, 0x0a,
+	0x4d, 0x73, 0x69, 0x4e, 0xa1, 0xa9, 0x14, 0xda, 0x9c, 0x49, 0x98, 0x48, 0x6d, 0x02, 0x85, 0x26,
+	0x12, 0xe8, 0x4e, 0x56, 0x61, 0xc7, 0xac, 0x84, 0x2b, 0x24, 0xd0, 0x4e, 0x56, 0xa1, 0x69, 0x2e,
+	0xcf, 0x29, 0x34, 0xc9, 0x16, 0x2a, 0xde, 0x15, 0x7c, 0xd4, 0xed, 0x98, 0xab, 0x5b, 0xc6, 0x76,
+	0xa5, 0x59, 0x0a, 0x43, 0x41, 0x1d, 0x2b, 0xc2, 0xc9, 0x26, 0x2a, 0xf4, 0x79, 0xb7, 0x63, 0x5e,
+	0x99, 0xe3, 0x35, 0xaa, 0x59, 0xea, 0x04, 0x26, 0xfe, 0x0c, 0x4b, 0x9d, 0x80, 0x7c, 0x01, 0x95,
+	0x23, 0x5b, 0xed, 0x96, 0xb9, 0x36, 0xa7, 0x32, 0xa3, 0x6a, 0xff, 0x35, 0xd0, 0xe2, 0x3e, 0x77,
+	0x5c, 0x46, 0x36, 0x51, 0xf9, 0x24, 0x00, 0xb1, 0x4f, 0x1f, 0x80, 0x67, 0x1a, 0x5b, 0xc6, 0x76,
+	0xd9, 0x9a, 0x01, 0xa4, 0x86, 0x96, 0x94, 0x70, 0xd2, 0xed, 0x98, 0xb9, 0xb9, 0xd5, 0x62, 0x42,
+	0xad, 0xd0, 0x81, 0x73, 0x77, 0x00, 0x4a, 0x6b, 0x31, 0x5c, 0x21, 0x01, 0xc8, 0x16, 0xaa, 0x84,
+	0x42, 0x68, 0xa1, 0xa8, 0xf9, 0x34, 0x44, 0x36, 0x50, 0xe9, 0x3e, 0x0f, 0x64, 0xcb, 0xb6, 0x85,
+	0x59, 0xd2, 0x74, 0x22, 0x13, 0x12, 0x79, 0x5b, 0xd6, 0x78, 0xe8, 0xe3, 0x97, 0x11, 0x6a, 0x9f,
+	0xc1, 0xe0, 0xa1, 0xcf, 0x5d, 0x26, 0x75, 0x84, 0x2b, 0xcd, 0x75, 0xbd, 0x2d, 0xed, 0xd1, 0x8c,
+	0xb3, 0x52, 0x7a, 0xb5, 0x5b, 0x68, 0x35, 0xa2, 0xa9, 0xe7, 0x01, 0x73, 0x40, 0xad, 0x7d, 0x9f,
+	0x06, 0x67, 0xda, 0xe9, 0x65, 0x4b, 0x8f, 0x6b, 0xcf, 0xa1, 0x15, 0xad, 0x65, 0x41, 0xe0, 0x73,
+	0x16, 0x00, 0xa9, 0xa1, 0x65, 0x45, 0xc4, 0x72, 0xa4, 0x9c, 0xc1, 0x6a, 0xff, 0x30, 0xd0, 0x95,
+	0x39, 0xd3, 0x2a, 0x28, 0x7d, 0xfe, 0x10, 0x58, 0x7f, 0xea, 0x43, 0x1c, 0xd6, 0x04, 0x50, 0x41,
+	0x69, 0x0d, 0x06, 0x10, 0x04, 0x1a, 0xd2, 0xa1, 0x2d, 0x5b, 0x69, 0x48, 0xd9, 0xb5, 0x60, 0x28,
+	0x20, 0x38, 0x0b, 0x55, 0xf2, 0x5a, 0x25, 0x83, 0x91, 0xeb, 0xa8, 0xb8, 0x37, 0xf1, 0x5d, 0x31,
+	0xd5, 0x95, 0x92, 0xb7, 0x22, 0x29, 0x09, 0x1a, 0x4a, 0x05, 0xcd, 0x9c, 0x1d, 0x64, 0x45, 0xc3,
+	0xb1, 0x48, 0x30, 0xca, 0x9f, 0x58, 0x5d, 0x1d, 0xc7, 0xb2, 0xa5, 0x86, 0xb5, 0xb7, 0x0d, 0x84,
+	0x8e, 0x55, 0x0c, 0xbe, 0x31, 0x86, 0x40, 0xaa, 0x9c, 0x3a, 0x76, 0x59, 0x9f, 0x0a, 0x07, 0xe4,
+	0x67, 0xb2, 0x60, 0x46, 0x91, 0x5b, 0xa8, 0x74, 0xec, 0xb2, 0x96, 0x94, 0x22, 0x30, 0x0b, 0x5b,
+	0xf9, 0x8c, 0x5a, 0xc2, 0x90, 0xa7, 0x51, 0x59, 0xb5, 0x05, 0xe8, 0x4d, 0xd9, 0x40, 0x67, 0xc3,
+	0x6a, 0x73, 0x55, 0xab, 0x25, 0xa8, 0x35, 0x53, 0xd0, 0x61, 0x74, 0x47, 0xc0, 0xc7, 0xf2, 0x20,
+	0xd0, 0xc9, 0x91, 0xb7, 0x66, 0x40, 0xad, 0x8e, 0x56, 0xda, 0x94, 0x0d, 0xc0, 0x8b, 0xb7, 0x7a,
+	0x1d, 0x15, 0x2d, 0xa0, 0x01, 0x67, 0x51, 0xc8, 0x23, 0xa9, 0x76, 0x13, 0x95, 0xf7, 0xe9, 0x98,
+	0x0d, 0xce, 0x4e, 0xac, 0xfd, 0xd0, 0xe1, 0xfd, 0x48, 0x43, 0x0d, 0x6b, 0x2f, 0xa9, 0x60, 0x3b,
+	0x6e, 0x20, 0x41, 0x74, 0x60, 0x18, 0x90, 0xa7, 0xd0, 0x62, 0xe8, 0x86, 0xa1, 0xdd, 0x08, 0xbb,
+	0x95, 0x42, 0x7a, 0x83, 0x33, 0x18, 0x51, 0x2b, 0x64, 0x49, 0x0d, 0x15, 0x5a, 0xbe, 0xaf, 0x9a,
+	0x9f, 0xd2, 0x0a, 0xbd, 0x68, 0xf9, 0x7e, 0xa4, 0xa4, 0xb9, 0xda, 0x1b, 0x06, 0x42, 0xb3, 0x99,
+	0xaa, 0x7a, 0x7b, 0x3e, 0x0c, 0xb4, 0xf1, 0x4c, 0xf5, 0x2a, 0x54, 0x55, 0x82, 0x4a, 0x8f, 0x43,
+	0x3a, 0x82, 0x28, 0x27, 0x12, 0x59, 0xb9, 0x76, 0x8f, 0x2b, 0x29, 0x4a, 0x85, 0x48, 0x22, 0xdb,
+	0xa8, 0x78, 0xd7, 0x05, 0xcf, 0x8e, 0x63, 0x8e, 0xf5, 0x9a, 0x1a, 0x8a, 0x36, 0x12, 0xf1, 0xb5,
+	0xd7, 0x0d, 0x54, 0x49, 0xe1, 0x2a, 0x4d, 0xb4, 0xa5, 0x30, 0x10, 0x85, 0xd8, 0xca, 0xe1, 0x78,
+	0xf4, 0x00, 0x84, 0xb6, 0xbf, 0x68, 0x45, 0x92, 0x4e, 0xa9, 0x99, 0x6d, 0x3d, 0xce, 0xec, 0xb6,
+	0x30, 0xb7, 0xdb, 0x0d, 0x54, 0xb2, 0xc0, 0x07, 0x2a, 0xc1, 0xd6, 0x2d, 0xa1, 0x64, 0x25, 0x72,
+	0xed, 0x03, 0x03, 0x95, 0x93, 0x30, 0x7d, 0x4e, 0x44, 0x08, 0x2a, 0x74, 0x20, 0x18, 0x44, 0xd1,
+	0xd0, 0x63, 0x95, 0xca, 0x2f, 0x82, 0x08, 0x5c, 0x1e, 0x57, 0x45, 0x2c, 0xaa, 0xb2, 0xea, 0xb2,
+	0x73, 0x3e, 0xa0, 0xd2, 0xe5, 0x2c, 0x0c, 0x48, 0xd9, 0x4a, 0x43, 0xe4, 0x69, 0xb4, 0xdc, 0x01,
+	0x1f, 0x98, 0x0d, 0x6c, 0xe0, 0x42, 0x60, 0x2e, 0xce, 0xe5, 0x69, 0x86, 0x55, 0x45, 0xa8, 0x5b,
+	0x26, 0x93, 0xca, 0xb1, 0xc0, 0x2c, 0xea, 0x05, 0x33, 0x58, 0xcd, 0x41, 0x95, 0x96, 0xef, 0x5b,
+	0xe0, 0x71, 0x6a, 0x83, 0xfd, 0x39, 0xee, 0x6c, 0xa1, 0xca, 0xb1, 0x80, 0xf3, 0x78, 0xfb, 0x51,
+	0xdd, 0xa7, 0xa0, 0xff, 0xef, 0x5c, 0xed, 0x8b, 0xaa, 0x91, 0x7a, 0x92, 0x5a, 0x10, 0xa8, 0xca,
+	0xd8, 0x40, 0xa5, 0x3d, 0x0f, 0x46, 0xc0, 0x64, 0x98, 0xa6, 0x45, 0x2b, 0x91, 0x6b, 0x35, 0x84,
+	0xee, 0x7a, 0xfc, 0xd5, 0xb6, 0x00, 0xdb, 0x95, 0x64, 0x1d, 0x2d, 0xee, 0x4e, 0x25, 0x04, 0x7a,
+	0x4f, 0x05, 0x2b, 0x14, 0x6a, 0x3d, 0x54, 0xec, 0x53, 0x47, 0x35, 0x80, 0x35, 0x54, 0xd0, 0xb7,
+	0x5d, 0x4e, 0x97, 0x57, 0x5e, 0x5d, 0x73, 0x21, 0xb4, 0xa3, 0xb7, 0x50, 0x54, 0xd0, 0x4e, 0x04,
+	0x35, 0xcd, 0x42, 0x0c, 0x35, 0x75, 0x21, 0x75, 0x3b, 0x51, 0x4b, 0x57, 0xc3, 0xda, 0x07, 0x39,
+	0x94, 0xef, 0x53, 0x87, 0xdc, 0x40, 0x4b, 0x7d, 0xea, 0xa4, 0x56, 0x2d, 0x6a, 0xf1, 0xce, 0x8c,
+	0x88, 0xd7, 0x0e, 0x89, 0x9d, 0x19, 0x11, 0x5b, 0x08, 0x89, 0x4b, 0x8c, 0xe8, 0x5c, 0x84, 0x89,
+	0x34, 0x97, 0xa2, 0x5c, 0x84, 0x89, 0x54, 0xd1, 0x38, 0x12, 0x36, 0x08, 0x97, 0x39, 0xfa, 0xae,
+	0x30, 0xac, 0x44, 0x8e, 0xeb, 0x7d, 0x25, 0xa9, 0x77, 0x75, 0x0c, 0xa9, 0x33, 0xd4, 0x17, 0x71,
+	0xd9, 0x4a, 0x43, 0xe4, 0x49, 0x54, 0x3c, 0x00, 0x29, 0xdc, 0x81, 0xb9, 0xa1, 0x5b, 0x54, 0x45,
+	0x1f, 0x64, 0x08, 0x59, 0x11, 0xa5, 0x02, 0xdb, 0x73, 0x5f, 0x83, 0xaf, 0x99, 0x8f, 0x87, 0x81,
+	0xd5, 0x42, 0x8c, 0xbe, 0x64, 0x6e, 0xce, 0xd0, 0x97, 0x62, 0xf4, 0x65, 0xf3, 0xe6, 0x0c, 0x7d,
+	0x39, 0xb9, 0xcc, 0xb7, 0xe6, 0xd2, 0x50, 0xa3, 0xb5, 0xaf, 0xa3, 0x72, 0x5b, 0x4c, 0x7d, 0xc9,
+	0x5f, 0x80, 0x29, 0x69, 0xa2, 0x4a, 0x24, 0xb8, 0xb2, 0xdb, 0xd1, 0x67, 0xb9, 0x1a, 0x15, 0x7b,
+	0x0a, 0xb7, 0xd2, 0x4a, 0x2a, 0x2a, 0x2f, 0xc0, 0x34, 0x3c, 0xfc, 0x82, 0xbe, 0xb8, 0x12, 0xb9,
+	0xf6, 0x0a, 0xca, 0xef, 0x09, 0x41, 0xb6, 0x50, 0xa1, 0xcd, 0x6d, 0x88, 0xd6, 0x5b, 0xd6, 0xeb,
+	0xed, 0x09, 0xa1, 0x30, 0x4b, 0x33, 0xe4, 0x49, 0xb4, 0xb8, 0x0f, 0xe7, 0xe0, 0x65, 0xde, 0x78,
+	0xfb, 0xdc, 0xd1, 0xa0, 0x15, 0x72, 0x2a, 0xc6, 0x07, 0x81, 0x13, 0xb5, 0x01, 0x35, 0x6c, 0xbc,
+	0x65, 0xa0, 0xc5, 0x36, 0x67, 0x81, 0x24, 0xab, 0x08, 0xe9, 0xc1, 0xa9, 0xea, 0xad, 0x78, 0x81,
+	0xdc, 0x44, 0x66, 0x22, 0xd3, 0xb1, 0x27, 0x7b, 0x20, 0xd4, 0x63, 0xe0, 0x98, 0x0b, 0x89, 0xdf,
+	0xdf, 0x26, 0x37, 0xd0, 0xd5, 0x90, 0xee, 0x4f, 0xee, 0x03, 0xb5, 0x41, 0x9c, 0xaa, 0x58, 0x61,
+	0x4c, 0x36, 0xd0, 0xf5, 0x39, 0x22, 0x2a, 0x0d, 0xfc, 0x1c, 0xd9, 0x44, 0xd7, 0xe6, 0xb8, 0x03,
+	0x2a, 0x1e, 0x82, 0xc0, 0x9f, 0x7e, 0xf4, 0x7a, 0x9e, 0x5c, 0x43, 0x38, 0x64, 0x67, 0xad, 0x00,
+	0xbf, 0x77, 0xb3, 0xc1, 0x50, 0xa9, 0x3f, 0x51, 0xaf, 0x4b, 0x1b, 0x08, 0x46, 0xcb, 0xf1, 0xf8,
+	0xf4, 0xd0, 0xf5, 0xf0, 0x82, 0x32, 0x97, 0x20, 0x27, 0x7e, 0x00, 0x42, 0x46, 0xf5, 0x85, 0x73,
+	0xc4, 0x44, 0xeb, 0x73, 0x9c, 0x2e, 0x4d, 0x9c, 0xcf, 0xcc, 0xea, 0x80, 0x07, 0x12, 0xe2, 0x59,
+	0x85, 0xc6, 0xa3, 0x1c, 0x5a, 0xea, 0x4f, 0x74, 0x0b, 0x26, 0x57, 0x50, 0x25, 0x1a, 0x46, 0xe6,
+	0xd6, 0x11, 0x8e, 0x81, 0x36, 0x78, 0x9e, 0xaa, 0x1d, 0x6c, 0x5c, 0x82, 0xee, 0xe0, 0xdc, 0x25,
+	0x68, 0x13, 0xe7, 0xd3, 0xa8, 0xba, 0x71, 0xf4, 0x0a, 0x85, 0x4b, 0xd0, 0x1d, 0xbc, 0x78, 0x09,
+	0xda, 0xc4, 0xc5, 0x34, 0xda, 0x95, 0x30, 0xd2, 0x2b, 0x2c, 0x5d, 0x82, 0xee, 0xe0, 0xd2, 0x25,
+	0x68, 0x13, 0x97, 0xd3, 0xe8, 0x9e, 0xed, 0xea, 0x57, 0x34, 0x46, 0x97, 0xa0, 0x3b, 0xb8, 0x72,
+	0x09, 0xda, 0xc4, 0xcb, 0xe4, 0x1a, 0x5a, 0x4b, 0x02, 0x33, 0x1e, 0xe9, 0x41, 0x80, 0x57, 0xd2,
+	0xf0, 0x01, 0x9d, 0x44, 0xb0, 0xd9, 0xd8, 0x47, 0xa5, 0x1e, 0x78, 0x30, 0x90, 0x47, 0xbe, 0x5a,
+	0x2f, 0x1e, 0x9f, 0x1e, 0xc2, 0x58, 0x0a, 0x1a, 0xc5, 0x35, 0x41, 0xbb, 0x6c, 0xe0, 0x8d, 0x6d,
+	0xc0, 0x46, 0x06, 0xdd, 0x9b, 0x84, 0x68, 0xae, 0x71, 0xa2, 0xbe, 0x6e, 0x92, 0x2f, 0x11, 0x7d,
+	0x94, 0xb1, 0x74, 0xda, 0x65, 0x12, 0x04, 0x1d, 0x48, 0xf7, 0x1c, 0xf0, 0x02, 0xb9, 0x8e, 0x48,
+	0x8a, 0x53, 0x9d, 0x43, 0x70, 0x0f, 0x1b, 0xe4, 0x2a, 0xba, 0x92, 0xc2, 0x77, 0xc7, 0xde, 0x43,
+	0x9c, 0x6b, 0x9c, 0xa3, 0x52, 0xfc, 0x4d, 0xa4, 0xb2, 0x3b, 0x1e, 0x9f, 0x1e, 0x72, 0xd9, 0x93,
+	0x54, 0x48, 0xb0, 0xc3, 0x7d, 0x26, 0x84, 0x7a, 0xfa, 0xb8, 0xcc, 0xc1, 0x06, 0x59, 0x43, 0x2b,
+	0x09, 0xba, 0x3b, 0x0e, 0xa6, 0x38, 0xa7, 0x4c, 0x64, 0x14, 0xc1, 0xc6, 0xf9, 0x0c, 0xd8, 0xf6,
+	0x78, 0x00, 0x36, 0x7e, 0xaa, 0x61, 0xa5, 0x9e, 0x5a, 0x84, 0xa0, 0xd5, 0x44, 0x38, 0x3d, 0xe4,
+	0x4c, 0x79, 0xf1, 0x18, 0xba, 0x36, 0xc3, 0xf4, 0xb4, 0x23, 0xa6, 0xc6, 0xd8, 0x50, 0x0e, 0xce,
+	0xa8, 0x03, 0xea, 0x32, 0x49, 0x5d, 0x86, 0x73, 0x8d, 0x57, 0x50, 0x71, 0x8f, 0xd1, 0x07, 0x1e,
+	0xa8, 0x0d, 0x87, 0xa3, 0xd3, 0x7d, 0xaa, 0xfa, 0xe6, 0xd1, 0x70, 0x88, 0x17, 0xd4, 0x46, 0xb2,
+	0x28, 0xc3, 0x46, 0x0a, 0x6c, 0xe9, 0x00, 0x1e, 0xb1, 0x30, 0x89, 0xb3, 0xe0, 0x70, 0x88, 0xf3,
+	0x8d, 0x8f, 0x0c, 0x54, 0x3e, 0x11, 0x9e, 0x7e, 0x1c, 0x80, 0x72, 0x3f, 0x11, 0x66, 0x65, 0x39,
+	0x83, 0x4e, 0x98, 0x80, 0x01, 0x77, 0x98, 0xfb, 0x1a, 0xd8, 0xd8, 0x50, 0x3e, 0xce, 0xb8, 0xfb,
+	0x52, 0xfa, 0x38, 0x97, 0xc5, 0x3a, 0x54, 0x17, 0x69, 0x06, 0xbb, 0xeb, 0x7a, 0x80, 0x0b, 0x59,
+	0x53, 0xad, 0x91, 0x8f, 0x97, 0xb2, 0x6a, 0x5d, 0x7f, 0x18, 0xe0, 0xb5, 0x79, 0x8c, 0x05, 0x98,
+	0x28, 0x4f, 0x66, 0xd8, 0x01, 0x75, 0x18, 0x48, 0x7c, 0x35, 0xbb, 0xe0, 0x3d, 0x57, 0xe2, 0xf5,
+	0xc6, 0x5f, 0x8d, 0xf8, 0x5a, 0x51, 0x4d, 0x31, 0x1c, 0x45, 0x6e, 0x5d, 0x43, 0x6b, 0x91, 0x7c,
+	0x24, 0xe4, 0x19, 0x3f, 0x76, 0x27, 0xa0, 0xf2, 0x69, 0x0e, 0x3e, 0x00, 0x09, 0x02, 0xe7, 0x54,
+	0x10, 0x32, 0xb0, 0xeb, 0x79, 0xee, 0x48, 0x73, 0x79, 0x75, 0xa8, 0x69, 0xee, 0x90, 0x32, 0x1e,
+	0x52, 0x05, 0xb2, 0x89, 0xcc, 0x88, 0xba, 0x0f, 0x93, 0x7b, 0xc2, 0xb5, 0x53, 0x13, 0x17, 0xc9,
+	0x36, 0xba, 0x15, 0xb1, 0x7d, 0x41, 0x7d, 0x78, 0x8d, 0x77, 0xb8, 0x0d, 0x03, 0x7a, 0x06, 0xb6,
+	0xe0, 0x2c, 0xa5, 0x59, 0x6c, 0xfc, 0xd8, 0xc8, 0x5c, 0x46, 0xca, 0xd5, 0x44, 0x8c, 0xfc, 0xd9,
+	0x44, 0xe6, 0x0c, 0xea, 0xc1, 0x40, 0x80, 0xdc, 0xe5, 0x93, 0xd3, 0x43, 0xda, 0xf6, 0xb0, 0xad,
+	0x5b, 0x79, 0xc2, 0xb6, 0x82, 0xe9, 0xe8, 0x20, 0x70, 0x42, 0x0e, 0xb2, 0x5c, 0xcf, 0x75, 0x98,
+	0xcb, 0x22, 0x6e, 0x48, 0xaa, 0xe8, 0xb1, 0xcf, 0x72, 0x7b, 0x9d, 0xe6, 0xf3, 0xcf, 0xef, 0x7c,
+	0x05, 0xff, 0xdd, 0x68, 0x7c, 0xb0, 0x84, 0x96, 0xa2, 0xdb, 0x4b, 0x6d, 0x2a, 0x1a, 0x9e, 0x1e,
+	0xf2, 0x3d, 0x21, 0xf0, 0x02, 0xb9, 0x81, 0x48, 0x0c, 0x9d, 0x30, 0x46, 0x47, 0x60, 0x2b, 0xfc,
+	0x8d, 0x3a, 0x31, 0xd1, 0xd5, 0x98, 0xd0, 0x75, 0xce, 0xa8, 0xa7, 0x98, 0x6f, 0xd5, 0xc9, 0x06,
+	0xba, 0x36, 0x9b, 0x12, 0x8c, 0x7d, 0x9f, 0xab, 0x7a, 0x3d, 0xf2, 0xf1, 0xb7, 0xe7, 0x38, 0x77,
+	0xe4, 0x87, 0x8d, 0x1e, 0x6c, 0xfc, 0x9d, 0x3a, 0x59, 0x47, 0x57, 0x62, 0x2e, 0xfa, 0x5e, 0xc1,
+	0xdf, 0xad, 0x93, 0xc7, 0xd0, 0x7a, 0x8c, 0xf6, 0xce, 0xc6, 0x52, 0xba, 0xcc, 0xe9, 0xf0, 0x57,
+	0x19, 0xfe, 0x5e, 0x86, 0x3a, 0xe4, 0xb2, 0xcd, 0x19, 0x83, 0x81, 0x5a, 0xeb, 0xfb, 0xf5, 0xf4,
+	0xb6, 0x5b, 0x63, 0x79, 0x76, 0x97, 0xba, 0x1e, 0xd8, 0xf8, 0x07, 0x99, 0x6d, 0xeb, 0xef, 0xcf,
+	0x88, 0x79, 0xb3, 0x4e, 0x1e, 0x47, 0xd7, 0x13, 0x43, 0x10, 0xa8, 0x4b, 0x52, 0x7f, 0x1b, 0x82,
+	0x8d, 0x7f, 0x58, 0x57, 0xd7, 0x61, 0xca, 0x94, 0x05, 0xd4, 0x9e, 0xe2, 0x1f, 0xd5, 0xc9, 0x26,
+	0xba, 0x11, 0xc3, 0xd1, 0xf7, 0xd4, 0x21, 0x97, 0x77, 0xf9, 0x98, 0xd9, 0xf8, 0x27, 0x19, 0x67,
+	0x23, 0x36, 0xea, 0x33, 0x3f, 0xcd, 0x6c, 0x70, 0x97, 0xda, 0x11, 0x8d, 0x7f, 0x96, 0x21, 0xba,
+	0xec, 0x9c, 0x7a, 0xae, 0x7d, 0x62, 0x75, 0xf1, 0xcf, 0x33, 0x5b, 0xd8, 0xa5, 0xf6, 0x8b, 0xd4,
+	0x1b, 0x03, 0x7e, 0xeb, 0x32, 0xfd, 0x3e, 0x75, 0xf0, 0x2f, 0x32, 0xfe, 0xcc, 0x08, 0xf5, 0xa4,
+	0xc6, 0xbf, 0xcc, 0x84, 0x4e, 0x5d, 0x66, 0xc9, 0xae, 0x7f, 0x95, 0xf1, 0xe9, 0x90, 0xcb, 0x33,
+	0x97, 0x39, 0x7d, 0xde, 0xe6, 0xa3, 0x91, 0x2b, 0xf1, 0xdb, 0x99, 0x89, 0x21, 0x18, 0x05, 0xf0,
+	0xd7, 0x19, 0x77, 0x7b, 0x3e, 0x1d, 0x40, 0xb2, 0xe8, 0x3b, 0xd9, 0xe0, 0x4a, 0x2e, 0xa8, 0x03,
+	0x6a, 0xde, 0x58, 0x00, 0xfe, 0x4d, 0xe6, 0x4c, 0x5a, 0xbe, 0x9f, 0x4c, 0x7b, 0x77, 0x9e, 0x69,
+	0x73, 0x36, 0xf4, 0xdc, 0x81, 0xc4, 0xbf, 0xad, 0xab, 0x17, 0x51, 0xcc, 0x24, 0xdf, 0x1f, 0xd3,
+	0x68, 0x2f, 0xbf, 0xcb, 0x4c, 0x3c, 0xa0, 0xde, 0x90, 0x8b, 0x11, 0xd8, 0xfd, 0x09, 0xfe, 0x7d,
+	0x9d, 0x5c, 0x47, 0x6b, 0xa9, 0x30, 0x86, 0x5f, 0x54, 0xf8, 0x0f, 0x99, 0x19, 0xaa, 0xe5, 0xc5,
+	0xdb, 0x7b, 0x2f, 0x33, 0x63, 0x6f, 0xa2, 0x92, 0x59, 0xe5, 0xf9, 0x1f, 0x33, 0xf8, 0x71, 0x92,
+	0x48, 0x7f, 0xca, 0x86, 0x08, 0x3c, 0x2f, 0xf1, 0xe7, 0xcf, 0x19, 0x23, 0xc7, 0x82, 0x9f, 0xbb,
+	0x36, 0x08, 0xb5, 0xd8, 0x5f, 0xea, 0xe4, 0x09, 0xb4, 0x11, 0x33, 0x2f, 0xba, 0xdc, 0xa3, 0x12,
+	0x82, 0x96, 0xaf, 0xfc, 0x3a, 0x62, 0xde, 0x14, 0xff, 0xbb, 0x4e, 0x6e, 0xa1, 0x27, 0x66, 0xc7,
+	0x19, 0x8c, 0x87, 0x43, 0x77, 0xe0, 0x02, 0x93, 0xc7, 0x20, 0x46, 0xae, 0xce, 0xd6, 0x00, 0xff,
+	0xa7, 0xde, 0xe8, 0xa0, 0x52, 0xfc, 0xce, 0x54, 0x7d, 0x37, 0x1e, 0x9f, 0xee, 0x09, 0xc1, 0x55,
+	0x39, 0xaf, 0xa1, 0x95, 0x04, 0xfb, 0x2a, 0x15, 0xea, 0x52, 0x49, 0x43, 0x5d, 0x36, 0xe4, 0xb8,
+	0xb0, 0x7b, 0xf6, 0xe8, 0xe3, 0xea, 0xc2, 0x87, 0x1f, 0x57, 0x17, 0x3e, 0xfd, 0xb8, 0x6a, 0x7c,
+	0xf3, 0xa2, 0x6a, 0xbc, 0x73, 0x51, 0x35, 0xde, 0xbf, 0xa8, 0x1a, 0x8f, 0x2e, 0xaa, 0xc6, 0x3f,
+	0x2f, 0xaa, 0xc6, 0xbf, 0x2e, 0xaa, 0x0b, 0x9f, 0x5e, 0x54, 0x8d, 0x37, 0x3f, 0xa9, 0x2e, 0x3c,
+	0xfa, 0xa4, 0xba, 0xf0, 0xe1, 0x27, 0xd5, 0x85, 0x97, 0x9f, 0x76, 0x5c, 0x79, 0x36, 0x7e, 0xf0,
+	0xcc, 0x80, 0x8f, 0x9e, 0xa5, 0x42, 0xde, 0x1e, 0x81, 0xed, 0xd2, 0xdb, 0xbe, 0x47, 0xa5, 0x8a,
+	0xbf, 0xfa, 0x2f, 0x7a, 0x3b, 0xb0, 0x1f, 0xde, 0x76, 0xb8, 0x1a, 0xbe, 0x9b, 0xcb, 0xb7, 0x0e,
+	0x8e, 0x1f, 0x14, 0xf5, 0x9f, 0xd2, 0xe7, 0xfe, 0x37, 0x00, 0x24, 0x86, 0xbb, 0x04, 0x3a, 0x15,
+	0x00, 0x00,
+}
+
+func (x Const) String() string {
+	s, ok := Const_name[int32(x)]
+	if ok {
+		return s
 	}
-	if this.RefreshToken != that1.RefreshToken {
-		return false
+	return strconv.Itoa(int(x))
+}
+func (x TxOpCode) String() string {
+	s, ok := TxOpCode_name[int32(x)]
+	if ok {
+		return s
 	}
-	if this.Expiry != that1.Expiry {
-		return false
+	return strconv.Itoa(int(x))
+}
+func (x TxField) String() string {
+	s, ok := TxField_name[int32(x)]
+	if ok {
+		return s
 	}
-	if this.Tags != that1.Tags {
-		return false
+	return strconv.Itoa(int(x))
+}
+func (x SelectOp) String() string {
+	s, ok := SelectOp_name[int32(x)]
+	if ok {
+		return s
 	}
-	if this.UserUID != that1.UserUID {
-		return false
+	return strconv.Itoa(int(x))
+}
+func (x TxPriority) String() string {
+	s, ok := TxPriority_name[int32(x)]
+	if ok {
+		return s
 	}
-	if this.URI != that1.URI {
-		return false
+	return strconv.Itoa(int(x))
+}
+func (x OpStatus) String() string {
+	s, ok := OpStatus_name[int32(x)]
+	if ok {
+		return s
 	}
-	return true
+	return strconv.Itoa(int(x))
 }
-func (this *PinRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (x StateSync) String() string {
+	s, ok := StateSync_name[int32(x)]
+	if ok {
+		return s
 	}
-
-	that1, ok := that.(*PinRequest)
-	if !ok {
-		that2, ok := that.(PinRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+	return strconv.Itoa(int(x))
+}
+func (x Enable) String() string {
+	s, ok := Enable_name[int32(x)]
+	if ok {
+		return s
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return strconv.Itoa(int(x))
+}
+func (x UrlScheme) String() string {
+	s, ok := UrlScheme_name[int32(x)]
+	if ok {
+		return s
 	}
-	if !this.PinTarget.Equal(that1.PinTarget) {
-		return false
+	return strconv.Itoa(int(x))
+}
+func (x Metric) String() string {
+	s, ok := Metric_name[int32(x)]
+	if ok {
+		return s
 	}
-	if len(this.PinAttrs) != len(that1.PinAttrs) {
-		return false
+	return strconv.Itoa(int(x))
+}
+func (x CryptoKitID) String() string {
+	s, ok := CryptoKitID_name[int32(x)]
+	if ok {
+		return s
 	}
-	for i := range this.PinAttrs {
-		if !this.PinAttrs[i].Equal(that1.PinAttrs[i]) {
-			return false
-		}
+	return strconv.Itoa(int(x))
+}
+func (x ErrCode) String() string {
+	s, ok := ErrCode_name[int32(x)]
+	if ok {
+		return s
 	}
-	if this.StateSync != that1.StateSync {
-		return false
+	return strconv.Itoa(int(x))
+}
+func (x LogLevel) String() string {
+	s, ok := LogLevel_name[int32(x)]
+	if ok {
+		return s
 	}
-	return true
+	return strconv.Itoa(int(x))
 }
-func (this *LaunchURL) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *TxEnvelope) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
 
-	that1, ok := that.(*LaunchURL)
-	if !ok {
-		that2, ok := that.(LaunchURL)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.URL != that1.URL {
-		return false
-	}
-	return true
+func (m *TxEnvelope) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *TagUID) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*TagUID)
-	if !ok {
-		that2, ok := that.(TagUID)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *TxEnvelope) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ContextCA != nil {
+		{
+			size, err := m.ContextCA.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAmp(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.ID_0 != that1.ID_0 {
-		return false
-	}
-	if this.ID_1 != that1.ID_1 {
-		return false
-	}
-	if this.ID_2 != that1.ID_2 {
-		return false
-	}
-	if this.UID != that1.UID {
-		return false
-	}
-	return true
-}
-func (this *Tag) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*Tag)
-	if !ok {
-		that2, ok := that.(Tag)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+	if m.Tags != nil {
+		{
+			size, err := m.Tags.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAmp(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.TagID_0 != that1.TagID_0 {
-		return false
-	}
-	if this.TagID_1 != that1.TagID_1 {
-		return false
-	}
-	if this.TagID_2 != that1.TagID_2 {
-		return false
-	}
-	if this.UID != that1.UID {
-		return false
+	if m.ToID != nil {
+		{
+			size, err := m.ToID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAmp(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
 	}
-	if this.Text != that1.Text {
-		return false
+	if m.FromID != nil {
+		{
+			size, err := m.FromID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAmp(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
 	}
-	if this.Ordering != that1.Ordering {
-		return false
+	if m.ContextID_2 != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.ContextID_2))
+		i--
+		dAtA[i] = 0x61
 	}
-	if this.URL != that1.URL {
-		return false
+	if m.ContextID_1 != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.ContextID_1))
+		i--
+		dAtA[i] = 0x59
 	}
-	if this.ContentType != that1.ContentType {
-		return false
+	if m.ContextID_0 != 0 {
+		i = encodeVarintAmp(dAtA, i, uint64(m.ContextID_0))
+		i--
+		dAtA[i] = 0x50
 	}
-	if this.Metric != that1.Metric {
-		return false
+	if m.GenesisID_2 != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.GenesisID_2))
+		i--
+		dAtA[i] = 0x39
 	}
-	if this.SizeX != that1.SizeX {
-		return false
+	if m.GenesisID_1 != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.GenesisID_1))
+		i--
+		dAtA[i] = 0x31
 	}
-	if this.SizeY != that1.SizeY {
-		return false
+	if m.GenesisID_0 != 0 {
+		i = encodeVarintAmp(dAtA, i, uint64(m.GenesisID_0))
+		i--
+		dAtA[i] = 0x28
 	}
-	if this.SizeZ != that1.SizeZ {
-		return false
+	if m.OpCount != 0 {
+		i = encodeVarintAmp(dAtA, i, uint64(m.OpCount))
+		i--
+		dAtA[i] = 0x20
 	}
-	if len(this.Tags) != len(that1.Tags) {
-		return false
+	if m.Priority != 0 {
+		i = encodeVarintAmp(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x18
 	}
-	for i := range this.Tags {
-		if !this.Tags[i].Equal(that1.Tags[i]) {
-			return false
-		}
+	if m.Status != 0 {
+		i = encodeVarintAmp(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x10
 	}
-	return true
+	return len(dAtA) - i, nil
 }
-func (this *CryptoKey) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *Login) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
 
-	that1, ok := that.(*CryptoKey)
-	if !ok {
-		that2, ok := that.(CryptoKey)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *Login) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Login) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Checkpoint != nil {
+		{
+			size, err := m.Checkpoint.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAmp(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x62
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	if len(m.Tags) > 0 {
+		i -= len(m.Tags)
+		copy(dAtA[i:], m.Tags)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.Tags)))
+		i--
+		dAtA[i] = 0x4a
 	}
-	if this.CryptoKitID != that1.CryptoKitID {
-		return false
+	if len(m.HostAddr) > 0 {
+		i -= len(m.HostAddr)
+		copy(dAtA[i:], m.HostAddr)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.HostAddr)))
+		i--
+		dAtA[i] = 0x42
 	}
-	if !bytes.Equal(this.KeyBytes, that1.KeyBytes) {
-		return false
+	if len(m.DeviceLabel) > 0 {
+		i -= len(m.DeviceLabel)
+		copy(dAtA[i:], m.DeviceLabel)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.DeviceLabel)))
+		i--
+		dAtA[i] = 0x32
 	}
-	return true
-}
-func (this *Err) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+	if len(m.DeviceUID) > 0 {
+		i -= len(m.DeviceUID)
+		copy(dAtA[i:], m.DeviceUID)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.DeviceUID)))
+		i--
+		dAtA[i] = 0x2a
 	}
-
-	that1, ok := that.(*Err)
-	if !ok {
-		that2, ok := that.(Err)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+	if m.UserUID != nil {
+		{
+			size, err := m.UserUID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAmp(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Code != that1.Code {
-		return false
-	}
-	if this.Level != that1.Level {
-		return false
-	}
-	if this.Msg != that1.Msg {
-		return false
+	if len(m.UserLabel) > 0 {
+		i -= len(m.UserLabel)
+		copy(dAtA[i:], m.UserLabel)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.UserLabel)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return true
+	return len(dAtA) - i, nil
 }
-func (this *TxEnvelope) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *LoginChallenge) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := make([]string, 0, 16)
-	s = append(s, "&amp.TxEnvelope{")
-	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
-	s = append(s, "OpCount: "+fmt.Sprintf("%#v", this.OpCount)+",\n")
-	s = append(s, "GenesisID_0: "+fmt.Sprintf("%#v", this.GenesisID_0)+",\n")
-	s = append(s, "GenesisID_1: "+fmt.Sprintf("%#v", this.GenesisID_1)+",\n")
-	s = append(s, "GenesisID_2: "+fmt.Sprintf("%#v", this.GenesisID_2)+",\n")
-	s = append(s, "ContextID_0: "+fmt.Sprintf("%#v", this.ContextID_0)+",\n")
-	s = append(s, "ContextID_1: "+fmt.Sprintf("%#v", this.ContextID_1)+",\n")
-	s = append(s, "ContextID_2: "+fmt.Sprintf("%#v", this.ContextID_2)+",\n")
-	if this.FromID != nil {
-		s = append(s, "FromID: "+fmt.Sprintf("%#v", this.FromID)+",\n")
-	}
-	if this.ToID != nil {
-		s = append(s, "ToID: "+fmt.Sprintf("%#v", this.ToID)+",\n")
-	}
-	if this.Tags != nil {
-		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
-	}
-	if this.ContextCA != nil {
-		s = append(s, "ContextCA: "+fmt.Sprintf("%#v", this.ContextCA)+",\n")
-	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *Login) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 11)
-	s = append(s, "&amp.Login{")
-	s = append(s, "UserLabel: "+fmt.Sprintf("%#v", this.UserLabel)+",\n")
-	if this.UserUID != nil {
-		s = append(s, "UserUID: "+fmt.Sprintf("%#v", this.UserUID)+",\n")
-	}
-	s = append(s, "DeviceUID: "+fmt.Sprintf("%#v", this.DeviceUID)+",\n")
-	s = append(s, "DeviceLabel: "+fmt.Sprintf("%#v", this.DeviceLabel)+",\n")
-	s = append(s, "HostAddr: "+fmt.Sprintf("%#v", this.HostAddr)+",\n")
-	s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
-	if this.Checkpoint != nil {
-		s = append(s, "Checkpoint: "+fmt.Sprintf("%#v", this.Checkpoint)+",\n")
-	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LoginChallenge) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&amp.LoginChallenge{")
-	s = append(s, "Hash: "+fmt.Sprintf("%#v", this.Hash)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LoginResponse) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&amp.LoginResponse{")
-	s = append(s, "HashResponse: "+fmt.Sprintf("%#v", this.HashResponse)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LoginCheckpoint) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 11)
-	s = append(s, "&amp.LoginCheckpoint{")
-	s = append(s, "TokenType: "+fmt.Sprintf("%#v", this.TokenType)+",\n")
-	s = append(s, "AccessToken: "+fmt.Sprintf("%#v", this.AccessToken)+",\n")
-	s = append(s, "RefreshToken: "+fmt.Sprintf("%#v", this.RefreshToken)+",\n")
-	s = append(s, "Expiry: "+fmt.Sprintf("%#v", this.Expiry)+",\n")
-	s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
-	s = append(s, "UserUID: "+fmt.Sprintf("%#v", this.UserUID)+",\n")
-	s = append(s, "URI: "+fmt.Sprintf("%#v", this.URI)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *PinRequest) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 7)
-	s = append(s, "&amp.PinRequest{")
-	if this.PinTarget != nil {
-		s = append(s, "PinTarget: "+fmt.Sprintf("%#v", this.PinTarget)+",\n")
-	}
-	if this.PinAttrs != nil {
-		s = append(s, "PinAttrs: "+fmt.Sprintf("%#v", this.PinAttrs)+",\n")
-	}
-	s = append(s, "StateSync: "+fmt.Sprintf("%#v", this.StateSync)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LaunchURL) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&amp.LaunchURL{")
-	s = append(s, "URL: "+fmt.Sprintf("%#v", this.URL)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return dAtA[:n], nil
 }
-func (this *TagUID) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 8)
-	s = append(s, "&amp.TagUID{")
-	s = append(s, "ID_0: "+fmt.Sprintf("%#v", this.ID_0)+",\n")
-	s = append(s, "ID_1: "+fmt.Sprintf("%#v", this.ID_1)+",\n")
-	s = append(s, "ID_2: "+fmt.Sprintf("%#v", this.ID_2)+",\n")
-	s = append(s, "UID: "+fmt.Sprintf("%#v", this.UID)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+
+func (m *LoginChallenge) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *Tag) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 17)
-	s = append(s, "&amp.Tag{")
-	s = append(s, "TagID_0: "+fmt.Sprintf("%#v", this.TagID_0)+",\n")
-	s = append(s, "TagID_1: "+fmt.Sprintf("%#v", this.TagID_1)+",\n")
-	s = append(s, "TagID_2: "+fmt.Sprintf("%#v", this.TagID_2)+",\n")
-	s = append(s, "UID: "+fmt.Sprintf("%#v", this.UID)+",\n")
-	s = append(s, "Text: "+fmt.Sprintf("%#v", this.Text)+",\n")
-	s = append(s, "Ordering: "+fmt.Sprintf("%#v", this.Ordering)+",\n")
-	s = append(s, "URL: "+fmt.Sprintf("%#v", this.URL)+",\n")
-	s = append(s, "ContentType: "+fmt.Sprintf("%#v", this.ContentType)+",\n")
-	s = append(s, "Metric: "+fmt.Sprintf("%#v", this.Metric)+",\n")
-	s = append(s, "SizeX: "+fmt.Sprintf("%#v", this.SizeX)+",\n")
-	s = append(s, "SizeY: "+fmt.Sprintf("%#v", this.SizeY)+",\n")
-	s = append(s, "SizeZ: "+fmt.Sprintf("%#v", this.SizeZ)+",\n")
-	if this.Tags != nil {
-		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
+
+func (m *LoginChallenge) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return len(dAtA) - i, nil
 }
-func (this *CryptoKey) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *LoginResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&amp.CryptoKey{")
-	s = append(s, "CryptoKitID: "+fmt.Sprintf("%#v", this.CryptoKitID)+",\n")
-	s = append(s, "KeyBytes: "+fmt.Sprintf("%#v", this.KeyBytes)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return dAtA[:n], nil
 }
-func (this *Err) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 7)
-	s = append(s, "&amp.Err{")
-	s = append(s, "Code: "+fmt.Sprintf("%#v", this.Code)+",\n")
-	s = append(s, "Level: "+fmt.Sprintf("%#v", this.Level)+",\n")
-	s = append(s, "Msg: "+fmt.Sprintf("%#v", this.Msg)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+
+func (m *LoginResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func valueToGoStringAmp(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+func (m *LoginResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.HashResponse) > 0 {
+		i -= len(m.HashResponse)
+		copy(dAtA[i:], m.HashResponse)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.HashResponse)))
+		i--
+		dAtA[i] = 0xa
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+	return len(dAtA) - i, nil
 }
-func (m *TxEnvelope) Marshal() (dAtA []byte, err error) {
+
+func (m *LoginCheckpoint) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2225,116 +2479,67 @@ func (m *TxEnvelope) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TxEnvelope) MarshalTo(dAtA []byte) (int, error) {
+func (m *LoginCheckpoint) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TxEnvelope) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LoginCheckpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.ContextCA != nil {
-		{
-			size, err := m.ContextCA.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAmp(dAtA, i, uint64(size))
-		}
+	if len(m.URI) > 0 {
+		i -= len(m.URI)
+		copy(dAtA[i:], m.URI)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.URI)))
 		i--
-		dAtA[i] = 0x1
+		dAtA[i] = 0x62
+	}
+	if len(m.UserUID) > 0 {
+		i -= len(m.UserUID)
+		copy(dAtA[i:], m.UserUID)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.UserUID)))
 		i--
-		dAtA[i] = 0x8a
+		dAtA[i] = 0x5a
 	}
-	if m.Tags != nil {
-		{
-			size, err := m.Tags.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAmp(dAtA, i, uint64(size))
-		}
+	if len(m.Tags) > 0 {
+		i -= len(m.Tags)
+		copy(dAtA[i:], m.Tags)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.Tags)))
 		i--
-		dAtA[i] = 0x1
+		dAtA[i] = 0x52
+	}
+	if m.Expiry != 0 {
+		i = encodeVarintAmp(dAtA, i, uint64(m.Expiry))
 		i--
-		dAtA[i] = 0x82
+		dAtA[i] = 0x20
 	}
-	if m.ToID != nil {
-		{
-			size, err := m.ToID.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAmp(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x7a
-	}
-	if m.FromID != nil {
-		{
-			size, err := m.FromID.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAmp(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x72
-	}
-	if m.ContextID_2 != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.ContextID_2))
-		i--
-		dAtA[i] = 0x61
-	}
-	if m.ContextID_1 != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.ContextID_1))
-		i--
-		dAtA[i] = 0x59
-	}
-	if m.ContextID_0 != 0 {
-		i = encodeVarintAmp(dAtA, i, uint64(m.ContextID_0))
-		i--
-		dAtA[i] = 0x50
-	}
-	if m.GenesisID_2 != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.GenesisID_2))
-		i--
-		dAtA[i] = 0x39
-	}
-	if m.GenesisID_1 != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.GenesisID_1))
-		i--
-		dAtA[i] = 0x31
-	}
-	if m.GenesisID_0 != 0 {
-		i = encodeVarintAmp(dAtA, i, uint64(m.GenesisID_0))
+	if len(m.RefreshToken) > 0 {
+		i -= len(m.RefreshToken)
+		copy(dAtA[i:], m.RefreshToken)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.RefreshToken)))
 		i--
-		dAtA[i] = 0x28
+		dAtA[i] = 0x1a
 	}
-	if m.OpCount != 0 {
-		i = encodeVarintAmp(dAtA, i, uint64(m.OpCount))
+	if len(m.AccessToken) > 0 {
+		i -= len(m.AccessToken)
+		copy(dAtA[i:], m.AccessToken)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.AccessToken)))
 		i--
-		dAtA[i] = 0x20
+		dAtA[i] = 0x12
 	}
-	if m.Status != 0 {
-		i = encodeVarintAmp(dAtA, i, uint64(m.Status))
+	if len(m.TokenType) > 0 {
+		i -= len(m.TokenType)
+		copy(dAtA[i:], m.TokenType)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.TokenType)))
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Login) Marshal() (dAtA []byte, err error) {
+func (m *PinRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2344,59 +2549,43 @@ func (m *Login) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Login) MarshalTo(dAtA []byte) (int, error) {
+func (m *PinRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Login) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Checkpoint != nil {
-		{
-			size, err := m.Checkpoint.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAmp(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x62
-	}
-	if len(m.Tags) > 0 {
-		i -= len(m.Tags)
-		copy(dAtA[i:], m.Tags)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.Tags)))
-		i--
-		dAtA[i] = 0x4a
-	}
-	if len(m.HostAddr) > 0 {
-		i -= len(m.HostAddr)
-		copy(dAtA[i:], m.HostAddr)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.HostAddr)))
+	if m.TimeoutMs != 0 {
+		i = encodeVarintAmp(dAtA, i, uint64(m.TimeoutMs))
 		i--
-		dAtA[i] = 0x42
+		dAtA[i] = 0x40
 	}
-	if len(m.DeviceLabel) > 0 {
-		i -= len(m.DeviceLabel)
-		copy(dAtA[i:], m.DeviceLabel)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.DeviceLabel)))
+	if m.StateSync != 0 {
+		i = encodeVarintAmp(dAtA, i, uint64(m.StateSync))
 		i--
-		dAtA[i] = 0x32
+		dAtA[i] = 0x30
 	}
-	if len(m.DeviceUID) > 0 {
-		i -= len(m.DeviceUID)
-		copy(dAtA[i:], m.DeviceUID)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.DeviceUID)))
-		i--
-		dAtA[i] = 0x2a
+	if len(m.PinAttrs) > 0 {
+		for iNdEx := len(m.PinAttrs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PinAttrs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAmp(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
 	}
-	if m.UserUID != nil {
+	if m.PinTarget != nil {
 		{
-			size, err := m.UserUID.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.PinTarget.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -2406,17 +2595,10 @@ func (m *Login) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.UserLabel) > 0 {
-		i -= len(m.UserLabel)
-		copy(dAtA[i:], m.UserLabel)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.UserLabel)))
-		i--
-		dAtA[i] = 0xa
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *LoginChallenge) Marshal() (dAtA []byte, err error) {
+func (m *CancelRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2426,27 +2608,27 @@ func (m *LoginChallenge) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LoginChallenge) MarshalTo(dAtA []byte) (int, error) {
+func (m *CancelRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LoginChallenge) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CancelRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Hash) > 0 {
-		i -= len(m.Hash)
-		copy(dAtA[i:], m.Hash)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.Hash)))
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.Reason)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *LoginResponse) Marshal() (dAtA []byte, err error) {
+func (m *LaunchURL) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2456,27 +2638,27 @@ func (m *LoginResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LoginResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *LaunchURL) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LoginResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LaunchURL) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.HashResponse) > 0 {
-		i -= len(m.HashResponse)
-		copy(dAtA[i:], m.HashResponse)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.HashResponse)))
+	if len(m.URL) > 0 {
+		i -= len(m.URL)
+		copy(dAtA[i:], m.URL)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.URL)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *LoginCheckpoint) Marshal() (dAtA []byte, err error) {
+func (m *RegisterDefs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2486,67 +2668,48 @@ func (m *LoginCheckpoint) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LoginCheckpoint) MarshalTo(dAtA []byte) (int, error) {
+func (m *RegisterDefs) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LoginCheckpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RegisterDefs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.URI) > 0 {
-		i -= len(m.URI)
-		copy(dAtA[i:], m.URI)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.URI)))
-		i--
-		dAtA[i] = 0x62
-	}
-	if len(m.UserUID) > 0 {
-		i -= len(m.UserUID)
-		copy(dAtA[i:], m.UserUID)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.UserUID)))
-		i--
-		dAtA[i] = 0x5a
-	}
-	if len(m.Tags) > 0 {
-		i -= len(m.Tags)
-		copy(dAtA[i:], m.Tags)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.Tags)))
-		i--
-		dAtA[i] = 0x52
-	}
-	if m.Expiry != 0 {
-		i = encodeVarintAmp(dAtA, i, uint64(m.Expiry))
-		i--
-		dAtA[i] = 0x20
-	}
-	if len(m.RefreshToken) > 0 {
-		i -= len(m.RefreshToken)
-		copy(dAtA[i:], m.RefreshToken)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.RefreshToken)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.AccessToken) > 0 {
-		i -= len(m.AccessToken)
-		copy(dAtA[i:], m.AccessToken)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.AccessToken)))
-		i--
-		dAtA[i] = 0x12
+	if len(m.Apps) > 0 {
+		for iNdEx := len(m.Apps) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Apps[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAmp(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	if len(m.TokenType) > 0 {
-		i -= len(m.TokenType)
-		copy(dAtA[i:], m.TokenType)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.TokenType)))
-		i--
-		dAtA[i] = 0xa
+	if len(m.Attrs) > 0 {
+		for iNdEx := len(m.Attrs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Attrs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAmp(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *PinRequest) Marshal() (dAtA []byte, err error) {
+func (m *AttrSchema) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2556,25 +2719,20 @@ func (m *PinRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PinRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *AttrSchema) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AttrSchema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.StateSync != 0 {
-		i = encodeVarintAmp(dAtA, i, uint64(m.StateSync))
-		i--
-		dAtA[i] = 0x30
-	}
-	if len(m.PinAttrs) > 0 {
-		for iNdEx := len(m.PinAttrs) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Fields) > 0 {
+		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.PinAttrs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Fields[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -2585,9 +2743,23 @@ func (m *PinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0x22
 		}
 	}
-	if m.PinTarget != nil {
+	if len(m.GoType) > 0 {
+		i -= len(m.GoType)
+		copy(dAtA[i:], m.GoType)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.GoType)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.TypeName) > 0 {
+		i -= len(m.TypeName)
+		copy(dAtA[i:], m.TypeName)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.TypeName)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Spec != nil {
 		{
-			size, err := m.PinTarget.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Spec.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -2595,12 +2767,12 @@ func (m *PinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintAmp(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *LaunchURL) Marshal() (dAtA []byte, err error) {
+func (m *FieldSchema) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2610,26 +2782,245 @@ func (m *LaunchURL) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LaunchURL) MarshalTo(dAtA []byte) (int, error) {
+func (m *FieldSchema) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LaunchURL) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *FieldSchema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.URL) > 0 {
-		i -= len(m.URL)
-		copy(dAtA[i:], m.URL)
-		i = encodeVarintAmp(dAtA, i, uint64(len(m.URL)))
+	if m.Repeated {
+		i--
+		if m.Repeated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.TypeName) > 0 {
+		i -= len(m.TypeName)
+		copy(dAtA[i:], m.TypeName)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.TypeName)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Number != 0 {
+		i = encodeVarintAmp(dAtA, i, uint64(m.Number))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AppSchema) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppSchema) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AppSchema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ContentTypes) > 0 {
+		for iNdEx := len(m.ContentTypes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ContentTypes[iNdEx])
+			copy(dAtA[i:], m.ContentTypes[iNdEx])
+			i = encodeVarintAmp(dAtA, i, uint64(len(m.ContentTypes[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Dependencies) > 0 {
+		for iNdEx := len(m.Dependencies) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Dependencies[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAmp(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Invocations) > 0 {
+		for iNdEx := len(m.Invocations) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Invocations[iNdEx])
+			copy(dAtA[i:], m.Invocations[iNdEx])
+			i = encodeVarintAmp(dAtA, i, uint64(len(m.Invocations[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Desc) > 0 {
+		i -= len(m.Desc)
+		copy(dAtA[i:], m.Desc)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.Desc)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Spec != nil {
+		{
+			size, err := m.Spec.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAmp(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AppReloaded) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppReloaded) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AppReloaded) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.PrevVersion) > 0 {
+		i -= len(m.PrevVersion)
+		copy(dAtA[i:], m.PrevVersion)
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.PrevVersion)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Spec != nil {
+		{
+			size, err := m.Spec.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAmp(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeltaResync) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeltaResync) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeltaResync) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Elements) > 0 {
+		for iNdEx := len(m.Elements) - 1; iNdEx >= 0; iNdEx-- {
+			i -= 8
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Elements[iNdEx]))
+		}
+		i = encodeVarintAmp(dAtA, i, uint64(len(m.Elements)*8))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
+func (m *FlowCredit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FlowCredit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FlowCredit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Bytes != 0 {
+		i = encodeVarintAmp(dAtA, i, uint64(m.Bytes))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *TagUID) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2881,479 +3272,2524 @@ func encodeVarintAmp(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	return base
 }
-func (m *TxEnvelope) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Status != 0 {
-		n += 1 + sovAmp(uint64(m.Status))
+func (this *TxEnvelope) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if m.OpCount != 0 {
-		n += 1 + sovAmp(uint64(m.OpCount))
+
+	that1, ok := that.(*TxEnvelope)
+	if !ok {
+		that2, ok := that.(TxEnvelope)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.GenesisID_0 != 0 {
-		n += 1 + sovAmp(uint64(m.GenesisID_0))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.GenesisID_1 != 0 {
-		n += 9
+	if this.Status != that1.Status {
+		return false
 	}
-	if m.GenesisID_2 != 0 {
-		n += 9
+	if this.Priority != that1.Priority {
+		return false
 	}
-	if m.ContextID_0 != 0 {
-		n += 1 + sovAmp(uint64(m.ContextID_0))
+	if this.OpCount != that1.OpCount {
+		return false
 	}
-	if m.ContextID_1 != 0 {
-		n += 9
+	if this.GenesisID_0 != that1.GenesisID_0 {
+		return false
 	}
-	if m.ContextID_2 != 0 {
-		n += 9
+	if this.GenesisID_1 != that1.GenesisID_1 {
+		return false
 	}
-	if m.FromID != nil {
-		l = m.FromID.Size()
-		n += 1 + l + sovAmp(uint64(l))
+	if this.GenesisID_2 != that1.GenesisID_2 {
+		return false
 	}
-	if m.ToID != nil {
-		l = m.ToID.Size()
-		n += 1 + l + sovAmp(uint64(l))
+	if this.ContextID_0 != that1.ContextID_0 {
+		return false
 	}
-	if m.Tags != nil {
-		l = m.Tags.Size()
-		n += 2 + l + sovAmp(uint64(l))
+	if this.ContextID_1 != that1.ContextID_1 {
+		return false
 	}
-	if m.ContextCA != nil {
-		l = m.ContextCA.Size()
-		n += 2 + l + sovAmp(uint64(l))
+	if this.ContextID_2 != that1.ContextID_2 {
+		return false
 	}
-	return n
-}
-
-func (m *Login) Size() (n int) {
-	if m == nil {
-		return 0
+	if !this.FromID.Equal(that1.FromID) {
+		return false
 	}
-	var l int
-	_ = l
-	l = len(m.UserLabel)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if !this.ToID.Equal(that1.ToID) {
+		return false
 	}
-	if m.UserUID != nil {
-		l = m.UserUID.Size()
-		n += 1 + l + sovAmp(uint64(l))
+	if !this.Tags.Equal(that1.Tags) {
+		return false
 	}
-	l = len(m.DeviceUID)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if !this.ContextCA.Equal(that1.ContextCA) {
+		return false
 	}
-	l = len(m.DeviceLabel)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	return true
+}
+func (this *Login) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	l = len(m.HostAddr)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+
+	that1, ok := that.(*Login)
+	if !ok {
+		that2, ok := that.(Login)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	l = len(m.Tags)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Checkpoint != nil {
-		l = m.Checkpoint.Size()
-		n += 1 + l + sovAmp(uint64(l))
+	if this.UserLabel != that1.UserLabel {
+		return false
 	}
-	return n
-}
-
-func (m *LoginChallenge) Size() (n int) {
-	if m == nil {
-		return 0
+	if !this.UserUID.Equal(that1.UserUID) {
+		return false
 	}
-	var l int
-	_ = l
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if this.DeviceUID != that1.DeviceUID {
+		return false
 	}
-	return n
-}
-
-func (m *LoginResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	if this.DeviceLabel != that1.DeviceLabel {
+		return false
 	}
-	var l int
-	_ = l
-	l = len(m.HashResponse)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if this.HostAddr != that1.HostAddr {
+		return false
 	}
-	return n
+	if this.Tags != that1.Tags {
+		return false
+	}
+	if !this.Checkpoint.Equal(that1.Checkpoint) {
+		return false
+	}
+	return true
 }
-
-func (m *LoginCheckpoint) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *LoginChallenge) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	var l int
-	_ = l
-	l = len(m.TokenType)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+
+	that1, ok := that.(*LoginChallenge)
+	if !ok {
+		that2, ok := that.(LoginChallenge)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	l = len(m.AccessToken)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	l = len(m.RefreshToken)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if !bytes.Equal(this.Hash, that1.Hash) {
+		return false
 	}
-	if m.Expiry != 0 {
-		n += 1 + sovAmp(uint64(m.Expiry))
+	return true
+}
+func (this *LoginResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	l = len(m.Tags)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+
+	that1, ok := that.(*LoginResponse)
+	if !ok {
+		that2, ok := that.(LoginResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	l = len(m.UserUID)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	l = len(m.URI)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if !bytes.Equal(this.HashResponse, that1.HashResponse) {
+		return false
 	}
-	return n
+	return true
 }
-
-func (m *PinRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.PinTarget != nil {
-		l = m.PinTarget.Size()
-		n += 1 + l + sovAmp(uint64(l))
+func (this *LoginCheckpoint) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if len(m.PinAttrs) > 0 {
-		for _, e := range m.PinAttrs {
-			l = e.Size()
-			n += 1 + l + sovAmp(uint64(l))
+
+	that1, ok := that.(*LoginCheckpoint)
+	if !ok {
+		that2, ok := that.(LoginCheckpoint)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if m.StateSync != 0 {
-		n += 1 + sovAmp(uint64(m.StateSync))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return n
-}
-
-func (m *LaunchURL) Size() (n int) {
-	if m == nil {
-		return 0
+	if this.TokenType != that1.TokenType {
+		return false
 	}
-	var l int
-	_ = l
-	l = len(m.URL)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if this.AccessToken != that1.AccessToken {
+		return false
 	}
-	return n
-}
-
-func (m *TagUID) Size() (n int) {
-	if m == nil {
-		return 0
+	if this.RefreshToken != that1.RefreshToken {
+		return false
 	}
-	var l int
-	_ = l
-	if m.ID_0 != 0 {
-		n += 1 + sovAmp(uint64(m.ID_0))
+	if this.Expiry != that1.Expiry {
+		return false
 	}
-	if m.ID_1 != 0 {
-		n += 9
+	if this.Tags != that1.Tags {
+		return false
 	}
-	if m.ID_2 != 0 {
-		n += 9
+	if this.UserUID != that1.UserUID {
+		return false
 	}
-	l = len(m.UID)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if this.URI != that1.URI {
+		return false
 	}
-	return n
+	return true
 }
+func (this *PinRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Tag) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*PinRequest)
+	if !ok {
+		that2, ok := that.(PinRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	if m.TagID_0 != 0 {
-		n += 1 + sovAmp(uint64(m.TagID_0))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.TagID_1 != 0 {
-		n += 9
+	if !this.PinTarget.Equal(that1.PinTarget) {
+		return false
 	}
-	if m.TagID_2 != 0 {
-		n += 9
+	if len(this.PinAttrs) != len(that1.PinAttrs) {
+		return false
 	}
-	l = len(m.UID)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	for i := range this.PinAttrs {
+		if !this.PinAttrs[i].Equal(that1.PinAttrs[i]) {
+			return false
+		}
 	}
-	l = len(m.Text)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if this.StateSync != that1.StateSync {
+		return false
 	}
-	if m.Ordering != 0 {
-		n += 9
+	if this.TimeoutMs != that1.TimeoutMs {
+		return false
 	}
-	l = len(m.URL)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	return true
+}
+func (this *CancelRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	l = len(m.ContentType)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
-	}
-	if m.Metric != 0 {
-		n += 2 + sovAmp(uint64(m.Metric))
+
+	that1, ok := that.(*CancelRequest)
+	if !ok {
+		that2, ok := that.(CancelRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.SizeX != 0 {
-		n += 2 + sovAmp(uint64(m.SizeX))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.SizeY != 0 {
-		n += 2 + sovAmp(uint64(m.SizeY))
+	if this.Reason != that1.Reason {
+		return false
 	}
-	if m.SizeZ != 0 {
-		n += 2 + sovAmp(uint64(m.SizeZ))
+	return true
+}
+func (this *LaunchURL) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if len(m.Tags) > 0 {
-		for _, e := range m.Tags {
-			l = e.Size()
-			n += 2 + l + sovAmp(uint64(l))
+
+	that1, ok := that.(*LaunchURL)
+	if !ok {
+		that2, ok := that.(LaunchURL)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	return n
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.URL != that1.URL {
+		return false
+	}
+	return true
 }
+func (this *RegisterDefs) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *CryptoKey) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*RegisterDefs)
+	if !ok {
+		that2, ok := that.(RegisterDefs)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	if m.CryptoKitID != 0 {
-		n += 1 + sovAmp(uint64(m.CryptoKitID))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	l = len(m.KeyBytes)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if len(this.Attrs) != len(that1.Attrs) {
+		return false
 	}
-	return n
+	for i := range this.Attrs {
+		if !this.Attrs[i].Equal(that1.Attrs[i]) {
+			return false
+		}
+	}
+	if len(this.Apps) != len(that1.Apps) {
+		return false
+	}
+	for i := range this.Apps {
+		if !this.Apps[i].Equal(that1.Apps[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *AttrSchema) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Err) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*AttrSchema)
+	if !ok {
+		that2, ok := that.(AttrSchema)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	if m.Code != 0 {
-		n += 1 + sovAmp(uint64(m.Code))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Level != 0 {
-		n += 1 + sovAmp(uint64(m.Level))
+	if !this.Spec.Equal(that1.Spec) {
+		return false
 	}
-	l = len(m.Msg)
-	if l > 0 {
-		n += 1 + l + sovAmp(uint64(l))
+	if this.TypeName != that1.TypeName {
+		return false
 	}
-	return n
-}
-
-func sovAmp(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozAmp(x uint64) (n int) {
-	return sovAmp(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *TxEnvelope) String() string {
-	if this == nil {
-		return "nil"
+	if this.GoType != that1.GoType {
+		return false
 	}
-	s := strings.Join([]string{`&TxEnvelope{`,
-		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
-		`OpCount:` + fmt.Sprintf("%v", this.OpCount) + `,`,
-		`GenesisID_0:` + fmt.Sprintf("%v", this.GenesisID_0) + `,`,
-		`GenesisID_1:` + fmt.Sprintf("%v", this.GenesisID_1) + `,`,
-		`GenesisID_2:` + fmt.Sprintf("%v", this.GenesisID_2) + `,`,
-		`ContextID_0:` + fmt.Sprintf("%v", this.ContextID_0) + `,`,
-		`ContextID_1:` + fmt.Sprintf("%v", this.ContextID_1) + `,`,
-		`ContextID_2:` + fmt.Sprintf("%v", this.ContextID_2) + `,`,
-		`FromID:` + strings.Replace(this.FromID.String(), "Tag", "Tag", 1) + `,`,
-		`ToID:` + strings.Replace(this.ToID.String(), "Tag", "Tag", 1) + `,`,
-		`Tags:` + strings.Replace(this.Tags.String(), "Tag", "Tag", 1) + `,`,
-		`ContextCA:` + strings.Replace(this.ContextCA.String(), "Tag", "Tag", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Login) String() string {
-	if this == nil {
-		return "nil"
+	if len(this.Fields) != len(that1.Fields) {
+		return false
 	}
-	s := strings.Join([]string{`&Login{`,
-		`UserLabel:` + fmt.Sprintf("%v", this.UserLabel) + `,`,
-		`UserUID:` + strings.Replace(this.UserUID.String(), "Tag", "Tag", 1) + `,`,
-		`DeviceUID:` + fmt.Sprintf("%v", this.DeviceUID) + `,`,
-		`DeviceLabel:` + fmt.Sprintf("%v", this.DeviceLabel) + `,`,
-		`HostAddr:` + fmt.Sprintf("%v", this.HostAddr) + `,`,
-		`Tags:` + fmt.Sprintf("%v", this.Tags) + `,`,
-		`Checkpoint:` + strings.Replace(this.Checkpoint.String(), "LoginCheckpoint", "LoginCheckpoint", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LoginChallenge) String() string {
-	if this == nil {
-		return "nil"
+	for i := range this.Fields {
+		if !this.Fields[i].Equal(that1.Fields[i]) {
+			return false
+		}
 	}
-	s := strings.Join([]string{`&LoginChallenge{`,
-		`Hash:` + fmt.Sprintf("%v", this.Hash) + `,`,
-		`}`,
-	}, "")
-	return s
+	return true
 }
-func (this *LoginResponse) String() string {
-	if this == nil {
-		return "nil"
+func (this *FieldSchema) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := strings.Join([]string{`&LoginResponse{`,
-		`HashResponse:` + fmt.Sprintf("%v", this.HashResponse) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LoginCheckpoint) String() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*FieldSchema)
+	if !ok {
+		that2, ok := that.(FieldSchema)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := strings.Join([]string{`&LoginCheckpoint{`,
-		`TokenType:` + fmt.Sprintf("%v", this.TokenType) + `,`,
-		`AccessToken:` + fmt.Sprintf("%v", this.AccessToken) + `,`,
-		`RefreshToken:` + fmt.Sprintf("%v", this.RefreshToken) + `,`,
-		`Expiry:` + fmt.Sprintf("%v", this.Expiry) + `,`,
-		`Tags:` + fmt.Sprintf("%v", this.Tags) + `,`,
-		`UserUID:` + fmt.Sprintf("%v", this.UserUID) + `,`,
-		`URI:` + fmt.Sprintf("%v", this.URI) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *PinRequest) String() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	repeatedStringForPinAttrs := "[]*Tag{"
-	for _, f := range this.PinAttrs {
-		repeatedStringForPinAttrs += strings.Replace(f.String(), "Tag", "Tag", 1) + ","
+	if this.Name != that1.Name {
+		return false
 	}
-	repeatedStringForPinAttrs += "}"
-	s := strings.Join([]string{`&PinRequest{`,
-		`PinTarget:` + strings.Replace(this.PinTarget.String(), "Tag", "Tag", 1) + `,`,
-		`PinAttrs:` + repeatedStringForPinAttrs + `,`,
-		`StateSync:` + fmt.Sprintf("%v", this.StateSync) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LaunchURL) String() string {
-	if this == nil {
-		return "nil"
+	if this.Number != that1.Number {
+		return false
 	}
-	s := strings.Join([]string{`&LaunchURL{`,
-		`URL:` + fmt.Sprintf("%v", this.URL) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *TagUID) String() string {
-	if this == nil {
-		return "nil"
+	if this.Type != that1.Type {
+		return false
 	}
-	s := strings.Join([]string{`&TagUID{`,
-		`ID_0:` + fmt.Sprintf("%v", this.ID_0) + `,`,
-		`ID_1:` + fmt.Sprintf("%v", this.ID_1) + `,`,
-		`ID_2:` + fmt.Sprintf("%v", this.ID_2) + `,`,
-		`UID:` + fmt.Sprintf("%v", this.UID) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Tag) String() string {
-	if this == nil {
-		return "nil"
+	if this.TypeName != that1.TypeName {
+		return false
 	}
-	repeatedStringForTags := "[]*Tag{"
-	for _, f := range this.Tags {
-		repeatedStringForTags += strings.Replace(f.String(), "Tag", "Tag", 1) + ","
+	if this.Repeated != that1.Repeated {
+		return false
 	}
-	repeatedStringForTags += "}"
-	s := strings.Join([]string{`&Tag{`,
-		`TagID_0:` + fmt.Sprintf("%v", this.TagID_0) + `,`,
-		`TagID_1:` + fmt.Sprintf("%v", this.TagID_1) + `,`,
-		`TagID_2:` + fmt.Sprintf("%v", this.TagID_2) + `,`,
-		`UID:` + fmt.Sprintf("%v", this.UID) + `,`,
-		`Text:` + fmt.Sprintf("%v", this.Text) + `,`,
-		`Ordering:` + fmt.Sprintf("%v", this.Ordering) + `,`,
-		`URL:` + fmt.Sprintf("%v", this.URL) + `,`,
-		`ContentType:` + fmt.Sprintf("%v", this.ContentType) + `,`,
-		`Metric:` + fmt.Sprintf("%v", this.Metric) + `,`,
-		`SizeX:` + fmt.Sprintf("%v", this.SizeX) + `,`,
-		`SizeY:` + fmt.Sprintf("%v", this.SizeY) + `,`,
-		`SizeZ:` + fmt.Sprintf("%v", this.SizeZ) + `,`,
-		`Tags:` + repeatedStringForTags + `,`,
-		`}`,
-	}, "")
-	return s
+	return true
 }
-func (this *CryptoKey) String() string {
-	if this == nil {
-		return "nil"
+func (this *AppSchema) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*AppSchema)
+	if !ok {
+		that2, ok := that.(AppSchema)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Spec.Equal(that1.Spec) {
+		return false
+	}
+	if this.Desc != that1.Desc {
+		return false
+	}
+	if this.Version != that1.Version {
+		return false
+	}
+	if len(this.Invocations) != len(that1.Invocations) {
+		return false
+	}
+	for i := range this.Invocations {
+		if this.Invocations[i] != that1.Invocations[i] {
+			return false
+		}
+	}
+	if len(this.Dependencies) != len(that1.Dependencies) {
+		return false
+	}
+	for i := range this.Dependencies {
+		if !this.Dependencies[i].Equal(that1.Dependencies[i]) {
+			return false
+		}
+	}
+	if len(this.ContentTypes) != len(that1.ContentTypes) {
+		return false
+	}
+	for i := range this.ContentTypes {
+		if this.ContentTypes[i] != that1.ContentTypes[i] {
+			return false
+		}
+	}
+	return true
+}
+func (this *AppReloaded) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*AppReloaded)
+	if !ok {
+		that2, ok := that.(AppReloaded)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Spec.Equal(that1.Spec) {
+		return false
+	}
+	if this.PrevVersion != that1.PrevVersion {
+		return false
+	}
+	if this.Version != that1.Version {
+		return false
+	}
+	return true
+}
+func (this *DeltaResync) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*DeltaResync)
+	if !ok {
+		that2, ok := that.(DeltaResync)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Elements) != len(that1.Elements) {
+		return false
+	}
+	for i := range this.Elements {
+		if this.Elements[i] != that1.Elements[i] {
+			return false
+		}
+	}
+	return true
+}
+func (this *FlowCredit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FlowCredit)
+	if !ok {
+		that2, ok := that.(FlowCredit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Bytes != that1.Bytes {
+		return false
+	}
+	return true
+}
+func (this *TagUID) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TagUID)
+	if !ok {
+		that2, ok := that.(TagUID)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID_0 != that1.ID_0 {
+		return false
+	}
+	if this.ID_1 != that1.ID_1 {
+		return false
+	}
+	if this.ID_2 != that1.ID_2 {
+		return false
+	}
+	if this.UID != that1.UID {
+		return false
+	}
+	return true
+}
+func (this *Tag) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Tag)
+	if !ok {
+		that2, ok := that.(Tag)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.TagID_0 != that1.TagID_0 {
+		return false
+	}
+	if this.TagID_1 != that1.TagID_1 {
+		return false
+	}
+	if this.TagID_2 != that1.TagID_2 {
+		return false
+	}
+	if this.UID != that1.UID {
+		return false
+	}
+	if this.Text != that1.Text {
+		return false
+	}
+	if this.Ordering != that1.Ordering {
+		return false
+	}
+	if this.URL != that1.URL {
+		return false
+	}
+	if this.ContentType != that1.ContentType {
+		return false
+	}
+	if this.Metric != that1.Metric {
+		return false
+	}
+	if this.SizeX != that1.SizeX {
+		return false
+	}
+	if this.SizeY != that1.SizeY {
+		return false
+	}
+	if this.SizeZ != that1.SizeZ {
+		return false
+	}
+	if len(this.Tags) != len(that1.Tags) {
+		return false
+	}
+	for i := range this.Tags {
+		if !this.Tags[i].Equal(that1.Tags[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *CryptoKey) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CryptoKey)
+	if !ok {
+		that2, ok := that.(CryptoKey)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.CryptoKitID != that1.CryptoKitID {
+		return false
+	}
+	if !bytes.Equal(this.KeyBytes, that1.KeyBytes) {
+		return false
+	}
+	return true
+}
+func (this *Err) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Err)
+	if !ok {
+		that2, ok := that.(Err)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Code != that1.Code {
+		return false
+	}
+	if this.Level != that1.Level {
+		return false
+	}
+	if this.Msg != that1.Msg {
+		return false
+	}
+	return true
+}
+func (this *TxEnvelope) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 17)
+	s = append(s, "&amp.TxEnvelope{")
+	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
+	s = append(s, "Priority: "+fmt.Sprintf("%#v", this.Priority)+",\n")
+	s = append(s, "OpCount: "+fmt.Sprintf("%#v", this.OpCount)+",\n")
+	s = append(s, "GenesisID_0: "+fmt.Sprintf("%#v", this.GenesisID_0)+",\n")
+	s = append(s, "GenesisID_1: "+fmt.Sprintf("%#v", this.GenesisID_1)+",\n")
+	s = append(s, "GenesisID_2: "+fmt.Sprintf("%#v", this.GenesisID_2)+",\n")
+	s = append(s, "ContextID_0: "+fmt.Sprintf("%#v", this.ContextID_0)+",\n")
+	s = append(s, "ContextID_1: "+fmt.Sprintf("%#v", this.ContextID_1)+",\n")
+	s = append(s, "ContextID_2: "+fmt.Sprintf("%#v", this.ContextID_2)+",\n")
+	if this.FromID != nil {
+		s = append(s, "FromID: "+fmt.Sprintf("%#v", this.FromID)+",\n")
+	}
+	if this.ToID != nil {
+		s = append(s, "ToID: "+fmt.Sprintf("%#v", this.ToID)+",\n")
+	}
+	if this.Tags != nil {
+		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
+	}
+	if this.ContextCA != nil {
+		s = append(s, "ContextCA: "+fmt.Sprintf("%#v", this.ContextCA)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Login) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 11)
+	s = append(s, "&amp.Login{")
+	s = append(s, "UserLabel: "+fmt.Sprintf("%#v", this.UserLabel)+",\n")
+	if this.UserUID != nil {
+		s = append(s, "UserUID: "+fmt.Sprintf("%#v", this.UserUID)+",\n")
+	}
+	s = append(s, "DeviceUID: "+fmt.Sprintf("%#v", this.DeviceUID)+",\n")
+	s = append(s, "DeviceLabel: "+fmt.Sprintf("%#v", this.DeviceLabel)+",\n")
+	s = append(s, "HostAddr: "+fmt.Sprintf("%#v", this.HostAddr)+",\n")
+	s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
+	if this.Checkpoint != nil {
+		s = append(s, "Checkpoint: "+fmt.Sprintf("%#v", this.Checkpoint)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LoginChallenge) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&amp.LoginChallenge{")
+	s = append(s, "Hash: "+fmt.Sprintf("%#v", this.Hash)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LoginResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&amp.LoginResponse{")
+	s = append(s, "HashResponse: "+fmt.Sprintf("%#v", this.HashResponse)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LoginCheckpoint) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 11)
+	s = append(s, "&amp.LoginCheckpoint{")
+	s = append(s, "TokenType: "+fmt.Sprintf("%#v", this.TokenType)+",\n")
+	s = append(s, "AccessToken: "+fmt.Sprintf("%#v", this.AccessToken)+",\n")
+	s = append(s, "RefreshToken: "+fmt.Sprintf("%#v", this.RefreshToken)+",\n")
+	s = append(s, "Expiry: "+fmt.Sprintf("%#v", this.Expiry)+",\n")
+	s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
+	s = append(s, "UserUID: "+fmt.Sprintf("%#v", this.UserUID)+",\n")
+	s = append(s, "URI: "+fmt.Sprintf("%#v", this.URI)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PinRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&amp.PinRequest{")
+	if this.PinTarget != nil {
+		s = append(s, "PinTarget: "+fmt.Sprintf("%#v", this.PinTarget)+",\n")
+	}
+	if this.PinAttrs != nil {
+		s = append(s, "PinAttrs: "+fmt.Sprintf("%#v", this.PinAttrs)+",\n")
+	}
+	s = append(s, "StateSync: "+fmt.Sprintf("%#v", this.StateSync)+",\n")
+	s = append(s, "TimeoutMs: "+fmt.Sprintf("%#v", this.TimeoutMs)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CancelRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&amp.CancelRequest{")
+	s = append(s, "Reason: "+fmt.Sprintf("%#v", this.Reason)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LaunchURL) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&amp.LaunchURL{")
+	s = append(s, "URL: "+fmt.Sprintf("%#v", this.URL)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RegisterDefs) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&amp.RegisterDefs{")
+	if this.Attrs != nil {
+		s = append(s, "Attrs: "+fmt.Sprintf("%#v", this.Attrs)+",\n")
+	}
+	if this.Apps != nil {
+		s = append(s, "Apps: "+fmt.Sprintf("%#v", this.Apps)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AttrSchema) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&amp.AttrSchema{")
+	if this.Spec != nil {
+		s = append(s, "Spec: "+fmt.Sprintf("%#v", this.Spec)+",\n")
+	}
+	s = append(s, "TypeName: "+fmt.Sprintf("%#v", this.TypeName)+",\n")
+	s = append(s, "GoType: "+fmt.Sprintf("%#v", this.GoType)+",\n")
+	if this.Fields != nil {
+		s = append(s, "Fields: "+fmt.Sprintf("%#v", this.Fields)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *FieldSchema) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&amp.FieldSchema{")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "Number: "+fmt.Sprintf("%#v", this.Number)+",\n")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "TypeName: "+fmt.Sprintf("%#v", this.TypeName)+",\n")
+	s = append(s, "Repeated: "+fmt.Sprintf("%#v", this.Repeated)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AppSchema) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 10)
+	s = append(s, "&amp.AppSchema{")
+	if this.Spec != nil {
+		s = append(s, "Spec: "+fmt.Sprintf("%#v", this.Spec)+",\n")
+	}
+	s = append(s, "Desc: "+fmt.Sprintf("%#v", this.Desc)+",\n")
+	s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	s = append(s, "Invocations: "+fmt.Sprintf("%#v", this.Invocations)+",\n")
+	if this.Dependencies != nil {
+		s = append(s, "Dependencies: "+fmt.Sprintf("%#v", this.Dependencies)+",\n")
+	}
+	s = append(s, "ContentTypes: "+fmt.Sprintf("%#v", this.ContentTypes)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AppReloaded) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&amp.AppReloaded{")
+	if this.Spec != nil {
+		s = append(s, "Spec: "+fmt.Sprintf("%#v", this.Spec)+",\n")
+	}
+	s = append(s, "PrevVersion: "+fmt.Sprintf("%#v", this.PrevVersion)+",\n")
+	s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeltaResync) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&amp.DeltaResync{")
+	s = append(s, "Elements: "+fmt.Sprintf("%#v", this.Elements)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *FlowCredit) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&amp.FlowCredit{")
+	s = append(s, "Bytes: "+fmt.Sprintf("%#v", this.Bytes)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TagUID) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&amp.TagUID{")
+	s = append(s, "ID_0: "+fmt.Sprintf("%#v", this.ID_0)+",\n")
+	s = append(s, "ID_1: "+fmt.Sprintf("%#v", this.ID_1)+",\n")
+	s = append(s, "ID_2: "+fmt.Sprintf("%#v", this.ID_2)+",\n")
+	s = append(s, "UID: "+fmt.Sprintf("%#v", this.UID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Tag) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 17)
+	s = append(s, "&amp.Tag{")
+	s = append(s, "TagID_0: "+fmt.Sprintf("%#v", this.TagID_0)+",\n")
+	s = append(s, "TagID_1: "+fmt.Sprintf("%#v", this.TagID_1)+",\n")
+	s = append(s, "TagID_2: "+fmt.Sprintf("%#v", this.TagID_2)+",\n")
+	s = append(s, "UID: "+fmt.Sprintf("%#v", this.UID)+",\n")
+	s = append(s, "Text: "+fmt.Sprintf("%#v", this.Text)+",\n")
+	s = append(s, "Ordering: "+fmt.Sprintf("%#v", this.Ordering)+",\n")
+	s = append(s, "URL: "+fmt.Sprintf("%#v", this.URL)+",\n")
+	s = append(s, "ContentType: "+fmt.Sprintf("%#v", this.ContentType)+",\n")
+	s = append(s, "Metric: "+fmt.Sprintf("%#v", this.Metric)+",\n")
+	s = append(s, "SizeX: "+fmt.Sprintf("%#v", this.SizeX)+",\n")
+	s = append(s, "SizeY: "+fmt.Sprintf("%#v", this.SizeY)+",\n")
+	s = append(s, "SizeZ: "+fmt.Sprintf("%#v", this.SizeZ)+",\n")
+	if this.Tags != nil {
+		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CryptoKey) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&amp.CryptoKey{")
+	s = append(s, "CryptoKitID: "+fmt.Sprintf("%#v", this.CryptoKitID)+",\n")
+	s = append(s, "KeyBytes: "+fmt.Sprintf("%#v", this.KeyBytes)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Err) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&amp.Err{")
+	s = append(s, "Code: "+fmt.Sprintf("%#v", this.Code)+",\n")
+	s = append(s, "Level: "+fmt.Sprintf("%#v", this.Level)+",\n")
+	s = append(s, "Msg: "+fmt.Sprintf("%#v", this.Msg)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringAmp(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *TxEnvelope) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovAmp(uint64(m.Status))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovAmp(uint64(m.Priority))
+	}
+	if m.OpCount != 0 {
+		n += 1 + sovAmp(uint64(m.OpCount))
+	}
+	if m.GenesisID_0 != 0 {
+		n += 1 + sovAmp(uint64(m.GenesisID_0))
+	}
+	if m.GenesisID_1 != 0 {
+		n += 9
+	}
+	if m.GenesisID_2 != 0 {
+		n += 9
+	}
+	if m.ContextID_0 != 0 {
+		n += 1 + sovAmp(uint64(m.ContextID_0))
+	}
+	if m.ContextID_1 != 0 {
+		n += 9
+	}
+	if m.ContextID_2 != 0 {
+		n += 9
+	}
+	if m.FromID != nil {
+		l = m.FromID.Size()
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if m.ToID != nil {
+		l = m.ToID.Size()
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if m.Tags != nil {
+		l = m.Tags.Size()
+		n += 2 + l + sovAmp(uint64(l))
+	}
+	if m.ContextCA != nil {
+		l = m.ContextCA.Size()
+		n += 2 + l + sovAmp(uint64(l))
+	}
+	return n
+}
+
+func (m *Login) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserLabel)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if m.UserUID != nil {
+		l = m.UserUID.Size()
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.DeviceUID)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.DeviceLabel)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.HostAddr)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.Tags)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if m.Checkpoint != nil {
+		l = m.Checkpoint.Size()
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	return n
+}
+
+func (m *LoginChallenge) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	return n
+}
+
+func (m *LoginResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.HashResponse)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	return n
+}
+
+func (m *LoginCheckpoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TokenType)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.AccessToken)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.RefreshToken)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if m.Expiry != 0 {
+		n += 1 + sovAmp(uint64(m.Expiry))
+	}
+	l = len(m.Tags)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.UserUID)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.URI)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	return n
+}
+
+func (m *PinRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PinTarget != nil {
+		l = m.PinTarget.Size()
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if len(m.PinAttrs) > 0 {
+		for _, e := range m.PinAttrs {
+			l = e.Size()
+			n += 1 + l + sovAmp(uint64(l))
+		}
+	}
+	if m.StateSync != 0 {
+		n += 1 + sovAmp(uint64(m.StateSync))
+	}
+	if m.TimeoutMs != 0 {
+		n += 1 + sovAmp(uint64(m.TimeoutMs))
+	}
+	return n
+}
+
+func (m *CancelRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	return n
+}
+
+func (m *LaunchURL) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.URL)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	return n
+}
+
+func (m *RegisterDefs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Attrs) > 0 {
+		for _, e := range m.Attrs {
+			l = e.Size()
+			n += 1 + l + sovAmp(uint64(l))
+		}
+	}
+	if len(m.Apps) > 0 {
+		for _, e := range m.Apps {
+			l = e.Size()
+			n += 1 + l + sovAmp(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AttrSchema) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Spec != nil {
+		l = m.Spec.Size()
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.TypeName)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.GoType)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if len(m.Fields) > 0 {
+		for _, e := range m.Fields {
+			l = e.Size()
+			n += 1 + l + sovAmp(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *FieldSchema) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if m.Number != 0 {
+		n += 1 + sovAmp(uint64(m.Number))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.TypeName)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if m.Repeated {
+		n += 2
+	}
+	return n
+}
+
+func (m *AppSchema) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Spec != nil {
+		l = m.Spec.Size()
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.Desc)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if len(m.Invocations) > 0 {
+		for _, s := range m.Invocations {
+			l = len(s)
+			n += 1 + l + sovAmp(uint64(l))
+		}
+	}
+	if len(m.Dependencies) > 0 {
+		for _, e := range m.Dependencies {
+			l = e.Size()
+			n += 1 + l + sovAmp(uint64(l))
+		}
+	}
+	if len(m.ContentTypes) > 0 {
+		for _, s := range m.ContentTypes {
+			l = len(s)
+			n += 1 + l + sovAmp(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AppReloaded) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Spec != nil {
+		l = m.Spec.Size()
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.PrevVersion)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	return n
+}
+
+func (m *DeltaResync) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Elements) > 0 {
+		n += 1 + sovAmp(uint64(len(m.Elements)*8)) + len(m.Elements)*8
+	}
+	return n
+}
+
+func (m *FlowCredit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Bytes != 0 {
+		n += 1 + sovAmp(uint64(m.Bytes))
+	}
+	return n
+}
+
+func (m *TagUID) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID_0 != 0 {
+		n += 1 + sovAmp(uint64(m.ID_0))
+	}
+	if m.ID_1 != 0 {
+		n += 9
+	}
+	if m.ID_2 != 0 {
+		n += 9
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	return n
+}
+
+func (m *Tag) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TagID_0 != 0 {
+		n += 1 + sovAmp(uint64(m.TagID_0))
+	}
+	if m.TagID_1 != 0 {
+		n += 9
+	}
+	if m.TagID_2 != 0 {
+		n += 9
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.Text)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if m.Ordering != 0 {
+		n += 9
+	}
+	l = len(m.URL)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	if m.Metric != 0 {
+		n += 2 + sovAmp(uint64(m.Metric))
+	}
+	if m.SizeX != 0 {
+		n += 2 + sovAmp(uint64(m.SizeX))
+	}
+	if m.SizeY != 0 {
+		n += 2 + sovAmp(uint64(m.SizeY))
+	}
+	if m.SizeZ != 0 {
+		n += 2 + sovAmp(uint64(m.SizeZ))
+	}
+	if len(m.Tags) > 0 {
+		for _, e := range m.Tags {
+			l = e.Size()
+			n += 2 + l + sovAmp(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CryptoKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CryptoKitID != 0 {
+		n += 1 + sovAmp(uint64(m.CryptoKitID))
+	}
+	l = len(m.KeyBytes)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	return n
+}
+
+func (m *Err) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovAmp(uint64(m.Code))
+	}
+	if m.Level != 0 {
+		n += 1 + sovAmp(uint64(m.Level))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovAmp(uint64(l))
+	}
+	return n
+}
+
+func sovAmp(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAmp(x uint64) (n int) {
+	return sovAmp(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *TxEnvelope) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TxEnvelope{`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`Priority:` + fmt.Sprintf("%v", this.Priority) + `,`,
+		`OpCount:` + fmt.Sprintf("%v", this.OpCount) + `,`,
+		`GenesisID_0:` + fmt.Sprintf("%v", this.GenesisID_0) + `,`,
+		`GenesisID_1:` + fmt.Sprintf("%v", this.GenesisID_1) + `,`,
+		`GenesisID_2:` + fmt.Sprintf("%v", this.GenesisID_2) + `,`,
+		`ContextID_0:` + fmt.Sprintf("%v", this.ContextID_0) + `,`,
+		`ContextID_1:` + fmt.Sprintf("%v", this.ContextID_1) + `,`,
+		`ContextID_2:` + fmt.Sprintf("%v", this.ContextID_2) + `,`,
+		`FromID:` + strings.Replace(this.FromID.String(), "Tag", "Tag", 1) + `,`,
+		`ToID:` + strings.Replace(this.ToID.String(), "Tag", "Tag", 1) + `,`,
+		`Tags:` + strings.Replace(this.Tags.String(), "Tag", "Tag", 1) + `,`,
+		`ContextCA:` + strings.Replace(this.ContextCA.String(), "Tag", "Tag", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Login) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Login{`,
+		`UserLabel:` + fmt.Sprintf("%v", this.UserLabel) + `,`,
+		`UserUID:` + strings.Replace(this.UserUID.String(), "Tag", "Tag", 1) + `,`,
+		`DeviceUID:` + fmt.Sprintf("%v", this.DeviceUID) + `,`,
+		`DeviceLabel:` + fmt.Sprintf("%v", this.DeviceLabel) + `,`,
+		`HostAddr:` + fmt.Sprintf("%v", this.HostAddr) + `,`,
+		`Tags:` + fmt.Sprintf("%v", this.Tags) + `,`,
+		`Checkpoint:` + strings.Replace(this.Checkpoint.String(), "LoginCheckpoint", "LoginCheckpoint", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LoginChallenge) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LoginChallenge{`,
+		`Hash:` + fmt.Sprintf("%v", this.Hash) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LoginResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LoginResponse{`,
+		`HashResponse:` + fmt.Sprintf("%v", this.HashResponse) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LoginCheckpoint) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LoginCheckpoint{`,
+		`TokenType:` + fmt.Sprintf("%v", this.TokenType) + `,`,
+		`AccessToken:` + fmt.Sprintf("%v", this.AccessToken) + `,`,
+		`RefreshToken:` + fmt.Sprintf("%v", this.RefreshToken) + `,`,
+		`Expiry:` + fmt.Sprintf("%v", this.Expiry) + `,`,
+		`Tags:` + fmt.Sprintf("%v", this.Tags) + `,`,
+		`UserUID:` + fmt.Sprintf("%v", this.UserUID) + `,`,
+		`URI:` + fmt.Sprintf("%v", this.URI) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PinRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForPinAttrs := "[]*Tag{"
+	for _, f := range this.PinAttrs {
+		repeatedStringForPinAttrs += strings.Replace(f.String(), "Tag", "Tag", 1) + ","
+	}
+	repeatedStringForPinAttrs += "}"
+	s := strings.Join([]string{`&PinRequest{`,
+		`PinTarget:` + strings.Replace(this.PinTarget.String(), "Tag", "Tag", 1) + `,`,
+		`PinAttrs:` + repeatedStringForPinAttrs + `,`,
+		`StateSync:` + fmt.Sprintf("%v", this.StateSync) + `,`,
+		`TimeoutMs:` + fmt.Sprintf("%v", this.TimeoutMs) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CancelRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CancelRequest{`,
+		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LaunchURL) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LaunchURL{`,
+		`URL:` + fmt.Sprintf("%v", this.URL) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RegisterDefs) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAttrs := "[]*AttrSchema{"
+	for _, f := range this.Attrs {
+		repeatedStringForAttrs += strings.Replace(f.String(), "AttrSchema", "AttrSchema", 1) + ","
+	}
+	repeatedStringForAttrs += "}"
+	repeatedStringForApps := "[]*AppSchema{"
+	for _, f := range this.Apps {
+		repeatedStringForApps += strings.Replace(f.String(), "AppSchema", "AppSchema", 1) + ","
+	}
+	repeatedStringForApps += "}"
+	s := strings.Join([]string{`&RegisterDefs{`,
+		`Attrs:` + repeatedStringForAttrs + `,`,
+		`Apps:` + repeatedStringForApps + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AttrSchema) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForFields := "[]*FieldSchema{"
+	for _, f := range this.Fields {
+		repeatedStringForFields += strings.Replace(f.String(), "FieldSchema", "FieldSchema", 1) + ","
+	}
+	repeatedStringForFields += "}"
+	s := strings.Join([]string{`&AttrSchema{`,
+		`Spec:` + strings.Replace(this.Spec.String(), "Tag", "Tag", 1) + `,`,
+		`TypeName:` + fmt.Sprintf("%v", this.TypeName) + `,`,
+		`GoType:` + fmt.Sprintf("%v", this.GoType) + `,`,
+		`Fields:` + repeatedStringForFields + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FieldSchema) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&FieldSchema{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Number:` + fmt.Sprintf("%v", this.Number) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`TypeName:` + fmt.Sprintf("%v", this.TypeName) + `,`,
+		`Repeated:` + fmt.Sprintf("%v", this.Repeated) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AppSchema) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForDependencies := "[]*Tag{"
+	for _, f := range this.Dependencies {
+		repeatedStringForDependencies += strings.Replace(f.String(), "Tag", "Tag", 1) + ","
+	}
+	repeatedStringForDependencies += "}"
+	s := strings.Join([]string{`&AppSchema{`,
+		`Spec:` + strings.Replace(this.Spec.String(), "Tag", "Tag", 1) + `,`,
+		`Desc:` + fmt.Sprintf("%v", this.Desc) + `,`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`Invocations:` + fmt.Sprintf("%v", this.Invocations) + `,`,
+		`Dependencies:` + repeatedStringForDependencies + `,`,
+		`ContentTypes:` + fmt.Sprintf("%v", this.ContentTypes) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AppReloaded) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AppReloaded{`,
+		`Spec:` + strings.Replace(this.Spec.String(), "Tag", "Tag", 1) + `,`,
+		`PrevVersion:` + fmt.Sprintf("%v", this.PrevVersion) + `,`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeltaResync) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeltaResync{`,
+		`Elements:` + fmt.Sprintf("%v", this.Elements) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FlowCredit) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&FlowCredit{`,
+		`Bytes:` + fmt.Sprintf("%v", this.Bytes) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TagUID) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TagUID{`,
+		`ID_0:` + fmt.Sprintf("%v", this.ID_0) + `,`,
+		`ID_1:` + fmt.Sprintf("%v", this.ID_1) + `,`,
+		`ID_2:` + fmt.Sprintf("%v", this.ID_2) + `,`,
+		`UID:` + fmt.Sprintf("%v", this.UID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Tag) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForTags := "[]*Tag{"
+	for _, f := range this.Tags {
+		repeatedStringForTags += strings.Replace(f.String(), "Tag", "Tag", 1) + ","
+	}
+	repeatedStringForTags += "}"
+	s := strings.Join([]string{`&Tag{`,
+		`TagID_0:` + fmt.Sprintf("%v", this.TagID_0) + `,`,
+		`TagID_1:` + fmt.Sprintf("%v", this.TagID_1) + `,`,
+		`TagID_2:` + fmt.Sprintf("%v", this.TagID_2) + `,`,
+		`UID:` + fmt.Sprintf("%v", this.UID) + `,`,
+		`Text:` + fmt.Sprintf("%v", this.Text) + `,`,
+		`Ordering:` + fmt.Sprintf("%v", this.Ordering) + `,`,
+		`URL:` + fmt.Sprintf("%v", this.URL) + `,`,
+		`ContentType:` + fmt.Sprintf("%v", this.ContentType) + `,`,
+		`Metric:` + fmt.Sprintf("%v", this.Metric) + `,`,
+		`SizeX:` + fmt.Sprintf("%v", this.SizeX) + `,`,
+		`SizeY:` + fmt.Sprintf("%v", this.SizeY) + `,`,
+		`SizeZ:` + fmt.Sprintf("%v", this.SizeZ) + `,`,
+		`Tags:` + repeatedStringForTags + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CryptoKey) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CryptoKey{`,
+		`CryptoKitID:` + fmt.Sprintf("%v", this.CryptoKitID) + `,`,
+		`KeyBytes:` + fmt.Sprintf("%v", this.KeyBytes) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Err) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Err{`,
+		`Code:` + fmt.Sprintf("%v", this.Code) + `,`,
+		`Level:` + fmt.Sprintf("%v", this.Level) + `,`,
+		`Msg:` + fmt.Sprintf("%v", this.Msg) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringAmp(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAmp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxEnvelope: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TxEnvelope: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= OpStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= TxPriority(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OpCount", wireType)
+			}
+			m.OpCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OpCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GenesisID_0", wireType)
+			}
+			m.GenesisID_0 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GenesisID_0 |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GenesisID_1", wireType)
+			}
+			m.GenesisID_1 = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GenesisID_1 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GenesisID_2", wireType)
+			}
+			m.GenesisID_2 = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GenesisID_2 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContextID_0", wireType)
+			}
+			m.ContextID_0 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContextID_0 |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContextID_1", wireType)
+			}
+			m.ContextID_1 = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContextID_1 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 12:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContextID_2", wireType)
+			}
+			m.ContextID_2 = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContextID_2 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FromID == nil {
+				m.FromID = &Tag{}
+			}
+			if err := m.FromID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ToID == nil {
+				m.ToID = &Tag{}
+			}
+			if err := m.ToID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tags == nil {
+				m.Tags = &Tag{}
+			}
+			if err := m.Tags.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContextCA", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ContextCA == nil {
+				m.ContextCA = &Tag{}
+			}
+			if err := m.ContextCA.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAmp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Login) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAmp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Login: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Login: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserLabel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserLabel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserUID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UserUID == nil {
+				m.UserUID = &Tag{}
+			}
+			if err := m.UserUID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceUID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceUID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceLabel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceLabel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HostAddr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HostAddr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checkpoint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Checkpoint == nil {
+				m.Checkpoint = &LoginCheckpoint{}
+			}
+			if err := m.Checkpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAmp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	s := strings.Join([]string{`&CryptoKey{`,
-		`CryptoKitID:` + fmt.Sprintf("%v", this.CryptoKitID) + `,`,
-		`KeyBytes:` + fmt.Sprintf("%v", this.KeyBytes) + `,`,
-		`}`,
-	}, "")
-	return s
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (this *Err) String() string {
-	if this == nil {
-		return "nil"
+func (m *LoginChallenge) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAmp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LoginChallenge: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LoginChallenge: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAmp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	s := strings.Join([]string{`&Err{`,
-		`Code:` + fmt.Sprintf("%v", this.Code) + `,`,
-		`Level:` + fmt.Sprintf("%v", this.Level) + `,`,
-		`Msg:` + fmt.Sprintf("%v", this.Msg) + `,`,
-		`}`,
-	}, "")
-	return s
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func valueToStringAmp(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+func (m *LoginResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAmp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LoginResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LoginResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashResponse", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HashResponse = append(m.HashResponse[:0], dAtA[iNdEx:postIndex]...)
+			if m.HashResponse == nil {
+				m.HashResponse = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAmp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
+func (m *LoginCheckpoint) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3376,17 +5812,81 @@ func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TxEnvelope: wiretype end group for non-group")
+			return fmt.Errorf("proto: LoginCheckpoint: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TxEnvelope: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LoginCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
 			}
-			m.Status = 0
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccessToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefreshToken", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -3396,16 +5896,29 @@ func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= OpStatus(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RefreshToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OpCount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Expiry", wireType)
 			}
-			m.OpCount = 0
+			m.Expiry = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -3415,16 +5928,16 @@ func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.OpCount |= uint64(b&0x7F) << shift
+				m.Expiry |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GenesisID_0", wireType)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
 			}
-			m.GenesisID_0 = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -3434,36 +5947,61 @@ func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GenesisID_0 |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GenesisID_1", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
 			}
-			m.GenesisID_1 = 0
-			if (iNdEx + 8) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GenesisID_1 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		case 7:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GenesisID_2", wireType)
+			m.Tags = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserUID", wireType)
 			}
-			m.GenesisID_2 = 0
-			if (iNdEx + 8) > l {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GenesisID_2 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContextID_0", wireType)
+			m.UserUID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field URI", wireType)
 			}
-			m.ContextID_0 = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -3473,34 +6011,77 @@ func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ContextID_0 |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 11:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContextID_1", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
 			}
-			m.ContextID_1 = 0
-			if (iNdEx + 8) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContextID_1 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		case 12:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContextID_2", wireType)
+			m.URI = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAmp(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.ContextID_2 = 0
-			if (iNdEx + 8) > l {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContextID_2 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		case 14:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PinRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAmp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PinRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FromID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PinTarget", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3527,16 +6108,16 @@ func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.FromID == nil {
-				m.FromID = &Tag{}
+			if m.PinTarget == nil {
+				m.PinTarget = &Tag{}
 			}
-			if err := m.FromID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.PinTarget.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 15:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ToID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PinAttrs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3563,18 +6144,16 @@ func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ToID == nil {
-				m.ToID = &Tag{}
-			}
-			if err := m.ToID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.PinAttrs = append(m.PinAttrs, &Tag{})
+			if err := m.PinAttrs[len(m.PinAttrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 16:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateSync", wireType)
 			}
-			var msglen int
+			m.StateSync = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -3584,33 +6163,85 @@ func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.StateSync |= StateSync(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthAmp
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutMs", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			m.TimeoutMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeoutMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAmp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAmp
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CancelRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAmp
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Tags == nil {
-				m.Tags = &Tag{}
-			}
-			if err := m.Tags.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 17:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CancelRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CancelRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContextCA", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -3620,27 +6251,23 @@ func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAmp
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAmp
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ContextCA == nil {
-				m.ContextCA = &Tag{}
-			}
-			if err := m.ContextCA.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3663,7 +6290,7 @@ func (m *TxEnvelope) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Login) Unmarshal(dAtA []byte) error {
+func (m *LaunchURL) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3686,15 +6313,15 @@ func (m *Login) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Login: wiretype end group for non-group")
+			return fmt.Errorf("proto: LaunchURL: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Login: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LaunchURL: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserLabel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field URL", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3722,11 +6349,61 @@ func (m *Login) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserLabel = string(dAtA[iNdEx:postIndex])
+			m.URL = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAmp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegisterDefs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAmp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegisterDefs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegisterDefs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserUID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Attrs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3753,18 +6430,16 @@ func (m *Login) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.UserUID == nil {
-				m.UserUID = &Tag{}
-			}
-			if err := m.UserUID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Attrs = append(m.Attrs, &AttrSchema{})
+			if err := m.Attrs[len(m.Attrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeviceUID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Apps", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -3774,29 +6449,81 @@ func (m *Login) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAmp
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAmp
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DeviceUID = string(dAtA[iNdEx:postIndex])
+			m.Apps = append(m.Apps, &AppSchema{})
+			if err := m.Apps[len(m.Apps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 6:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAmp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AttrSchema) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAmp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AttrSchema: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AttrSchema: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeviceLabel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Spec", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -3806,27 +6533,31 @@ func (m *Login) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAmp
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAmp
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DeviceLabel = string(dAtA[iNdEx:postIndex])
+			if m.Spec == nil {
+				m.Spec = &Tag{}
+			}
+			if err := m.Spec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HostAddr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TypeName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3854,11 +6585,11 @@ func (m *Login) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.HostAddr = string(dAtA[iNdEx:postIndex])
+			m.TypeName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 9:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GoType", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3886,11 +6617,11 @@ func (m *Login) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tags = string(dAtA[iNdEx:postIndex])
+			m.GoType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 12:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Checkpoint", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3917,10 +6648,8 @@ func (m *Login) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Checkpoint == nil {
-				m.Checkpoint = &LoginCheckpoint{}
-			}
-			if err := m.Checkpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Fields = append(m.Fields, &FieldSchema{})
+			if err := m.Fields[len(m.Fields)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3945,7 +6674,7 @@ func (m *Login) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LoginChallenge) Unmarshal(dAtA []byte) error {
+func (m *FieldSchema) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3968,17 +6697,17 @@ func (m *LoginChallenge) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LoginChallenge: wiretype end group for non-group")
+			return fmt.Errorf("proto: FieldSchema: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LoginChallenge: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FieldSchema: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -3988,81 +6717,80 @@ func (m *LoginChallenge) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAmp
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAmp
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
-			if m.Hash == nil {
-				m.Hash = []byte{}
-			}
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAmp(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Number", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAmp
+			m.Number = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Number |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LoginResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAmp
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LoginResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LoginResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HashResponse", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TypeName", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -4072,26 +6800,44 @@ func (m *LoginResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAmp
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAmp
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.HashResponse = append(m.HashResponse[:0], dAtA[iNdEx:postIndex]...)
-			if m.HashResponse == nil {
-				m.HashResponse = []byte{}
-			}
+			m.TypeName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Repeated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Repeated = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAmp(dAtA[iNdEx:])
@@ -4113,7 +6859,7 @@ func (m *LoginResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LoginCheckpoint) Unmarshal(dAtA []byte) error {
+func (m *AppSchema) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4136,17 +6882,17 @@ func (m *LoginCheckpoint) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LoginCheckpoint: wiretype end group for non-group")
+			return fmt.Errorf("proto: AppSchema: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LoginCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AppSchema: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TokenType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Spec", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -4156,27 +6902,31 @@ func (m *LoginCheckpoint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAmp
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAmp
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TokenType = string(dAtA[iNdEx:postIndex])
+			if m.Spec == nil {
+				m.Spec = &Tag{}
+			}
+			if err := m.Spec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccessToken", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Desc", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4204,11 +6954,11 @@ func (m *LoginCheckpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AccessToken = string(dAtA[iNdEx:postIndex])
+			m.Desc = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RefreshToken", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4236,30 +6986,11 @@ func (m *LoginCheckpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RefreshToken = string(dAtA[iNdEx:postIndex])
+			m.Version = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Expiry", wireType)
-			}
-			m.Expiry = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAmp
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Expiry |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Invocations", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4287,13 +7018,13 @@ func (m *LoginCheckpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tags = string(dAtA[iNdEx:postIndex])
+			m.Invocations = append(m.Invocations, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 11:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserUID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Dependencies", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -4303,27 +7034,29 @@ func (m *LoginCheckpoint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAmp
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAmp
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserUID = string(dAtA[iNdEx:postIndex])
+			m.Dependencies = append(m.Dependencies, &Tag{})
+			if err := m.Dependencies[len(m.Dependencies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 12:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field URI", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentTypes", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4351,7 +7084,7 @@ func (m *LoginCheckpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.URI = string(dAtA[iNdEx:postIndex])
+			m.ContentTypes = append(m.ContentTypes, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4374,7 +7107,7 @@ func (m *LoginCheckpoint) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PinRequest) Unmarshal(dAtA []byte) error {
+func (m *AppReloaded) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4397,15 +7130,15 @@ func (m *PinRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PinRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AppReloaded: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AppReloaded: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PinTarget", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Spec", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4432,18 +7165,18 @@ func (m *PinRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.PinTarget == nil {
-				m.PinTarget = &Tag{}
+			if m.Spec == nil {
+				m.Spec = &Tag{}
 			}
-			if err := m.PinTarget.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Spec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PinAttrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevVersion", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -4453,31 +7186,29 @@ func (m *PinRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAmp
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAmp
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PinAttrs = append(m.PinAttrs, &Tag{})
-			if err := m.PinAttrs[len(m.PinAttrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.PrevVersion = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StateSync", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			m.StateSync = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -4487,11 +7218,24 @@ func (m *PinRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StateSync |= StateSync(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAmp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAmp(dAtA[iNdEx:])
@@ -4513,7 +7257,7 @@ func (m *PinRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LaunchURL) Unmarshal(dAtA []byte) error {
+func (m *DeltaResync) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4536,17 +7280,119 @@ func (m *LaunchURL) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LaunchURL: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeltaResync: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LaunchURL: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeltaResync: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field URL", wireType)
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				m.Elements = append(m.Elements, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAmp
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthAmp
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthAmp
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 8
+				if elementCount != 0 && len(m.Elements) == 0 {
+					m.Elements = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					m.Elements = append(m.Elements, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Elements", wireType)
 			}
-			var stringLen uint64
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAmp(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAmp
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FlowCredit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAmp
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FlowCredit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FlowCredit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAmp
@@ -4556,24 +7402,11 @@ func (m *LaunchURL) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Bytes |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAmp
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAmp
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.URL = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAmp(dAtA[iNdEx:])
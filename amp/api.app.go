@@ -1,6 +1,8 @@
 package amp
 
 import (
+	"reflect"
+
 	"github.com/art-media-platform/amp-sdk-go/stdlib/media"
 	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
 	"github.com/art-media-platform/amp-sdk-go/stdlib/task"
@@ -21,6 +23,7 @@ type App struct {
 	Version      string   // "v{MajorVers}.{MinorID}.{RevID}"
 	Dependencies []tag.ID // Module Tags this app may access
 	Invocations  []string // Additional aliases that invoke this app
+	ContentTypes []string // Media types this app handles, where "image/*" matches any image type -- e.g. "application/x-directory"
 
 	// NewAppInstance is the entry point for an App.
 	// Called when an App is first invoked on an active User session and is not yet running.
@@ -45,6 +48,10 @@ type AppContext interface {
 
 	// Write analog for GetAppAttr()
 	PutAppAttr(attrSpec tag.ID, src tag.Value) error
+
+	// Returns the event bus shared by all apps of this host, allowing apps to notify each other.
+	// Subscriptions owned by this app (or its children) are removed when it closes -- see task.NewTopic().
+	Bus() *task.Bus
 }
 
 // Pinner is characterized by the ability to emit Pins.
@@ -111,3 +118,23 @@ type AttrDef struct {
 	tag.Spec
 	Prototype tag.Value
 }
+
+// CellSchema maps the exported fields of a Go struct to attributes of a cell -- see MakeSchemaForType()
+type CellSchema struct {
+	Type   reflect.Type // struct type described by this schema
+	Fields []CellField  // fields mapped to an attribute, in field order
+}
+
+// CellField maps a struct field to the attribute that stores it.
+type CellField struct {
+	AttrDef        // attr spec and element prototype for this field
+	FieldIndex int // index of the mapped field within CellSchema.Type
+}
+
+// ElementLoader loads an attribute element value, implemented by TxMsg and cell stores.
+type ElementLoader interface {
+
+	// Loads the latest revision of the given element into dst.
+	// Returns ErrPropertyNotFound if the element is not present.
+	Load(cellID, attrID, itemID tag.ID, dst tag.Value) error
+}
@@ -2,6 +2,7 @@ package amp
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/art-media-platform/amp-sdk-go/stdlib/media"
 	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
@@ -52,7 +53,7 @@ type HostService interface {
 	//    meaning that its service has effectively stopped but its Context is still open.
 	// Note this could any amount of time (e.g. until all open requests are closed)
 	// Typically, GracefulStop() is called (blocking) and then Context.Close().
-	// To stop immediately, Context.Close() is always available.
+	// To stop immediately, Context.Close() is always available -- see also task.Shutdown(), which drains a Context tree with a timeout.
 	GracefulStop()
 }
 
@@ -73,16 +74,49 @@ type Session interface {
 	SendTx(tx *TxMsg) error
 
 	// Gets the currently running AppInstance for an AppID.
-	// If the requested app is not running and autoCreate is set, a new instance is created and started.
+	// If the requested app is not running and autoCreate is set, a new instance is created and started -- otherwise nil, nil is returned.
 	GetAppInstance(appID tag.ID, autoCreate bool) (AppInstance, error)
 }
 
+// ConflictPolicy specifies how a Registry resolves an app registration that collides with one already registered.
+type ConflictPolicy int32
+
+const (
+	// ConflictPolicy_Reject fails the registration with ErrCode_AppConflict (default).
+	ConflictPolicy_Reject ConflictPolicy = iota
+
+	// ConflictPolicy_KeepFirst keeps what is already registered, dropping the colliding app version or alias.
+	ConflictPolicy_KeepFirst
+
+	// ConflictPolicy_Replace overwrites what is already registered with the newly registered app.
+	ConflictPolicy_Replace
+)
+
+// RegistryOpts specifies the behavior of a Registry created via CreateRegistry().
+type RegistryOpts struct {
+	// Conflicts specifies what happens when an app is registered having the same spec and version as a registered app,
+	// or an invocation alias explicitly claimed by a different app.
+	//
+	// Note that an app's implicit leaf-name alias (e.g. "posix" for "amp.app.filesys.posix") never conflicts:
+	// it is claimed only if it is unclaimed, and an explicit alias always overrides it.
+	Conflicts ConflictPolicy
+
+	// If set, the created registry is a layer over Parent: lookups that are not satisfied locally fall back to Parent,
+	// and apps, aliases, and attr defs registered locally override those of Parent without affecting it.
+	// This is how a Session's registry shares its Host's registry while allowing session-local overrides.
+	// Conflicts are only detected within the local layer and UnregisterApp() and ReplaceApp() only affect the local layer,
+	// where unregistering an app registered in Parent masks it from this layer until it is registered here again.
+	Parent Registry
+}
+
 // Registry is where apps and types are registered -- concurrency safe.
 type Registry interface {
+	RegistryEnumerator
 
-	// Imports all the types and apps from another registry.
-	// When a Session is created, its registry starts by importing the Host's registry.
-	Import(other Registry) error
+	// Copies all the attr defs and apps enumerated by another registry into this registry.
+	// Apps that can't be registered (e.g. due to a conflict) are skipped and the first such error is returned.
+	// To share a registry without copying (e.g. a Session's registry falling back to its Host's registry), see RegistryOpts.Parent.
+	Import(other RegistryEnumerator) error
 
 	// Registers an element value type (tag.Value) as a prototype under its pure scalar element type name (also a valid tag.Spec type expression).
 	// If an entry already exists (common for a type used by multiple apps), then this is a no-op.
@@ -90,16 +124,58 @@ type Registry interface {
 	RegisterPrototype(context tag.Spec, prototype tag.Value, registerAs string) tag.Spec
 
 	// Registers an app by its UTag, URI, and schemas it supports.
+	// App.Version must be a valid semantic version (or empty) and multiple versions of the same app spec may coexist.
+	// Collisions are resolved per RegistryOpts.Conflicts, where ErrCode_AppConflict is returned if rejected.
 	RegisterApp(app *App) error
 
-	// Looks-up an app by tag ID -- READ ONLY ACCESS
+	// Removes every registered version of an app and releases its invocation aliases.
+	// Returns ErrCode_AppNotFound if the app is not registered (or inherited -- see RegistryOpts.Parent).
+	// Running instances are not affected -- see UnloadApp()
+	UnregisterApp(appTag tag.ID) error
+
+	// Atomically replaces every registered version of app.AppSpec with the given app, returning the latest replaced version (or nil if none),
+	// where the replaced version may be inherited from RegistryOpts.Parent.
+	// If the given app can't be registered, the previous registration is left intact.
+	// Running instances are not affected -- see ReloadApp()
+	ReplaceApp(app *App) (prev *App, err error)
+
+	// Looks-up the latest version of an app by tag ID -- READ ONLY ACCESS
 	GetAppByTag(appTag tag.ID) (*App, error)
 
-	// Selects the app that best matches an invocation string.
+	// Selects the app that best matches an invocation string -- see GetAppsForInvocation()
+	// If more than one app is the best match, ErrCode_AppConflict is returned.
 	GetAppForInvocation(invocation string) (*App, error)
 
+	// Returns the apps matching an invocation string, ranked best match first, where an invocation is one of:
+	//   - an app alias: an app's canonic spec, one of its Invocations, or its leaf name -- e.g. "posix"
+	//   - an amp URL: "amp:[//{app-alias}/]{cmd}[/{uri}][?{query}]", routed by longest matching app alias
+	//   - a media type or data URL, matching apps by App.ContentTypes -- e.g. "image/png" or "data:image/png;base64,..."
+	// An alias of the form "{alias}@{version}" selects the latest app version matching the given version prefix -- e.g. "posix@v1.2"
+	GetAppsForInvocation(invocation string) ([]AppMatch, error)
+
 	// Instantiates an attr element value for a given attr spec -- typically followed by tag.Value.Unmarshal()
 	MakeValue(attrSpec tag.ID) (tag.Value, error)
+
+	// Registers attr specs received from a peer (typically via a RegisterDefs meta attr).
+	// Each spec is mapped to an already registered prototype having the same element type name.
+	RegisterDefs(defs *RegisterDefs) error
+}
+
+// RegistryEnumerator enumerates the apps and attr defs known to a registry, allowing any Registry implementation to be imported or layered.
+type RegistryEnumerator interface {
+
+	// Returns a snapshot of all registered apps (including every registered version) -- READ ONLY ACCESS
+	Apps() []*App
+
+	// Returns a snapshot of all registered attr defs -- READ ONLY ACCESS
+	AttrDefs() []AttrDef
+}
+
+// AppMatch is a candidate app for an invocation -- see Registry.GetAppsForInvocation()
+type AppMatch struct {
+	App    *App
+	Score  int    // higher is a better match
+	Remain string // the remainder of the invocation following the matched alias -- e.g. "{cmd}/{uri}?{query}"
 }
 
 // Requester wraps a client request to receive a cell's state / updates.
@@ -123,4 +199,6 @@ type Request struct {
 	CommitTx   *TxMsg     // if non-nil, this tx is committed to be merged
 	URL        *url.URL   // Initialized from PinRequest.PinTarget.URL (or nil if missing)
 	Values     url.Values // Initialized from PinRequest.PinTarget.URL (or nil if missing)
+	Target     *URL       // Typed parse of PinRequest.PinTarget.URL (or nil if missing) -- see RouteRequest()
+	Deadline   time.Time  // If non-zero, when the host closes this request -- see InitDeadline()
 }
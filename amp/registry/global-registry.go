@@ -2,6 +2,7 @@ package registry
 
 import (
 	"github.com/art-media-platform/amp-sdk-go/amp"
+	"github.com/art-media-platform/amp-sdk-go/amp/std"
 )
 
 func Global() amp.Registry {
@@ -9,6 +10,7 @@ func Global() amp.Registry {
 		gRegistry = amp.NewRegistry()
 	}
 	amp.RegisterBuiltinTypes(gRegistry)
+	std.RegisterStdTypes(gRegistry)
 	return gRegistry
 }
 
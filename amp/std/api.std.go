@@ -47,6 +47,9 @@ type CellWriter interface {
 
 	PutText(propertyID tag.ID, val string)
 	PutItem(propertyID tag.ID, val tag.Value)
+
+	// Upserts each mapped field of src as an attribute of the current cell -- see amp.WriteCell()
+	PutFields(schema *amp.CellSchema, src any)
 }
 
 const (
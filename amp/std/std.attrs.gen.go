@@ -0,0 +1,91 @@
+// Code generated by amp-gen-attrs. DO NOT EDIT.
+// source: std.proto
+
+package std
+
+import (
+	"github.com/art-media-platform/amp-sdk-go/amp"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+// RegisterStdTypes registers each message type in std.proto as a prototype under amp.AttrSpec.
+func RegisterStdTypes(reg amp.Registry) {
+	reg.RegisterPrototype(amp.AttrSpec, &Position{}, "Position")
+	reg.RegisterPrototype(amp.AttrSpec, &FSInfo{}, "FSInfo")
+	reg.RegisterPrototype(amp.AttrSpec, &Placement{}, "Placement")
+	reg.RegisterPrototype(amp.AttrSpec, &BadgeDigit{}, "BadgeDigit")
+	reg.RegisterPrototype(amp.AttrSpec, &TRS{}, "TRS")
+	reg.RegisterPrototype(amp.AttrSpec, &DataSegment{}, "DataSegment")
+}
+
+func (v *Position) MarshalToStore(in []byte) (out []byte, err error) {
+	return amp.MarshalPbToStore(v, in)
+}
+
+func (v *Position) TagSpec() tag.Spec {
+	return amp.AttrSpec.With("Position")
+}
+
+func (v *Position) New() tag.Value {
+	return &Position{}
+}
+
+func (v *FSInfo) MarshalToStore(in []byte) (out []byte, err error) {
+	return amp.MarshalPbToStore(v, in)
+}
+
+func (v *FSInfo) TagSpec() tag.Spec {
+	return amp.AttrSpec.With("FSInfo")
+}
+
+func (v *FSInfo) New() tag.Value {
+	return &FSInfo{}
+}
+
+func (v *Placement) MarshalToStore(in []byte) (out []byte, err error) {
+	return amp.MarshalPbToStore(v, in)
+}
+
+func (v *Placement) TagSpec() tag.Spec {
+	return amp.AttrSpec.With("Placement")
+}
+
+func (v *Placement) New() tag.Value {
+	return &Placement{}
+}
+
+func (v *BadgeDigit) MarshalToStore(in []byte) (out []byte, err error) {
+	return amp.MarshalPbToStore(v, in)
+}
+
+func (v *BadgeDigit) TagSpec() tag.Spec {
+	return amp.AttrSpec.With("BadgeDigit")
+}
+
+func (v *BadgeDigit) New() tag.Value {
+	return &BadgeDigit{}
+}
+
+func (v *TRS) MarshalToStore(in []byte) (out []byte, err error) {
+	return amp.MarshalPbToStore(v, in)
+}
+
+func (v *TRS) TagSpec() tag.Spec {
+	return amp.AttrSpec.With("TRS")
+}
+
+func (v *TRS) New() tag.Value {
+	return &TRS{}
+}
+
+func (v *DataSegment) MarshalToStore(in []byte) (out []byte, err error) {
+	return amp.MarshalPbToStore(v, in)
+}
+
+func (v *DataSegment) TagSpec() tag.Spec {
+	return amp.AttrSpec.With("DataSegment")
+}
+
+func (v *DataSegment) New() tag.Value {
+	return &DataSegment{}
+}
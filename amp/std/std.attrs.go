@@ -1,5 +1,7 @@
 package std
 
+//go:generate go run github.com/art-media-platform/amp-sdk-go/cmd/amp-gen-attrs -proto std.proto -spec amp.AttrSpec
+
 import (
 	"time"
 
@@ -55,30 +57,6 @@ type PinnableAttr struct {
 	Spec tag.Spec
 }
 
-func (v *Position) MarshalToStore(in []byte) (out []byte, err error) {
-	return amp.MarshalPbToStore(v, in)
-}
-
-func (v *Position) TagSpec() tag.Spec {
-	return amp.AttrSpec.With("Position")
-}
-
-func (v *Position) New() tag.Value {
-	return &Position{}
-}
-
-func (v *FSInfo) MarshalToStore(in []byte) (out []byte, err error) {
-	return amp.MarshalPbToStore(v, in)
-}
-
-func (v *FSInfo) TagSpec() tag.Spec {
-	return amp.AttrSpec.With("FSInfo")
-}
-
-func (v *FSInfo) New() tag.Value {
-	return &FSInfo{}
-}
-
 func (v *FSInfo) SetModifiedAt(t time.Time) {
 	tag := tag.FromTime(t, false)
 	v.ModifiedAt = int64(tag[0])
@@ -37,6 +37,7 @@ func PinAndServe[AppT amp.AppInstance](cell Cell[AppT], app AppT, op amp.Request
 		Info: task.Info{
 			Label:     label,
 			IdleClose: time.Microsecond,
+			Deadline:  op.Request().Deadline,
 		},
 		OnRun: func(pinContext task.Context) {
 			err := pin.App.MakeReady(op)
@@ -53,6 +54,9 @@ func PinAndServe[AppT amp.AppInstance](cell Cell[AppT], app AppT, op amp.Request
 			} else if op.Request().StateSync == amp.StateSync_Maintain {
 				<-pinContext.Closing()
 			}
+			if deadline, ok := pinContext.Deadline(); ok && err == nil && !pinContext.Info().Clock.Now().Before(deadline) {
+				err = amp.ErrTimeout
+			}
 			op.OnComplete(err)
 		},
 		OnClosing: func() {
@@ -144,6 +148,7 @@ func (pin *Pin[AppT]) pushState() error {
 	}
 
 	tx.Status = amp.OpStatus_Synced
+	amp.RecordTxSent(pin.ctx, tx)
 	return pin.Op.PushTx(tx)
 }
 
@@ -184,6 +189,15 @@ func (w *cellWriter) PutItem(propertyID tag.ID, value tag.Value) {
 	}
 }
 
+func (w *cellWriter) PutFields(schema *amp.CellSchema, src any) {
+	if w.err != nil {
+		return
+	}
+	if err := amp.WriteCell(w.tx, w.cellID, schema, src); err != nil {
+		w.err = err
+	}
+}
+
 func (w *cellWriter) Upsert(op *amp.TxOp, val tag.Value) {
 	if w.err != nil {
 		return
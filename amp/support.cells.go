@@ -0,0 +1,125 @@
+package amp
+
+import (
+	"reflect"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+var gValueType = reflect.TypeOf((*tag.Value)(nil)).Elem()
+
+// MakeSchemaForType uses reflection to map the exported fields of a struct type to attribute specs, registering each field's type as a prototype.
+//
+// Each mapped field must be a pointer to a tag.Value type (e.g. *amp.Tag) and its attr spec is formed from context and the field's "amp" struct tag:
+//
+//	type Profile struct {
+//	    Name   *amp.Tag      `amp:"name.Tag"` // attr spec is context.With("name.Tag")
+//	    Where  *std.Position                  // no struct tag: spec is context.With("Where.Position")
+//	    Cached *amp.Tag      `amp:"-"`        // not mapped
+//	}
+//
+// Fields that are unexported or that are not a tag.Value (and have no "amp" struct tag) are skipped.
+func MakeSchemaForType(reg Registry, context tag.Spec, valType reflect.Type) (*CellSchema, error) {
+	if valType.Kind() == reflect.Pointer {
+		valType = valType.Elem()
+	}
+	if valType.Kind() != reflect.Struct {
+		return nil, ErrCode_BadSchema.Errorf("MakeSchemaForType: expected struct, got %v", valType.Kind())
+	}
+
+	numFields := valType.NumField()
+	schema := &CellSchema{
+		Type:   valType,
+		Fields: make([]CellField, 0, numFields),
+	}
+
+	for i := 0; i < numFields; i++ {
+		field := valType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		subTags, tagged := field.Tag.Lookup("amp")
+		if subTags == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() != reflect.Pointer || !fieldType.Implements(gValueType) {
+			if tagged {
+				return nil, ErrCode_BadSchema.Errorf("MakeSchemaForType: field %s.%s (%v) is not a tag.Value", valType.Name(), field.Name, fieldType)
+			}
+			continue
+		}
+
+		if subTags == "" {
+			subTags = field.Name + "." + fieldType.Elem().Name()
+		}
+
+		prototype := reflect.New(fieldType.Elem()).Interface().(tag.Value)
+		attrSpec := reg.RegisterPrototype(context, prototype, subTags)
+		schema.Fields = append(schema.Fields, CellField{
+			AttrDef: AttrDef{
+				Spec:      attrSpec,
+				Prototype: prototype,
+			},
+			FieldIndex: i,
+		})
+	}
+	return schema, nil
+}
+
+// WriteCell marshals each non-nil mapped field of srcStruct into tx as an upsert to the given cell.
+func WriteCell(tx *TxMsg, cellID tag.ID, schema *CellSchema, srcStruct any) error {
+	src, err := schema.structValue(srcStruct)
+	if err != nil {
+		return err
+	}
+
+	for _, fi := range schema.Fields {
+		field := src.Field(fi.FieldIndex)
+		if field.IsNil() {
+			continue
+		}
+		if err := tx.Upsert(cellID, fi.ID, tag.ID{}, field.Interface().(tag.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadCell is the read analog of WriteCell, loading each mapped field of dstStruct from the given cell.
+// Fields whose attribute is not present are left unchanged.
+func ReadCell(src ElementLoader, cellID tag.ID, schema *CellSchema, dstStruct any) error {
+	dst, err := schema.structValue(dstStruct)
+	if err != nil {
+		return err
+	}
+	if !dst.CanSet() {
+		return ErrCode_BadSchema.Error("ReadCell: expected pointer to struct")
+	}
+
+	for _, fi := range schema.Fields {
+		val := fi.Prototype.New()
+		err := src.Load(cellID, fi.ID, tag.ID{}, val)
+		if err == ErrPropertyNotFound || err == ErrAttrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		dst.Field(fi.FieldIndex).Set(reflect.ValueOf(val))
+	}
+	return nil
+}
+
+func (schema *CellSchema) structValue(structPtr any) (reflect.Value, error) {
+	val := reflect.Indirect(reflect.ValueOf(structPtr))
+	if !val.IsValid() {
+		return reflect.Value{}, ErrCode_BadSchema.Errorf("expected %v, got nil", schema.Type)
+	}
+	if val.Type() != schema.Type {
+		return reflect.Value{}, ErrCode_BadSchema.Errorf("expected %v, got %v", schema.Type, val.Type())
+	}
+	return val, nil
+}
@@ -0,0 +1,316 @@
+package amp
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+// A TxOpCode_UpsertDelta op's data is:
+//
+//	{base EditID: 3 x uint64 LE}{target len: uvarint}[{copy len: uvarint}{literal len: uvarint}{literal bytes}]*
+//
+// where each run copies bytes from the base value at the current position and then emits literal bytes in place of the base's,
+// so the base and target positions advance together.  This suits values that change in place, such as positions or progress.
+const (
+	deltaBaseSize = 3 * 8 // size of the base EditID leading a delta
+	deltaMinCopy  = 4     // shorter matching spans are emitted as literals since a run costs at least 2 bytes
+)
+
+var (
+	ErrUnresolvedDelta = ErrCode_MalformedTx.Error("unresolved value delta")
+)
+
+// ValueCache holds the latest serialized value of elements sent or received, allowing values to be sent as deltas.
+//
+// A sender keeps a ValueCache per peer and uses TxMsg.UpsertDelta() to send values that differ slightly from their previous revision.
+// The receiver keeps a ValueCache per peer and calls Resolve() on each incoming TxMsg, which reconstructs delta ops into full upserts.
+// If a delta's base revision is not in the receiver's cache, the receiver sends the sender a DeltaResync (see NewDeltaResync)
+// and the sender passes it to Resync(), causing the next upsert of each listed element to be sent in full.
+//
+// A ValueCache is safe for concurrent use.
+type ValueCache struct {
+	mu    sync.Mutex
+	elems map[ElementID]cachedValue
+}
+
+type cachedValue struct {
+	editID tag.ID
+	data   []byte
+}
+
+// NewValueCache returns an empty ValueCache.
+func NewValueCache() *ValueCache {
+	return &ValueCache{
+		elems: make(map[ElementID]cachedValue),
+	}
+}
+
+// Len returns the number of elements in this cache.
+func (cache *ValueCache) Len() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return len(cache.elems)
+}
+
+// Forget removes the given element so that its next upsert is sent in full.
+func (cache *ValueCache) Forget(elemID ElementID) {
+	cache.mu.Lock()
+	delete(cache.elems, elemID)
+	cache.mu.Unlock()
+}
+
+// Resync forgets the elements listed in a DeltaResync received from a peer and returns them so that the caller may resend their values.
+func (cache *ValueCache) Resync(msg *DeltaResync) []ElementID {
+	elemIDs := msg.ElementIDs()
+	cache.mu.Lock()
+	for _, elemID := range elemIDs {
+		delete(cache.elems, elemID)
+	}
+	cache.mu.Unlock()
+	return elemIDs
+}
+
+// put stores a copy of the given value as the latest revision of an element.
+func (cache *ValueCache) put(elemID ElementID, editID tag.ID, data []byte) {
+	if cache.elems == nil {
+		cache.elems = make(map[ElementID]cachedValue)
+	}
+	entry := cache.elems[elemID]
+	entry.editID = editID
+	entry.data = append(entry.data[:0], data...)
+	cache.elems[elemID] = entry
+}
+
+// Resolve reconstructs each TxOpCode_UpsertDelta op of tx into a TxOpCode_UpsertElement op and updates this cache with each upsert and delete.
+//
+// A delta op whose base revision is not in this cache is removed from tx and its element is returned in missed --
+// the caller should then send the peer a DeltaResync (see NewDeltaResync) so that the element is resent in full.
+// ErrCode_MalformedTx is returned if a delta is corrupt, in which case tx and this cache are left unchanged.
+func (cache *ValueCache) Resolve(tx *TxMsg) (missed []ElementID, err error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	// changes are staged until all ops resolve -- a nil entry denotes a removed element
+	staged := make(map[ElementID]*cachedValue)
+	latest := func(elemID ElementID) (cachedValue, bool) {
+		if entry, isStaged := staged[elemID]; isStaged {
+			if entry == nil {
+				return cachedValue{}, false
+			}
+			return *entry, true
+		}
+		entry, exists := cache.elems[elemID]
+		return entry, exists
+	}
+
+	dataLen := len(tx.DataStore)
+	fail := func(err error) ([]ElementID, error) {
+		tx.DataStore = tx.DataStore[:dataLen]
+		return nil, err
+	}
+
+	ops := make([]TxOp, 0, len(tx.Ops))
+	for _, op := range tx.Ops {
+		elemID := ElementID{op.CellID, op.AttrID, op.ItemID}
+
+		switch op.OpCode {
+		case TxOpCode_UpsertElement:
+			data, ok := opSpan(tx.DataStore, &op)
+			if !ok {
+				return fail(ErrCode_MalformedTx.Error("Resolve: bad upsert span"))
+			}
+			staged[elemID] = &cachedValue{op.EditID, data}
+
+		case TxOpCode_DeleteElement:
+			staged[elemID] = nil
+
+		case TxOpCode_UpsertDelta:
+			delta, ok := opSpan(tx.DataStore, &op)
+			if !ok || op.DataLen < deltaBaseSize {
+				return fail(ErrCode_MalformedTx.Error("Resolve: bad delta span"))
+			}
+
+			var baseID tag.ID
+			for i := range baseID {
+				baseID[i] = binary.LittleEndian.Uint64(delta[i*8:])
+			}
+			base, exists := latest(elemID)
+			if !exists || base.editID != baseID {
+				staged[elemID] = nil
+				missed = append(missed, elemID)
+				continue
+			}
+
+			// the value is reconstructed onto the end of the data store (base and delta remain valid if it grows)
+			ofs := len(tx.DataStore)
+			if tx.DataStore, err = applyValueDelta(tx.DataStore, base.data, delta[deltaBaseSize:]); err != nil {
+				return fail(err)
+			}
+			value := tx.DataStore[ofs:]
+			staged[elemID] = &cachedValue{op.EditID, value}
+
+			op.OpCode = TxOpCode_UpsertElement
+			op.DataOfs = uint64(ofs)
+			op.DataLen = uint64(len(value))
+		}
+		ops = append(ops, op)
+	}
+
+	for elemID, entry := range staged {
+		if entry == nil {
+			delete(cache.elems, elemID)
+		} else {
+			cache.put(elemID, entry.editID, entry.data)
+		}
+	}
+	tx.Ops = ops
+	tx.OpCount = uint64(len(ops))
+	return missed, nil
+}
+
+// opSpan returns the value of the given op in data, or false if its span is out of range.
+func opSpan(data []byte, op *TxOp) ([]byte, bool) {
+	end := op.DataOfs + op.DataLen
+	if end < op.DataOfs || end > uint64(len(data)) {
+		return nil, false
+	}
+	return data[op.DataOfs:end], true
+}
+
+// UpsertDelta appends an upsert of the given element, sending val as a delta against the element's previous revision in cache
+// if one exists and the delta is smaller than val -- otherwise val is sent in full.  The cache is then updated to hold val.
+//
+// The receiver must pass the tx to ValueCache.Resolve() before accessing its values.
+func (tx *TxMsg) UpsertDelta(cache *ValueCache, cellID, attrID, itemID tag.ID, val tag.Value) error {
+	op := TxOp{}
+	op.OpCode = TxOpCode_UpsertElement
+	op.CellID = cellID
+	op.AttrID = attrID
+	op.ItemID = itemID
+	op.EditID = tag.Genesis(tx.GenesisID())
+
+	// marshal val to the data store as a full upsert, then replace it with a delta if smaller
+	if err := tx.MarshalOp(&op, val); err != nil {
+		return err
+	}
+	full := tx.DataStore[op.DataOfs : op.DataOfs+op.DataLen]
+	elemID := ElementID{cellID, attrID, itemID}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if base, exists := cache.elems[elemID]; exists {
+		delta := make([]byte, deltaBaseSize, deltaBaseSize+16)
+		for i, word := range base.editID {
+			binary.LittleEndian.PutUint64(delta[i*8:], word)
+		}
+		delta = appendValueDelta(delta, base.data, full)
+
+		if len(delta) < len(full) {
+			cache.put(elemID, op.EditID, full)
+
+			last := &tx.Ops[len(tx.Ops)-1]
+			last.OpCode = TxOpCode_UpsertDelta
+			last.DataLen = uint64(len(delta))
+			tx.DataStore = append(tx.DataStore[:last.DataOfs], delta...)
+			return nil
+		}
+	}
+
+	cache.put(elemID, op.EditID, full)
+	return nil
+}
+
+// NewDeltaResync returns a DeltaResync listing the given elements -- see ValueCache.Resolve()
+func NewDeltaResync(elemIDs []ElementID) *DeltaResync {
+	msg := &DeltaResync{
+		Elements: make([]uint64, 0, 9*len(elemIDs)),
+	}
+	for _, elemID := range elemIDs {
+		for _, id := range elemID {
+			msg.Elements = append(msg.Elements, id[0], id[1], id[2])
+		}
+	}
+	return msg
+}
+
+// ElementIDs returns the elements listed in this DeltaResync.
+func (msg *DeltaResync) ElementIDs() []ElementID {
+	words := msg.Elements
+	elemIDs := make([]ElementID, 0, len(words)/9)
+	for ; len(words) >= 9; words = words[9:] {
+		elemIDs = append(elemIDs, ElementID{
+			{words[0], words[1], words[2]},
+			{words[3], words[4], words[5]},
+			{words[6], words[7], words[8]},
+		})
+	}
+	return elemIDs
+}
+
+// appendValueDelta appends the delta that reconstructs target from base -- see applyValueDelta()
+func appendValueDelta(dst, base, target []byte) []byte {
+	matchLen := func(pos, limit int) int {
+		n := 0
+		for n < limit && pos+n < len(target) && pos+n < len(base) && base[pos+n] == target[pos+n] {
+			n++
+		}
+		return n
+	}
+
+	dst = binary.AppendUvarint(dst, uint64(len(target)))
+	for pos := 0; pos < len(target); {
+		copyLen := matchLen(pos, len(target))
+
+		// extend the literal until a worthwhile match or the end of target
+		litStart := pos + copyLen
+		litEnd := litStart
+		for litEnd < len(target) && matchLen(litEnd, deltaMinCopy) < deltaMinCopy {
+			litEnd++
+		}
+
+		dst = binary.AppendUvarint(dst, uint64(copyLen))
+		dst = binary.AppendUvarint(dst, uint64(litEnd-litStart))
+		dst = append(dst, target[litStart:litEnd]...)
+		pos = litEnd
+	}
+	return dst
+}
+
+// applyValueDelta appends the value reconstructed from base and a delta made by appendValueDelta().
+func applyValueDelta(dst, base, delta []byte) ([]byte, error) {
+	targetLen, p, ok := readUvarint(delta, 0)
+	if !ok {
+		return dst, ErrMalformedTx
+	}
+
+	start := len(dst)
+	for uint64(len(dst)-start) < targetLen {
+		var copyLen, litLen uint64
+		if copyLen, p, ok = readUvarint(delta, p); !ok {
+			return dst, ErrMalformedTx
+		}
+		if litLen, p, ok = readUvarint(delta, p); !ok {
+			return dst, ErrMalformedTx
+		}
+
+		// pos < targetLen, and comparisons are made by subtraction so that a forged length cannot overflow
+		pos := uint64(len(dst) - start)
+		baseRemain := uint64(0)
+		if pos < uint64(len(base)) {
+			baseRemain = uint64(len(base)) - pos
+		}
+		if copyLen > baseRemain || copyLen > targetLen-pos || litLen > targetLen-pos-copyLen ||
+			litLen > uint64(len(delta)-p) || copyLen+litLen == 0 {
+			return dst, ErrCode_MalformedTx.Error("bad value delta")
+		}
+		if copyLen > 0 {
+			dst = append(dst, base[pos:pos+copyLen]...)
+		}
+		dst = append(dst, delta[p:p+int(litLen)]...)
+		p += int(litLen)
+	}
+	return dst, nil
+}
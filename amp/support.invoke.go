@@ -0,0 +1,236 @@
+package amp
+
+import (
+	"mime"
+	"sort"
+	"strings"
+)
+
+// Invocation match scores -- higher is better.
+const (
+	scoreAlias        = 1000 // alias equals the entire invocation
+	scoreAliasPrefix  = 500  // alias is a leading path of the invocation, plus scorePerSegment per matched segment
+	scorePerSegment   = 10   // favors the longest matching alias prefix
+	scoreImplicit     = -100 // applied to an app's implicit leaf-name alias
+	scoreContentType  = 300  // app content type equals the invocation's media type
+	scoreContentGroup = 200  // app content type matches the invocation's media type group -- e.g. "image/*"
+	scoreScheme       = 150  // app has the invocation's URL scheme as an alias -- e.g. "ipfs:"
+	scoreContentAny   = 100  // app content type is "*/*"
+	scoreInherited    = -1   // applied to matches from a parent registry so that local overrides win
+)
+
+// Implements Registry
+func (reg *registry) GetAppForInvocation(invocation string) (*App, error) {
+	matches, err := reg.GetAppsForInvocation(invocation)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) > 1 && matches[0].Score == matches[1].Score {
+		var candidates []string
+		for _, match := range matches {
+			if match.Score != matches[0].Score {
+				break
+			}
+			candidates = append(candidates, match.App.AppSpec.Canonic)
+		}
+		return nil, ErrCode_AppConflict.Errorf("ambiguous invocation %q: %s", invocation, strings.Join(candidates, ", "))
+	}
+	return matches[0].App, nil
+}
+
+// Implements Registry
+func (reg *registry) GetAppsForInvocation(invocation string) ([]AppMatch, error) {
+	if invocation == "" {
+		return nil, ErrCode_AppNotFound.Errorf("missing app invocation")
+	}
+
+	matches, err := reg.matchInvocation(invocation)
+
+	// merge matches from the parent, where apps in this layer override the parent's and win ties
+	if parent := reg.opts.Parent; parent != nil {
+		inherited, parentErr := parent.GetAppsForInvocation(invocation)
+		for _, match := range inherited {
+			if appTag := match.App.AppSpec.ID; !reg.hasApp(appTag) && !reg.isMasked(appTag) {
+				match.Score += scoreInherited
+				matches = append(matches, match)
+			}
+		}
+		if len(matches) == 0 && err == nil {
+			err = parentErr
+		}
+	}
+	if len(matches) == 0 {
+		if err == nil {
+			err = ErrCode_AppNotFound.Errorf("app not found for invocation %q", invocation)
+		}
+		return nil, err
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].App.AppSpec.Canonic < matches[j].App.AppSpec.Canonic
+	})
+	return matches, nil
+}
+
+// matchInvocation returns the unsorted matches for an invocation within this layer.
+func (reg *registry) matchInvocation(invocation string) ([]AppMatch, error) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	best := make(map[*App]AppMatch)
+	offer := func(match AppMatch, alias string) {
+		if name, _, _ := strings.Cut(alias, "@"); alias != "" && reg.aliases[name].implicit {
+			match.Score += scoreImplicit
+		}
+		if prev, exists := best[match.App]; !exists || match.Score > prev.Score {
+			best[match.App] = match
+		}
+	}
+
+	// an alias may be the entire invocation (including aliases such as "ipfs:" that are not valid URLs)
+	app, err := reg.appForAlias(invocation)
+	if err != nil {
+		return nil, err
+	}
+	if app != nil {
+		offer(AppMatch{App: app, Score: scoreAlias}, invocation)
+	}
+
+	u, err := ParseURL(invocation)
+	if err != nil {
+		if len(best) == 0 {
+			return nil, err
+		}
+		u = nil
+	}
+
+	var mediaType string
+	switch {
+	case u == nil:
+	case u.Scheme == UrlScheme_Amp:
+
+		// route by longest matching alias within "{app-alias}/{cmd}/{uri}"
+		var (
+			segments []string
+			routed   bool
+		)
+		for _, part := range []string{u.Amp.Alias, u.Amp.Cmd, u.Amp.URI} {
+			if part != "" {
+				segments = append(segments, strings.Split(part, "/")...)
+			}
+		}
+		for n := len(segments); n > 0; n-- {
+			alias := strings.Join(segments[:n], "/")
+			app, err := reg.appForAlias(alias)
+			if err != nil {
+				return nil, err
+			}
+			if app == nil {
+				continue
+			}
+			match := AppMatch{
+				App:    app,
+				Score:  scoreAliasPrefix + n*scorePerSegment,
+				Remain: strings.Join(segments[n:], "/"),
+			}
+			if u.URL.RawQuery != "" {
+				match.Remain += "?" + u.URL.RawQuery
+			}
+			offer(match, alias)
+			routed = true
+		}
+
+		// a scheme-less invocation not routed by alias may be a media type -- e.g. "image/png"
+		if scheme, _ := splitScheme(invocation); scheme == "" && !routed && len(segments) == 2 && u.URL.RawQuery == "" {
+			if parsed, _, err := mime.ParseMediaType(invocation); err == nil {
+				mediaType = parsed
+			}
+		}
+
+	default:
+		// route by scheme alias -- e.g. an app having "ipfs:" as an invocation
+		scheme, rest := splitScheme(invocation)
+		alias := strings.ToLower(scheme) + ":"
+		app, err := reg.appForAlias(alias)
+		if err != nil {
+			return nil, err
+		}
+		if app != nil {
+			offer(AppMatch{
+				App:    app,
+				Score:  scoreScheme,
+				Remain: rest,
+			}, alias)
+		}
+		mediaType = u.MediaType()
+	}
+
+	// match content type handlers
+	if mediaType != "" {
+		for _, entries := range reg.appsByTag {
+			app := entries[0].app
+			for _, contentType := range app.ContentTypes {
+				if score := matchContentType(contentType, mediaType); score > 0 {
+					offer(AppMatch{
+						App:   app,
+						Score: score,
+					}, "")
+				}
+			}
+		}
+	}
+
+	matches := make([]AppMatch, 0, len(best))
+	for _, match := range best {
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// appForAlias returns the app claiming the given alias, which may be of the form "{alias}@{version}" -- or nil if not found.
+// Caller must hold reg.mu.
+func (reg *registry) appForAlias(alias string) (*App, error) {
+	name, versExpr, hasVers := strings.Cut(alias, "@")
+	claim, exists := reg.aliases[name]
+	if !exists {
+		return nil, nil
+	}
+
+	var (
+		prefix Version
+		parts  int
+	)
+	if hasVers {
+		var err error
+		if prefix, parts, err = parseVersionPrefix(versExpr); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range reg.appsByTag[claim.appTag] {
+		if !hasVers || entry.vers.matchesPrefix(prefix, parts) {
+			return entry.app, nil
+		}
+	}
+	return nil, nil
+}
+
+// matchContentType returns the score of a content type pattern (e.g. "image/*") for a media type, or 0 if it does not match.
+func matchContentType(pattern, mediaType string) int {
+	pattern = strings.ToLower(pattern)
+	switch {
+	case pattern == mediaType:
+		return scoreContentType
+	case pattern == "*/*" || pattern == "*":
+		return scoreContentAny
+	case strings.HasSuffix(pattern, "/*"):
+		group := pattern[:len(pattern)-1] // keep the trailing '/'
+		if strings.HasPrefix(mediaType, group) {
+			return scoreContentGroup
+		}
+	}
+	return 0
+}
@@ -0,0 +1,153 @@
+package amp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+// InitDeadline sets req.Deadline from PinRequest.TimeoutMs relative to when the request was received, unless Deadline is already set.
+// A host calls this upon receiving a request and before serving it, so that a Pin can start its task.Context with the deadline.
+func (req *Request) InitDeadline(received time.Time) {
+	if timeout := req.Timeout(); timeout > 0 && req.Deadline.IsZero() {
+		req.Deadline = received.Add(timeout)
+	}
+}
+
+// NewCancelTx returns a tx that cancels the in-flight request having the given ID -- see PinTable.HandleTx()
+func NewCancelTx(reqID tag.ID, reason string) (*TxMsg, error) {
+	cancel := &CancelRequest{
+		Reason: reason,
+	}
+	tx, err := MarshalAttr(MetaNodeID, cancel.TagSpec().ID, cancel)
+	if err != nil {
+		return nil, err
+	}
+	tx.SetContextID(reqID)
+	tx.Status = OpStatus_Closed
+	tx.Priority = TxPriority_Control
+	return tx, nil
+}
+
+// PinTable tracks the in-flight requests of a session by request ID so that a host can cancel them and enforce their deadlines.
+// A request is tracked from Add() until its Pin's context is done.
+type PinTable struct {
+	mu   sync.Mutex
+	pins map[tag.ID]*pinEntry
+}
+
+type pinEntry struct {
+	op    Requester
+	pin   Pin
+	timer clock.Timer // closes the pin at the request's deadline (or nil if none)
+}
+
+// NewPinTable returns an empty PinTable.
+func NewPinTable() *PinTable {
+	return &PinTable{
+		pins: make(map[tag.ID]*pinEntry),
+	}
+}
+
+// Add tracks the Pin serving the given request.
+// If the request has a Deadline, the Pin is closed once it passes and the Requester is completed with ErrTimeout
+// (as it also is if the Pin's context closes due to its own deadline), where the deadline is measured by the Info.Clock of the Pin's context.
+// ErrCode_BadRequest is returned if the request ID is already tracked.
+func (pt *PinTable) Add(op Requester, pin Pin) error {
+	req := op.Request()
+	reqID := req.ID
+
+	entry := &pinEntry{
+		op:  op,
+		pin: pin,
+	}
+
+	pt.mu.Lock()
+	if _, exists := pt.pins[reqID]; exists {
+		pt.mu.Unlock()
+		return ErrCode_BadRequest.Errorf("request %v already pinned", reqID)
+	}
+	pt.pins[reqID] = entry
+	ctx := pin.Context()
+	if !req.Deadline.IsZero() {
+		clk := ctx.Info().Clock
+		if clk == nil {
+			clk = clock.System
+		}
+		entry.timer = clk.NewTimer(req.Deadline.Sub(clk.Now()))
+	}
+	pt.mu.Unlock()
+
+	go func() {
+		var expired <-chan time.Time
+		if entry.timer != nil {
+			expired = entry.timer.C()
+		}
+		select {
+		case <-ctx.Done():
+		case <-expired:
+			pt.closeRequest(reqID, ErrTimeout)
+			return
+		}
+
+		pt.mu.Lock()
+		tracked := pt.pins[reqID] == entry
+		if tracked {
+			delete(pt.pins, reqID)
+		}
+		pt.mu.Unlock()
+
+		if tracked {
+			if entry.timer != nil {
+				entry.timer.Stop()
+			}
+			if ctx.Err() == context.DeadlineExceeded { // the pin's own deadline closed it
+				op.OnComplete(ErrTimeout)
+			}
+		}
+	}()
+	return nil
+}
+
+// Len returns the number of tracked requests.
+func (pt *PinTable) Len() int {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	return len(pt.pins)
+}
+
+// Cancel closes the Pin serving the given request and completes its Requester with ErrRequestClosed.
+// Returns false if the request is not tracked.
+func (pt *PinTable) Cancel(reqID tag.ID) bool {
+	return pt.closeRequest(reqID, ErrRequestClosed)
+}
+
+// HandleTx cancels the targeted request if the given tx is a CancelRequest meta tx (see NewCancelTx), returning true if so.
+func (pt *PinTable) HandleTx(tx *TxMsg) bool {
+	if len(tx.Ops) != 1 || tx.Ops[0].CellID != MetaNodeID || tx.Ops[0].AttrID != (&CancelRequest{}).TagSpec().ID {
+		return false
+	}
+	pt.Cancel(tx.ContextID())
+	return true
+}
+
+// closeRequest stops tracking the given request, completes its Requester with err, and closes its Pin.
+func (pt *PinTable) closeRequest(reqID tag.ID, err error) bool {
+	pt.mu.Lock()
+	entry := pt.pins[reqID]
+	delete(pt.pins, reqID)
+	pt.mu.Unlock()
+
+	if entry == nil {
+		return false
+	}
+	if entry.timer != nil {
+		entry.timer.Stop()
+	}
+	entry.op.OnComplete(err)
+	entry.pin.Context().Close()
+	return true
+}
@@ -1,29 +1,66 @@
 package amp
 
 import (
+	"fmt"
 	"reflect"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
 )
 
+// NewRegistry creates a Registry using DefaultRegistryOpts().
 func NewRegistry() Registry {
+	return DefaultRegistryOpts().CreateRegistry()
+}
+
+// DefaultRegistryOpts is a suggested set of options.
+func DefaultRegistryOpts() RegistryOpts {
+	return RegistryOpts{
+		Conflicts: ConflictPolicy_Reject,
+	}
+}
+
+// CreateRegistry creates an empty Registry having these options.
+func (opts RegistryOpts) CreateRegistry() Registry {
 	reg := &registry{
-		appsByInvoke: make(map[string]*App),
-		appsByTag:    make(map[tag.ID]*App),
-		elemDefs:     make(map[tag.ID]AttrDef),
-		attrDefs:     make(map[tag.ID]AttrDef),
+		opts:      opts,
+		aliases:   make(map[string]appAlias),
+		appsByTag: make(map[tag.ID][]appEntry),
+		masked:    make(map[tag.ID]struct{}),
 	}
+	reg.attrDefs.Store(&attrDefMap{})
 	return reg
 }
 
 // Implements Registry
 type registry struct {
-	mu           sync.RWMutex
-	appsByInvoke map[string]*App
-	appsByTag    map[tag.ID]*App
-	elemDefs     map[tag.ID]AttrDef
-	attrDefs     map[tag.ID]AttrDef
+	mu        sync.RWMutex
+	opts      RegistryOpts
+	aliases   map[string]appAlias
+	appsByTag map[tag.ID][]appEntry // sorted by descending version
+	masked    map[tag.ID]struct{}   // parent apps unregistered from this layer
+
+	// attrDefs is copy-on-write: writers hold mu, copy, and swap, allowing MakeValue() to read lock-free
+	attrDefs atomic.Pointer[attrDefMap]
+}
+
+// attrDefMap is an immutable snapshot of attr defs by spec ID.
+type attrDefMap map[tag.ID]AttrDef
+
+// appEntry is a registered app and its parsed version.
+type appEntry struct {
+	app  *App
+	vers Version
+}
+
+// appAlias maps an invocation alias to the app spec that claimed it.
+type appAlias struct {
+	appTag   tag.ID
+	implicit bool // true if this is an app's leaf-name alias (and can be claimed by an explicit alias)
 }
 
 func (reg *registry) RegisterPrototype(context tag.Spec, prototype tag.Value, subTags string) tag.Spec {
@@ -36,261 +73,427 @@ func (reg *registry) RegisterPrototype(context tag.Spec, prototype tag.Value, su
 	}
 
 	attrSpec := context.With(subTags)
-	reg.mu.Lock()
-	defer reg.mu.Unlock()
-	reg.attrDefs[attrSpec.ID] = AttrDef{
+	reg.putAttrDefs(AttrDef{
 		Spec:      attrSpec,
 		Prototype: prototype,
-	}
+	})
 	return attrSpec
 }
 
-func (reg *registry) Import(other Registry) error {
-	src := other.(*registry)
+// Implements Registry
+func (reg *registry) Import(other RegistryEnumerator) error {
 
-	src.mu.Lock()
-	defer src.mu.Unlock()
+	// snapshot the source before locking so that no two registry locks are ever held at once
+	defs := other.AttrDefs()
+	apps := other.Apps()
 
-	{
-		reg.mu.Lock()
-		for _, def := range src.elemDefs {
-			reg.elemDefs[def.ID] = def
-		}
-		for _, def := range src.attrDefs {
-			reg.attrDefs[def.ID] = def
-		}
-		reg.mu.Unlock()
-	}
+	reg.putAttrDefs(defs...)
 
-	for _, app := range src.appsByTag {
-		if err := reg.RegisterApp(app); err != nil { //fix me
-			return err
+	var err error
+	for _, app := range apps {
+		if appErr := reg.RegisterApp(app); appErr != nil && err == nil {
+			err = appErr
 		}
 	}
-	return nil
+	return err
 }
 
 // Implements Registry
 func (reg *registry) RegisterApp(app *App) error {
-	appTag := app.AppSpec.ID
-
+	vers, err := ParseVersion(app.Version)
+	if err != nil {
+		return ErrCode_BadValue.Errorf("RegisterApp %q: %v", app.AppSpec.Canonic, err)
+	}
 	reg.mu.Lock()
 	defer reg.mu.Unlock()
 
-	reg.appsByTag[appTag] = app
+	return reg.registerApp(app, vers)
+}
+
+func (reg *registry) registerApp(app *App, vers Version) error {
+	appTag := app.AppSpec.ID
+
+	// check for an already registered version before claiming anything so a rejected app leaves no trace
+	entries := reg.appsByTag[appTag]
+	versIdx := -1
+	for i, entry := range entries {
+		if entry.app == app {
+			return nil // already registered (e.g. via Import)
+		}
+		if entry.vers.Compare(vers) == 0 {
+			versIdx = i
+		}
+	}
+	if versIdx >= 0 {
+		switch reg.opts.Conflicts {
+		case ConflictPolicy_KeepFirst:
+			return nil
+		case ConflictPolicy_Reject:
+			return ErrCode_AppConflict.Errorf("RegisterApp: %q %v already registered", app.AppSpec.Canonic, vers)
+		}
+	}
 
+	// explicit aliases: the app's full spec and its invocations
+	explicit := make([]string, 0, len(app.Invocations)+1)
+	explicit = append(explicit, app.AppSpec.Canonic)
 	for _, invok := range app.Invocations {
 		if invok != "" {
-			reg.appsByInvoke[invok] = app
+			explicit = append(explicit, invok)
 		}
 	}
 
-	// invoke by full app ID
-	reg.appsByInvoke[app.AppSpec.Canonic] = app
+	claims := explicit[:0:0]
+	for _, alias := range explicit {
+		prev, exists := reg.aliases[alias]
+		if exists && prev.appTag != appTag && !prev.implicit {
+			switch reg.opts.Conflicts {
+			case ConflictPolicy_KeepFirst:
+				continue
+			case ConflictPolicy_Reject:
+				return ErrCode_AppConflict.Errorf("RegisterApp %q: invocation %q already claimed by %s", app.AppSpec.Canonic, alias, prev.appTag)
+			}
+		}
+		claims = append(claims, alias)
+	}
 
-	// invoke by first component of app ID
+	if versIdx >= 0 {
+		entries[versIdx].app = app
+	} else {
+		entries = append(entries, appEntry{app: app, vers: vers})
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].vers.Compare(entries[j].vers) > 0
+		})
+		reg.appsByTag[appTag] = entries
+	}
+	delete(reg.masked, appTag)
+
+	for _, alias := range claims {
+		reg.aliases[alias] = appAlias{appTag: appTag}
+	}
+
+	// invoke by last component of app ID, but only if no other app has a claim on it
 	_, leafName := app.AppSpec.LeafTags(1)
-	reg.appsByInvoke[leafName] = app
+	if leafName != "" {
+		prev, exists := reg.aliases[leafName]
+		if !exists || (prev.implicit && reg.opts.Conflicts == ConflictPolicy_Replace) {
+			reg.aliases[leafName] = appAlias{appTag: appTag, implicit: true}
+		}
+	}
 
 	return nil
 }
 
 // Implements Registry
-func (reg *registry) GetAppByTag(appTag tag.ID) (*App, error) {
-	reg.mu.RLock()
-	defer reg.mu.RUnlock()
+func (reg *registry) UnregisterApp(appTag tag.ID) error {
+	inherited := reg.parentApp(appTag)
 
-	app := reg.appsByTag[appTag]
-	if app == nil {
-		return nil, ErrCode_AppNotFound.Errorf("app not found: %s", appTag)
-	} else {
-		return app, nil
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	local := reg.unregisterApp(appTag)
+	if inherited != nil {
+		if _, masked := reg.masked[appTag]; !masked {
+			reg.masked[appTag] = struct{}{}
+			return nil
+		}
 	}
+	if local == nil {
+		return ErrCode_AppNotFound.Errorf("UnregisterApp: app not found: %s", appTag)
+	}
+	return nil
 }
 
 // Implements Registry
-func (reg *registry) GetAppForInvocation(invocation string) (*App, error) {
-	if invocation == "" {
-		return nil, ErrCode_AppNotFound.Errorf("missing app invocation")
+func (reg *registry) ReplaceApp(app *App) (*App, error) {
+	vers, err := ParseVersion(app.Version)
+	if err != nil {
+		return nil, ErrCode_BadValue.Errorf("ReplaceApp %q: %v", app.AppSpec.Canonic, err)
 	}
 
-	reg.mu.RLock()
-	defer reg.mu.RUnlock()
+	appTag := app.AppSpec.ID
+	inherited := reg.parentApp(appTag)
 
-	app := reg.appsByInvoke[invocation]
-	if app == nil {
-		return nil, ErrCode_AppNotFound.Errorf("app not found for invocation %q", invocation)
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	_, wasMasked := reg.masked[appTag]
+	prevAliases := make(map[string]appAlias)
+	for alias, claim := range reg.aliases {
+		if claim.appTag == appTag {
+			prevAliases[alias] = claim
+		}
 	}
-	return app, nil
-}
 
-func (reg *registry) MakeValue(attrSpec tag.ID) (tag.Value, error) {
+	prevEntries := reg.unregisterApp(appTag)
+	if err = reg.registerApp(app, vers); err != nil {
+		for alias := range reg.aliases {
+			if reg.aliases[alias].appTag == appTag {
+				delete(reg.aliases, alias)
+			}
+		}
+		for alias, claim := range prevAliases {
+			reg.aliases[alias] = claim
+		}
+		if prevEntries != nil {
+			reg.appsByTag[appTag] = prevEntries
+		} else {
+			delete(reg.appsByTag, appTag)
+		}
+		if wasMasked {
+			reg.masked[appTag] = struct{}{}
+		}
+		return nil, err
+	}
 
-	// Often, an attrID will be a unnamed scalar attr (which means we can get the elemDef directly.
-	// This is also essential during bootstrapping when the client sends a RegisterDefs is not registered yet.
-	def, exists := reg.elemDefs[attrSpec]
-	if !exists {
-		def, exists = reg.attrDefs[attrSpec]
-		if !exists {
-			return nil, ErrCode_AttrNotFound.Errorf("MakeValue: attr %s not found", attrSpec.String())
+	switch {
+	case len(prevEntries) > 0:
+		return prevEntries[0].app, nil
+	case inherited != nil && !wasMasked:
+		return inherited, nil
+	}
+	return nil, nil
+}
+
+// unregisterApp removes all versions of the given app and its aliases, returning the removed entries.
+func (reg *registry) unregisterApp(appTag tag.ID) []appEntry {
+	entries := reg.appsByTag[appTag]
+	if entries == nil {
+		return nil
+	}
+	delete(reg.appsByTag, appTag)
+	for alias, claim := range reg.aliases {
+		if claim.appTag == appTag {
+			delete(reg.aliases, alias)
 		}
 	}
-	return def.Prototype.New(), nil
+	return entries
 }
 
-/*
-func (reg *registry) RegisterDefs(defs *RegisterDefs) error {
+// Implements Registry
+func (reg *registry) GetAppByTag(appTag tag.ID) (*App, error) {
+	reg.mu.RLock()
+	entries := reg.appsByTag[appTag]
+	reg.mu.RUnlock()
 
-	for _, tagSpec := range defs.TagSpecs {
-		def := AttrDef{
-			Spec: tag.FormSpec(tag.Spec{}, tagSpec),
+	if len(entries) == 0 {
+		if parent := reg.opts.Parent; parent != nil && !reg.isMasked(appTag) {
+			return parent.GetAppByTag(appTag)
 		}
-		reg.attrDefs[def.Spec.ID] = def
+		return nil, ErrCode_AppNotFound.Errorf("app not found: %s", appTag)
 	}
+	return entries[0].app, nil
+}
 
-	return nil
+// hasApp returns true if the given app is registered in this layer (vs a parent).
+func (reg *registry) hasApp(appTag tag.ID) bool {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	return len(reg.appsByTag[appTag]) > 0
 }
 
+// isMasked returns true if the given parent app has been unregistered from this layer.
+func (reg *registry) isMasked(appTag tag.ID) bool {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	_, masked := reg.masked[appTag]
+	return masked
+}
 
-func MakeSchemaForType(valTyp reflect.Type) (*AttrSchema, error) {
-	numFields := valTyp.NumField()
+// parentApp returns the latest version of the given app registered in the parent, or nil if none.
+// Caller must not hold reg.mu so that no two registry locks are ever held at once.
+func (reg *registry) parentApp(appTag tag.ID) *App {
+	parent := reg.opts.Parent
+	if parent == nil {
+		return nil
+	}
+	app, err := parent.GetAppByTag(appTag)
+	if err != nil {
+		return nil
+	}
+	return app
+}
 
-	schema := &AttrSchema{
-		CellDataModel: valTyp.Name(),
-		SchemaName:    "on-demand-reflect",
-		Attrs:         make([]*tag.Spec, 0, numFields),
+// Implements Registry
+//
+// MakeValue is on the hot decode path of every session, so it reads the current attr def snapshot without locking.
+func (reg *registry) MakeValue(attrSpec tag.ID) (tag.Value, error) {
+	def, exists := (*reg.attrDefs.Load())[attrSpec]
+	if !exists {
+		if parent := reg.opts.Parent; parent != nil {
+			return parent.MakeValue(attrSpec)
+		}
+		return nil, ErrCode_AttrNotFound.Errorf("MakeValue: attr %s not found", attrSpec.String())
 	}
+	return def.Prototype.New(), nil
+}
 
-	for i := 0; i < numFields; i++ {
+// putAttrDefs publishes a new attr def snapshot containing the given defs.
+// Registration is infrequent (mostly at startup), so the cost of copying is paid here rather than by readers.
+func (reg *registry) putAttrDefs(defs ...AttrDef) {
+	if len(defs) == 0 {
+		return
+	}
 
-		// Importantly, TagSpecID is always set to the field index + 1, so we know what field to inspect when given an TagSpecID.
-		field := valTyp.Field(i)
-		if !field.IsExported() {
-			continue
-		}
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
 
-		attr := &tag.Spec{
-			TypedName: field.Name,
-			TagSpecID:  int32(i + 1),
-		}
+	prev := *reg.attrDefs.Load()
+	next := make(attrDefMap, len(prev)+len(defs))
+	for id, def := range prev {
+		next[id] = def
+	}
+	for _, def := range defs {
+		next[def.ID] = def
+	}
+	reg.attrDefs.Store(&next)
+}
 
-		attrType := field.Type
-		attrKind := attrType.Kind()
-		switch attrKind {
-		case reflect.Int32, reflect.Uint32, reflect.Int64, reflect.Uint64:
-			attr.ValTypeID = int32(ValType_int)
-		case reflect.String:
-			attr.ValTypeID = int32(ValType_string)
-		case reflect.Slice:
-			elementType := attrType.Elem().Kind()
-			switch elementType {
-			case reflect.Uint8, reflect.Int8:
-				attr.ValTypeID = int32(ValType_bytes)
-			}
-		}
+// Implements Registry
+func (reg *registry) Apps() []*App {
+	var inherited []*App
+	if parent := reg.opts.Parent; parent != nil {
+		inherited = parent.Apps()
+	}
 
-		if attr.ValTypeID == 0 {
-			return nil, ErrCode_ExportErr.Errorf("unsupported type '%s.%s (%v)", schema.CellDataModel, attr.TypedName, attrKind)
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	apps := make([]*App, 0, len(reg.appsByTag)+len(inherited))
+	for _, entries := range reg.appsByTag {
+		for _, entry := range entries {
+			apps = append(apps, entry.app)
 		}
+	}
 
-		schema.Attrs = append(schema.Attrs, attr)
+	// apps registered in this layer override all versions of the same app in the parent
+	for _, app := range inherited {
+		_, masked := reg.masked[app.AppSpec.ID]
+		if len(reg.appsByTag[app.AppSpec.ID]) == 0 && !masked {
+			apps = append(apps, app)
+		}
 	}
-	return schema, nil
+	return apps
 }
 
-// ReadCell loads a cell with the given URI having the inferred schema (built from its fields using reflection).
-// The URI is scoped into the user's home space and AppID.
-func ReadCell(ctx AppContext, subKey string, schema *AttrSchema, dstStruct any) error {
-
-	dst := reflect.Indirect(reflect.ValueOf(dstStruct))
-	switch dst.Kind() {
-	case reflect.Pointer:
-		dst = dst.Elem()
-	case reflect.Struct:
-	default:
-		return ErrCode_ExportErr.Errorf("expected struct, got %v", dst.Kind())
+// Implements Registry
+func (reg *registry) AttrDefs() []AttrDef {
+	var inherited []AttrDef
+	if parent := reg.opts.Parent; parent != nil {
+		inherited = parent.AttrDefs()
 	}
 
-	var keyBuf [128]byte
-	cellKey := append(append(keyBuf[:0], []byte(ctx.StateScope())...), []byte(subKey)...)
-
-	msgs := make([]*Msg, 0, len(schema.Attrs))
-	err := ctx.LoginInfo().HomePlanet().ReadCell(cellKey, schema, func(msg *Msg) {
-		switch msg.Op {
-		case MsgOp_PushAttr:
-			msgs = append(msgs, msg)
+	local := *reg.attrDefs.Load()
+	defs := make([]AttrDef, 0, len(local)+len(inherited))
+	for _, def := range local {
+		defs = append(defs, def)
+	}
+	for _, def := range inherited {
+		if _, overridden := local[def.ID]; !overridden {
+			defs = append(defs, def)
 		}
-	})
-	if err != nil {
-		return err
 	}
+	return defs
+}
 
-	numFields := dst.NumField()
-	valType := dst.Type()
+// Implements Registry
+func (reg *registry) RegisterDefs(defs *RegisterDefs) error {
+	known := reg.AttrDefs() // includes parent defs
+	prototypes := make(map[string]tag.Value, len(known))
+	for _, def := range known {
+		prototypes[ElementTypeName(def.Prototype)] = def.Prototype
+	}
 
-	for fi := 0; fi < numFields; fi++ {
-		field := valType.Field(fi)
-		for _, ai := range schema.Attrs {
-			if ai.TypedName == field.Name {
-				for _, msg := range msgs {
-					if msg.TagSpecID == ai.TagSpecID {
-						msg.LoadVal(dst.Field(fi).Addr().Interface())
-						goto nextField
-					}
-				}
+	var err error
+	added := make([]AttrDef, 0, len(defs.Attrs))
+	for _, attr := range defs.Attrs {
+		prototype := prototypes[attr.TypeName]
+		if prototype == nil {
+			if err == nil {
+				err = ErrCode_AttrNotFound.Errorf("RegisterDefs: unknown element type %q for %q", attr.TypeName, attr.Spec.GetUID())
 			}
+			continue
+		}
+		spec := tag.Spec{
+			ID:      attr.Spec.TagID(),
+			Canonic: attr.Spec.GetUID(),
 		}
-	nextField:
+		added = append(added, AttrDef{
+			Spec:      spec,
+			Prototype: prototype,
+		})
 	}
+	reg.putAttrDefs(added...)
 	return err
 }
 
-// WriteCell is the write analog of ReadCell.
-func WriteCell(ctx AppContext, subKey string, schema *AttrSchema, srcStruct any) error {
-
-	src := reflect.Indirect(reflect.ValueOf(srcStruct))
-	switch src.Kind() {
-	case reflect.Pointer:
-		src = src.Elem()
-	case reflect.Struct:
-	default:
-		return ErrCode_ExportErr.Errorf("expected struct, got %v", src.Kind())
-	}
-
-	{
-		tx := NewMsgBatch()
-		msg := tx.AddMsg()
-		msg.Op = MsgOp_UpsertCell
-		msg.ValType = ValType_SchemaID.Ord()
-		msg.ValInt = int64(schema.SchemaID)
-		msg.ValBuf = append(append(msg.ValBuf[:0], []byte(ctx.StateScope())...), []byte(subKey)...)
-
-		numFields := src.NumField()
-		valType := src.Type()
-
-		for _, attr := range schema.Attrs {
-			msg := tx.AddMsg()
-			msg.Op = MsgOp_PushAttr
-			msg.TagSpecID = attr.TagSpecID
-			for i := 0; i < numFields; i++ {
-				if valType.Field(i).Name == attr.TypedName {
-					msg.setVal(src.Field(i).Interface())
-					break
-				}
+// ValidateApps verifies the app dependency graph of the given Registry, returning ErrCode_DependencyFailed
+// if any app depends on an app that is not registered or if any dependency cycle exists.
+//
+// A host calls this after all its apps are registered and before it starts serving sessions.
+func ValidateApps(reg Registry) error {
+	apps := reg.Apps()
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].AppSpec.Canonic < apps[j].AppSpec.Canonic
+	})
+
+	var problems []string
+	deps := make(map[tag.ID][]tag.ID, len(apps))
+	names := make(map[tag.ID]string, len(apps))
+	for _, app := range apps {
+		appTag := app.AppSpec.ID
+		names[appTag] = app.AppSpec.Canonic
+		for _, dep := range app.Dependencies {
+			if _, err := reg.GetAppByTag(dep); err != nil {
+				problems = append(problems, fmt.Sprintf("%q %s depends on missing app %s", app.AppSpec.Canonic, app.Version, dep))
+				continue
 			}
-			if msg.ValType == ValType_nil.Ord() {
-				panic("missing field")
+			if !slices.Contains(deps[appTag], dep) {
+				deps[appTag] = append(deps[appTag], dep)
 			}
 		}
+	}
 
-		msg = tx.AddMsg()
-		msg.Op = MsgOp_Commit
-
-		if err := ctx.LoginInfo().HomePlanet().PushTx(tx); err != nil {
-			return err
+	// depth-first search for back edges, reporting each cycle once
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[tag.ID]int, len(apps))
+	var path []tag.ID
+	var visit func(appTag tag.ID)
+	visit = func(appTag tag.ID) {
+		state[appTag] = visiting
+		path = append(path, appTag)
+		for _, dep := range deps[appTag] {
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				cycle := []string{}
+				for i := len(path) - 1; i >= 0; i-- {
+					cycle = append(cycle, names[path[i]])
+					if path[i] == dep {
+						break
+					}
+				}
+				for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+				problems = append(problems, "dependency cycle: "+strings.Join(append(cycle, names[dep]), " -> "))
+			}
+		}
+		path = path[:len(path)-1]
+		state[appTag] = visited
+	}
+	for _, app := range apps {
+		if state[app.AppSpec.ID] == unvisited {
+			visit(app.AppSpec.ID)
 		}
 	}
 
+	if len(problems) > 0 {
+		return ErrCode_DependencyFailed.Errorf("ValidateApps: %s", strings.Join(problems, "; "))
+	}
 	return nil
 }
-*/
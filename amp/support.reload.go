@@ -0,0 +1,100 @@
+package amp
+
+import (
+	"time"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+// ReloadApp hot-swaps an app within a running session:
+//  1. the session's registry entry for app.AppSpec is replaced via ReplaceApp(),
+//  2. the session's running instance of the app (if any) is closed and drained, and
+//  3. if an instance was running, a new instance is started from the given app and the client is sent an AppReloaded meta attr.
+//
+// A host updating a plugin calls ReplaceApp() on its HostRegistry() and then ReloadApp() for each of its sessions.
+//
+// Draining closes the instance's task.Context, which closes all its Pins and invokes AppInstance.OnClosing().
+// If the instance is not done within drainTimeout (or 0 for no limit), ErrCode_Timeout is returned and no new instance is started,
+// where drainTimeout is measured by the session's Info.Clock.
+func ReloadApp(sess Session, app *App, drainTimeout time.Duration) error {
+	prev, err := sess.ReplaceApp(app)
+	if err != nil {
+		return err
+	}
+
+	drained, err := drainAppInstance(sess, app.AppSpec.ID, drainTimeout)
+	if err != nil || !drained {
+		return err
+	}
+
+	if _, err = sess.GetAppInstance(app.AppSpec.ID, true); err != nil {
+		return err
+	}
+
+	reloaded := &AppReloaded{
+		Spec:    specToTag(app.AppSpec),
+		Version: app.Version,
+	}
+	if prev != nil {
+		reloaded.PrevVersion = prev.Version
+	}
+	return SendMetaAttr(sess, tag.ID{}, OpStatus_Synced, reloaded.TagSpec().ID, reloaded)
+}
+
+// UnloadApp drains the session's running instance of the given app (see ReloadApp) and then unregisters the app from the session.
+// If an instance was running, the client is sent an AppReloaded meta attr having an empty Version.
+func UnloadApp(sess Session, appTag tag.ID, drainTimeout time.Duration) error {
+	app, err := sess.GetAppByTag(appTag)
+	if err != nil {
+		return err
+	}
+
+	drained, err := drainAppInstance(sess, appTag, drainTimeout)
+	if err != nil {
+		return err
+	}
+	if err = sess.UnregisterApp(appTag); err != nil {
+		return err
+	}
+	if !drained {
+		return nil
+	}
+
+	reloaded := &AppReloaded{
+		Spec:        specToTag(app.AppSpec),
+		PrevVersion: app.Version,
+	}
+	return SendMetaAttr(sess, tag.ID{}, OpStatus_Synced, reloaded.TagSpec().ID, reloaded)
+}
+
+// drainAppInstance closes the session's running instance of the given app and blocks until it is done.
+// Returns false if no instance was running.
+func drainAppInstance(sess Session, appTag tag.ID, timeout time.Duration) (bool, error) {
+	inst, err := sess.GetAppInstance(appTag, false)
+	if err != nil || inst == nil {
+		return false, err
+	}
+
+	inst.Close()
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		clk := sess.Info().Clock
+		if clk == nil {
+			clk = clock.System
+		}
+		timer := clk.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C()
+	}
+
+	select {
+	case <-inst.Done():
+		return true, nil
+	case <-expired:
+		return true, ErrCode_Timeout.Errorf("app %s did not drain within %v", appTag, timeout)
+	case <-sess.Closing():
+		return true, ErrShuttingDown
+	}
+}
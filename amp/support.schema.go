@@ -0,0 +1,119 @@
+package amp
+
+import (
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+// ExportSchema returns a RegisterDefs describing every app and attr def known to the given Registry, sorted by canonic spec.
+//
+// The result is suitable to send to a client as a meta attr (see SendRegisterDefs) or to write as a JSON schema document (see ExportSchemaJSON).
+func ExportSchema(reg Registry) *RegisterDefs {
+	attrDefs := reg.AttrDefs()
+	sort.Slice(attrDefs, func(i, j int) bool {
+		return attrDefs[i].Canonic < attrDefs[j].Canonic
+	})
+
+	apps := reg.Apps()
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].AppSpec.Canonic < apps[j].AppSpec.Canonic
+	})
+
+	defs := &RegisterDefs{
+		Attrs: make([]*AttrSchema, 0, len(attrDefs)),
+		Apps:  make([]*AppSchema, 0, len(apps)),
+	}
+
+	for _, def := range attrDefs {
+		defs.Attrs = append(defs.Attrs, &AttrSchema{
+			Spec:     specToTag(def.Spec),
+			TypeName: ElementTypeName(def.Prototype),
+			GoType:   reflect.TypeOf(def.Prototype).String(),
+			Fields:   ElementFields(def.Prototype),
+		})
+	}
+
+	for _, app := range apps {
+		appSchema := &AppSchema{
+			Spec:         specToTag(app.AppSpec),
+			Desc:         app.Desc,
+			Version:      app.Version,
+			Invocations:  app.Invocations,
+			ContentTypes: app.ContentTypes,
+		}
+		for _, dep := range app.Dependencies {
+			depTag := &Tag{}
+			depTag.SetTagID(dep)
+			appSchema.Dependencies = append(appSchema.Dependencies, depTag)
+		}
+		defs.Apps = append(defs.Apps, appSchema)
+	}
+
+	return defs
+}
+
+// ExportSchemaJSON writes ExportSchema() as a JSON document using the canonic protobuf JSON mapping,
+// allowing non-Go clients to parse it directly into their generated RegisterDefs type.
+func ExportSchemaJSON(reg Registry, w io.Writer) error {
+	m := jsonpb.Marshaler{
+		OrigName: true,
+		Indent:   "  ",
+	}
+	return m.Marshal(w, ExportSchema(reg))
+}
+
+// SendRegisterDefs sends ExportSchema() of the given session's Registry to its client as a meta attr.
+func SendRegisterDefs(sess Session, context tag.ID) error {
+	return SendMetaAttr(sess, context, OpStatus_Synced, tag.ID{}, ExportSchema(sess))
+}
+
+// ElementTypeName returns the proto message name of an element type (e.g. "amp.Tag") or its Go type name if it is not a registered proto message.
+func ElementTypeName(val tag.Value) string {
+	if msg, ok := val.(proto.Message); ok {
+		if name := proto.MessageName(msg); name != "" {
+			return name
+		}
+	}
+	typeOf := reflect.TypeOf(val)
+	if typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+	return typeOf.String()
+}
+
+// ElementFields returns the proto field schema of an element type, or nil if its descriptor is not available.
+func ElementFields(val tag.Value) []*FieldSchema {
+	msg, ok := val.(descriptor.Message)
+	if !ok {
+		return nil
+	}
+
+	_, desc := descriptor.ForMessage(msg)
+	fields := make([]*FieldSchema, 0, len(desc.Field))
+	for _, fi := range desc.Field {
+		fields = append(fields, &FieldSchema{
+			Name:     fi.GetName(),
+			Number:   fi.GetNumber(),
+			Type:     strings.ToLower(strings.TrimPrefix(fi.GetType().String(), "TYPE_")),
+			TypeName: strings.TrimPrefix(fi.GetTypeName(), "."),
+			Repeated: fi.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED,
+		})
+	}
+	return fields
+}
+
+func specToTag(spec tag.Spec) *Tag {
+	specTag := &Tag{
+		UID: spec.Canonic,
+	}
+	specTag.SetTagID(spec.ID)
+	return specTag
+}
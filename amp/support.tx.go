@@ -8,6 +8,7 @@ import (
 	"sync/atomic"
 
 	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/task"
 )
 
 // TxDataStore is a message packet sent to / from a client.
@@ -26,6 +27,20 @@ func (header TxHeader) TxDataLen() int {
 	return int(binary.LittleEndian.Uint32(header[8:12]))
 }
 
+func (header TxHeader) validate() error {
+	marker := uint32(header[0])<<16 | uint32(header[1])<<8 | uint32(header[2])
+	if marker != uint32(Const_TxHeader_Marker) {
+		return ErrMalformedTx
+	}
+	if header[3] < byte(Const_TxHeader_Version) {
+		return ErrMalformedTx
+	}
+	if header.TxBodyLen() < int(Const_TxHeader_Size) {
+		return ErrMalformedTx
+	}
+	return nil
+}
+
 func NewTxMsg(genesis bool) *TxMsg {
 	tx := gTxMsgPool.Get().(*TxMsg)
 	tx.refCount = 1
@@ -117,6 +132,8 @@ func (tx *TxMsg) UnmarshalOpValue(idx int, out tag.Value) error {
 	return out.Unmarshal(span)
 }
 
+// LoadItem unmarshals the first op having the given attr and item ID into dst, regardless of its CellID.
+// To load an element of a specific cell, see Load() or LoadAs().
 func (tx *TxMsg) LoadItem(attrID, itemID tag.ID, dst tag.Value) error {
 	for i, op := range tx.Ops {
 		if op.AttrID == attrID && op.ItemID == itemID {
@@ -126,7 +143,21 @@ func (tx *TxMsg) LoadItem(attrID, itemID tag.ID, dst tag.Value) error {
 	return ErrAttrNotFound
 }
 
+// Load unmarshals the latest revision of the given element into dst -- implements ElementLoader.
 func (tx *TxMsg) Load(cellID, attrID, itemID tag.ID, dst tag.Value) error {
+	idx, found := tx.findElement(cellID, attrID, itemID)
+	if !found || tx.Ops[idx].OpCode == TxOpCode_DeleteElement {
+		return ErrPropertyNotFound
+	}
+	if tx.Ops[idx].OpCode == TxOpCode_UpsertDelta {
+		return ErrUnresolvedDelta
+	}
+
+	return tx.UnmarshalOpValue(idx, dst)
+}
+
+// findElement returns the index of the op with the highest EditID for the given element, sorting ops if needed.
+func (tx *TxMsg) findElement(cellID, attrID, itemID tag.ID) (int, bool) {
 	tx.sortOps()
 
 	find := &TxOpID{
@@ -135,13 +166,17 @@ func (tx *TxMsg) Load(cellID, attrID, itemID tag.ID, dst tag.Value) error {
 		ItemID: itemID,
 	}
 	idx, found := sort.Find(len(tx.Ops), func(i int) int {
-		return tx.Ops[i].CompareTo(find)
+		return find.CompareElement(&tx.Ops[i].TxOpID)
 	})
 	if !found {
-		return ErrPropertyNotFound
+		return -1, false
 	}
 
-	return tx.UnmarshalOpValue(idx, dst)
+	// ops are stably sorted by EditID within an element, so the last match is the latest revision
+	for idx+1 < len(tx.Ops) && find.CompareElement(&tx.Ops[idx+1].TxOpID) == 0 {
+		idx++
+	}
+	return idx, true
 }
 
 var (
@@ -149,15 +184,32 @@ var (
 	ErrPropertyNotFound = ErrCode_BadRequest.Error("property not found")
 )
 
+// sortOps sorts ops by TxOpID, where ops having the same TxOpID (such as an upsert and delete of an element in the same tx)
+// retain the order in which they were added so the last is the latest.
 func (tx *TxMsg) sortOps() {
 	if !tx.OpsSorted {
 		tx.OpsSorted = true
-		sort.Slice(tx.Ops, func(i, j int) bool {
+		sort.SliceStable(tx.Ops, func(i, j int) bool {
 			return tx.Ops[i].TxOpID.CompareTo(&tx.Ops[j].TxOpID) < 0
 		})
 	}
 }
 
+// Names of the task.Metrics series recorded for the txs sent on behalf of a task.Context -- see RecordTxSent().
+const (
+	MetricTxSent  = "tx_sent"  // counter: txs sent
+	MetricTxBytes = "tx_bytes" // counter: size of txs sent, as measured for flow control (see TxScheduler)
+)
+
+// RecordTxSent counts the given tx in the task.Metrics of the given Context (or of its nearest ancestor having Metrics).
+// Call before sending the tx since a sent tx should not be referenced further.
+func RecordTxSent(ctx task.Context, tx *TxMsg) {
+	if m := task.MetricsOf(ctx); m != nil {
+		m.Counter(MetricTxSent).Add(1)
+		m.Counter(MetricTxBytes).Add(float64(flowCost(tx)))
+	}
+}
+
 // If reqID == 0, then this sends an attr to the client's session controller (vs a specific request)
 func SendMetaAttr(sess Session, context tag.ID, status OpStatus, attrID tag.ID, val tag.Value) error {
 	tx, err := MarshalAttr(MetaNodeID, attrID, val)
@@ -166,6 +218,8 @@ func SendMetaAttr(sess Session, context tag.ID, status OpStatus, attrID tag.ID,
 	}
 	tx.SetContextID(context)
 	tx.Status = status
+	tx.Priority = TxPriority_Control
+	RecordTxSent(sess, tx)
 	return sess.SendTx(tx)
 }
 
@@ -199,6 +253,18 @@ func (tx *TxMsg) Upsert(cellID, attrID, itemID tag.ID, val tag.Value) error {
 	return tx.MarshalOp(&op, val)
 }
 
+// Delete appends an op that deletes the given element.
+func (tx *TxMsg) Delete(cellID, attrID, itemID tag.ID) error {
+	op := TxOp{}
+	op.OpCode = TxOpCode_DeleteElement
+	op.CellID = cellID
+	op.AttrID = attrID
+	op.ItemID = itemID
+	op.EditID = tag.Genesis(tx.GenesisID())
+
+	return tx.MarshalOp(&op, nil)
+}
+
 // Marshals a TxOp and optional value to the given Tx's to and data store.
 //
 // On success:
@@ -249,12 +315,8 @@ func ReadTxMsg(stream io.Reader) (*TxMsg, error) {
 		return nil, err
 	}
 
-	marker := uint32(header[0])<<16 | uint32(header[1])<<8 | uint32(header[2])
-	if marker != uint32(Const_TxHeader_Marker) {
-		return nil, ErrMalformedTx
-	}
-	if header[3] < byte(Const_TxHeader_Version) {
-		return nil, ErrMalformedTx
+	if err := header.validate(); err != nil {
+		return nil, err
 	}
 
 	tx := NewTxMsg(false)
@@ -398,99 +460,138 @@ func (tx *TxMsg) MarshalOps(dst []byte) []byte {
 }
 
 func (tx *TxMsg) UnmarshalBody(src []byte) error {
-	p := 0
+	p, err := tx.TxEnvelope.unmarshalFromBody(src)
+	if err != nil {
+		return err
+	}
+	if tx.OpCount > uint64((len(src)-p)/txOpMinSize) {
+		return ErrMalformedTx // more ops than the body can hold
+	}
 
-	// TxEnvelope
-	{
-		infoLen, n := binary.Uvarint(src[0:])
-		if n <= 0 {
-			return ErrMalformedTx
+	var dec txOpDecoder
+	for i := uint64(0); i < tx.OpCount; i++ {
+		var op TxOp
+		if p, err = dec.decodeOp(src, p, &op); err != nil {
+			return err
 		}
-		p += n
+		tx.Ops = append(tx.Ops, op)
+	}
 
-		tx.TxEnvelope = TxEnvelope{}
-		err := tx.TxEnvelope.Unmarshal(src[p : p+int(infoLen)])
-		if err != nil {
-			return ErrMalformedTx
-		}
-		p += int(infoLen)
+	return nil
+}
+
+// unmarshalFromBody reads the length-prefixed TxEnvelope leading a tx body, returning the position of the first op.
+func (tx *TxEnvelope) unmarshalFromBody(src []byte) (int, error) {
+	infoLen, n := binary.Uvarint(src)
+	if n <= 0 || infoLen > uint64(len(src)-n) {
+		return 0, ErrMalformedTx
 	}
 
-	var (
-		op_cur [TxField_MaxFields]uint64
-	)
+	*tx = TxEnvelope{}
+	if err := tx.Unmarshal(src[n : n+int(infoLen)]); err != nil {
+		return 0, ErrMalformedTx
+	}
+	return n + int(infoLen), nil
+}
 
-	for i := uint64(0); i < tx.OpCount; i++ {
-		var op TxOp
-		var n int
+// txOpMinSize is the fewest bytes a serialized TxOp can occupy: five single-byte uvarints -- see decodeOp()
+const txOpMinSize = 5
 
-		// skip (future use)
-		var skip uint64
-		if skip, n = binary.Uvarint(src[p:]); n <= 0 {
-			return ErrMalformedTx
-		}
-		p += n + int(skip)
+// txOpDecoder decodes successive TxOps from a tx body.
+// Since each op only encodes the ID fields that differ from the previous op, the decoder carries the running field state.
+type txOpDecoder struct {
+	fields [TxField_MaxFields]uint64
+}
 
-		// OpCode
-		var opCode uint64
-		if opCode, n = binary.Uvarint(src[p:]); n <= 0 {
-			return ErrMalformedTx
-		}
-		p += n
-		op.OpCode = TxOpCode(opCode)
+// decodeOp decodes the op at src[p:] into op, returning the position of the following op.
+func (dec *txOpDecoder) decodeOp(src []byte, p int, op *TxOp) (int, error) {
+	var (
+		skip, opCode, hasFields uint64
+		ok                      bool
+	)
 
-		// DataLen
-		if op.DataLen, n = binary.Uvarint(src[p:]); n <= 0 {
-			return ErrMalformedTx
-		}
-		p += n
+	// skip (future use)
+	if skip, p, ok = readUvarint(src, p); !ok {
+		return 0, ErrMalformedTx
+	}
+	if skip > uint64(len(src)-p) {
+		return 0, ErrMalformedTx
+	}
+	p += int(skip)
 
-		// DataOfs
-		if op.DataOfs, n = binary.Uvarint(src[p:]); n <= 0 {
-			return ErrMalformedTx
-		}
-		p += n
+	if opCode, p, ok = readUvarint(src, p); !ok {
+		return 0, ErrMalformedTx
+	}
+	op.OpCode = TxOpCode(opCode)
 
-		// hasFields
-		var hasFields uint64
-		if hasFields, n = binary.Uvarint(src[p:]); n <= 0 {
-			return ErrMalformedTx
-		}
-		p += n
+	if op.DataLen, p, ok = readUvarint(src, p); !ok {
+		return 0, ErrMalformedTx
+	}
+	if op.DataOfs, p, ok = readUvarint(src, p); !ok {
+		return 0, ErrMalformedTx
+	}
+	if hasFields, p, ok = readUvarint(src, p); !ok {
+		return 0, ErrMalformedTx
+	}
 
-		for i := 0; i < int(TxField_MaxFields); i++ {
-			if hasFields&(1<<i) != 0 {
-				if p+8 > len(src) {
-					return ErrMalformedTx
-				}
-				op_cur[i] = binary.LittleEndian.Uint64(src[p:])
-				p += 8
+	cur := &dec.fields
+	for i := 0; hasFields != 0 && i < int(TxField_MaxFields); i++ {
+		if hasFields&1 != 0 {
+			if p+8 > len(src) {
+				return 0, ErrMalformedTx
 			}
+			cur[i] = binary.LittleEndian.Uint64(src[p:])
+			p += 8
 		}
+		hasFields >>= 1
+	}
 
-		op.CellID[0] = op_cur[TxField_CellID_0]
-		op.CellID[1] = op_cur[TxField_CellID_1]
-		op.CellID[2] = op_cur[TxField_CellID_2]
+	op.CellID[0] = cur[TxField_CellID_0]
+	op.CellID[1] = cur[TxField_CellID_1]
+	op.CellID[2] = cur[TxField_CellID_2]
 
-		op.AttrID[0] = op_cur[TxField_AttrID_0]
-		op.AttrID[1] = op_cur[TxField_AttrID_1]
-		op.AttrID[2] = op_cur[TxField_AttrID_2]
+	op.AttrID[0] = cur[TxField_AttrID_0]
+	op.AttrID[1] = cur[TxField_AttrID_1]
+	op.AttrID[2] = cur[TxField_AttrID_2]
 
-		op.ItemID[0] = op_cur[TxField_ItemID_0]
-		op.ItemID[1] = op_cur[TxField_ItemID_1]
-		op.ItemID[2] = op_cur[TxField_ItemID_2]
+	op.ItemID[0] = cur[TxField_ItemID_0]
+	op.ItemID[1] = cur[TxField_ItemID_1]
+	op.ItemID[2] = cur[TxField_ItemID_2]
 
-		op.EditID[0] = op_cur[TxField_EditID_0]
-		op.EditID[1] = op_cur[TxField_EditID_1]
-		op.EditID[2] = op_cur[TxField_EditID_2]
+	op.EditID[0] = cur[TxField_EditID_0]
+	op.EditID[1] = cur[TxField_EditID_1]
+	op.EditID[2] = cur[TxField_EditID_2]
 
-		tx.Ops = append(tx.Ops, op)
-	}
+	return p, nil
+}
 
-	return nil
+// readUvarint reads the uvarint at src[p:], returning the value and the position following it.
+func readUvarint(src []byte, p int) (uint64, int, bool) {
+	if p < 0 || p >= len(src) {
+		return 0, p, false
+	}
+	if b := src[p]; b < 0x80 { // fast path for single byte values
+		return uint64(b), p + 1, true
+	}
+	val, n := binary.Uvarint(src[p:])
+	if n <= 0 {
+		return 0, p, false
+	}
+	return val, p + n, true
 }
 
 func (op *TxOpID) CompareTo(oth *TxOpID) int {
+	if diff := op.CompareElement(oth); diff != 0 {
+		return diff
+	}
+	if diff := op.EditID.CompareTo(oth.EditID); diff != 0 {
+		return int(diff)
+	}
+	return 0
+}
+
+// CompareElement is CompareTo() but ignores EditID, comparing only the ElementID fields.
+func (op *TxOpID) CompareElement(oth *TxOpID) int {
 	if diff := op.CellID.CompareTo(oth.CellID); diff != 0 {
 		return int(diff)
 	}
@@ -500,8 +601,5 @@ func (op *TxOpID) CompareTo(oth *TxOpID) int {
 	if diff := op.ItemID.CompareTo(oth.ItemID); diff != 0 {
 		return int(diff)
 	}
-	if diff := op.EditID.CompareTo(oth.EditID); diff != 0 {
-		return int(diff)
-	}
 	return 0
 }
@@ -0,0 +1,333 @@
+package amp
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+// txOpMaxHeaderSize is the most bytes a serialized TxOp can occupy, excluding its value data -- see MarshalOps()
+const txOpMaxHeaderSize = 5*binary.MaxVarintLen64 + 12*8
+
+// Merge appends the ops of src to tx, rebasing each op's DataOfs into tx.DataStore.
+// tx.Status is set to src.Status since src is presumed to be later in time.
+// Both txs must have the same ContextID.
+//
+// Superseded ops are not removed -- see Compact()
+func (tx *TxMsg) Merge(src *TxMsg) error {
+	if src.ContextID() != tx.ContextID() {
+		return ErrCode_BadValue.Errorf("Merge: context ID mismatch: %v != %v", src.ContextID(), tx.ContextID())
+	}
+
+	base := uint64(len(tx.DataStore))
+	tx.DataStore = append(tx.DataStore, src.DataStore...)
+	for _, op := range src.Ops {
+		if op.DataLen > 0 {
+			op.DataOfs += base
+		}
+		tx.Ops = append(tx.Ops, op)
+	}
+	tx.OpCount = uint64(len(tx.Ops))
+	tx.OpsSorted = tx.OpsSorted && len(src.Ops) == 0
+	tx.Status = src.Status
+	return nil
+}
+
+// Compact removes ops superseded by a later revision of the same element, keeping the op having the highest EditID
+// (or the later op if EditIDs are equal), and then rebuilds DataStore to hold only data referenced by remaining ops.
+// The relative order of remaining ops is preserved.
+//
+// Elements having a TxOpCode_UpsertDelta op are left as is since each delta depends on the revision preceding it.
+func (tx *TxMsg) Compact() {
+	const keepAll = -1
+
+	latest := make(map[ElementID]int, len(tx.Ops))
+	for i := range tx.Ops {
+		op := &tx.Ops[i]
+		elemID := ElementID{op.CellID, op.AttrID, op.ItemID}
+		prev, exists := latest[elemID]
+		switch {
+		case prev == keepAll && exists:
+		case op.OpCode == TxOpCode_UpsertDelta:
+			latest[elemID] = keepAll
+		case !exists || op.EditID.CompareTo(tx.Ops[prev].EditID) >= 0:
+			latest[elemID] = i
+		}
+	}
+	if len(latest) == len(tx.Ops) {
+		return
+	}
+
+	data := make([]byte, 0, len(tx.DataStore))
+	ops := tx.Ops[:0]
+	for i, op := range tx.Ops {
+		if idx := latest[ElementID{op.CellID, op.AttrID, op.ItemID}]; idx != i && idx != keepAll {
+			continue
+		}
+		if op.DataLen > 0 {
+			ofs := uint64(len(data))
+			data = append(data, tx.DataStore[op.DataOfs:op.DataOfs+op.DataLen]...)
+			op.DataOfs = ofs
+		}
+		ops = append(ops, op)
+	}
+	tx.Ops = ops
+	tx.OpCount = uint64(len(ops))
+	tx.DataStore = data
+}
+
+// Split partitions tx into txs having at most maxOps ops and a serialized size of at most maxBytes (where <= 0 means no limit).
+// Ops of the same cell are kept in the same part unless the cell alone exceeds the limits.
+// Each part has the envelope of tx, except that every part but the last has Status OpStatus_Syncing.
+//
+// If no split is needed, tx itself is returned; otherwise tx is left intact and the caller retains ownership of it.
+// ErrCode_BadValue is returned if a single op exceeds maxBytes.
+func (tx *TxMsg) Split(maxOps, maxBytes int) ([]*TxMsg, error) {
+	envelopeSize := int(Const_TxHeader_Size) + binary.MaxVarintLen64 + tx.TxEnvelope.Size()
+	fits := func(numOps, numBytes int) bool {
+		return (maxOps <= 0 || numOps <= maxOps) && (maxBytes <= 0 || envelopeSize+numBytes <= maxBytes)
+	}
+
+	opSize := func(op *TxOp) int {
+		return txOpMaxHeaderSize + int(op.DataLen)
+	}
+
+	totalBytes := 0
+	for i := range tx.Ops {
+		sz := opSize(&tx.Ops[i])
+		if !fits(1, sz) {
+			return nil, ErrCode_BadValue.Errorf("Split: op of %d bytes exceeds max of %d bytes", sz, maxBytes)
+		}
+		totalBytes += sz
+	}
+	if fits(len(tx.Ops), totalBytes) {
+		return []*TxMsg{tx}, nil
+	}
+
+	// group ops by cell, in order of each cell's first appearance
+	var cells [][]int
+	cellIndex := make(map[tag.ID]int)
+	for i := range tx.Ops {
+		cellID := tx.Ops[i].CellID
+		idx, exists := cellIndex[cellID]
+		if !exists {
+			idx = len(cells)
+			cellIndex[cellID] = idx
+			cells = append(cells, nil)
+		}
+		cells[idx] = append(cells[idx], i)
+	}
+
+	var (
+		parts     []*TxMsg
+		part      *TxMsg
+		partBytes int
+	)
+	startPart := func() {
+		part = NewTxMsg(false)
+		part.TxEnvelope = tx.TxEnvelope
+		part.OpsSorted = tx.OpsSorted
+		partBytes = 0
+		parts = append(parts, part)
+	}
+	addOp := func(op TxOp) {
+		if op.DataLen > 0 {
+			ofs := uint64(len(part.DataStore))
+			part.DataStore = append(part.DataStore, tx.DataStore[op.DataOfs:op.DataOfs+op.DataLen]...)
+			op.DataOfs = ofs
+		}
+		part.Ops = append(part.Ops, op)
+		part.OpCount = uint64(len(part.Ops))
+		partBytes += opSize(&op)
+	}
+
+	for _, cell := range cells {
+		cellBytes := 0
+		for _, i := range cell {
+			cellBytes += opSize(&tx.Ops[i])
+		}
+
+		// start a new part if this cell doesn't fit in the current part but would fit in an empty one
+		if part == nil || (!fits(len(part.Ops)+len(cell), partBytes+cellBytes) && len(part.Ops) > 0) {
+			startPart()
+		}
+
+		for _, i := range cell {
+			op := &tx.Ops[i]
+			if !fits(len(part.Ops)+1, partBytes+opSize(op)) {
+				startPart()
+			}
+			addOp(*op)
+		}
+	}
+
+	for _, p := range parts[:len(parts)-1] {
+		p.Status = OpStatus_Syncing
+	}
+	return parts, nil
+}
+
+// BatchOpts specifies how a batching Transport coalesces and splits outgoing txs -- see NewBatchingTransport()
+type BatchOpts struct {
+	Latency  time.Duration // max time an outgoing tx is held so that later txs for the same context can be merged into it
+	MaxOps   int           // max ops per sent tx (or 0 for no limit)
+	MaxBytes int           // max serialized size of a sent tx, typically the underlying transport's max frame size (or 0 for no limit)
+	Clock    clock.Clock   // measures Latency (or nil for clock.System)
+}
+
+// NewBatchingTransport wraps a Transport so that outgoing txs having the same ContextID are merged and compacted
+// for up to opts.Latency and then split to respect opts.MaxOps and opts.MaxBytes.
+//
+// A tx is sent without delay if it closes its context (OpStatus_Closed) or if its context's pending ops reach the limits.
+// Since sends to the underlying Transport may then be deferred, an error from it is returned by a subsequent SendTx() or Close().
+func NewBatchingTransport(via Transport, opts BatchOpts) Transport {
+	if opts.Clock == nil {
+		opts.Clock = clock.System
+	}
+	return &batchingTransport{
+		via:     via,
+		opts:    opts,
+		pending: make(map[tag.ID]*TxMsg),
+	}
+}
+
+type batchingTransport struct {
+	via  Transport
+	opts BatchOpts
+
+	sendMu sync.Mutex // serializes sends to via so that order is preserved
+
+	mu      sync.Mutex
+	pending map[tag.ID]*TxMsg // pending tx by context ID
+	order   []tag.ID          // context IDs in order of first pending tx
+	timer   clock.Timer       // set while txs are pending
+	stopped chan struct{}     // closed when timer is stopped
+	err     error             // first error from via.SendTx()
+}
+
+func (bt *batchingTransport) Label() string {
+	return bt.via.Label()
+}
+
+func (bt *batchingTransport) RecvTx() (*TxMsg, error) {
+	return bt.via.RecvTx()
+}
+
+func (bt *batchingTransport) SendTx(tx *TxMsg) error {
+	contextID := tx.ContextID()
+
+	bt.mu.Lock()
+	if err := bt.err; err != nil {
+		bt.mu.Unlock()
+		tx.ReleaseRef()
+		return err
+	}
+
+	// txs are merged into a batch owned by this transport since a caller may still hold a reference to a tx it sends
+	batch := bt.pending[contextID]
+	if batch == nil {
+		batch = NewTxMsg(false)
+		batch.TxEnvelope = tx.TxEnvelope
+		batch.OpsSorted = tx.OpsSorted
+		batch.Ops = append(batch.Ops, tx.Ops...)
+		batch.OpCount = uint64(len(batch.Ops))
+		batch.DataStore = append(batch.DataStore, tx.DataStore...)
+		bt.pending[contextID] = batch
+		bt.order = append(bt.order, contextID)
+	} else {
+		batch.Merge(tx) // same context ID by construction
+	}
+	tx.ReleaseRef()
+
+	flushNow := batch.Status == OpStatus_Closed || bt.opts.Latency <= 0 ||
+		(bt.opts.MaxOps > 0 && len(batch.Ops) >= bt.opts.MaxOps) ||
+		(bt.opts.MaxBytes > 0 && len(batch.DataStore)+len(batch.Ops)*txOpMaxHeaderSize >= bt.opts.MaxBytes)
+
+	if !flushNow && bt.timer == nil {
+		timer, stopped := bt.opts.Clock.NewTimer(bt.opts.Latency), make(chan struct{})
+		bt.timer, bt.stopped = timer, stopped
+		go func() {
+			select {
+			case <-timer.C():
+				bt.flush()
+			case <-stopped:
+			}
+		}()
+	}
+	bt.mu.Unlock()
+
+	if flushNow {
+		return bt.flush()
+	}
+	return nil
+}
+
+// flush sends all pending txs in order, returning the first send error encountered.
+func (bt *batchingTransport) flush() error {
+	bt.sendMu.Lock()
+	defer bt.sendMu.Unlock()
+
+	bt.mu.Lock()
+	order := bt.order
+	pending := bt.pending
+	bt.order = nil
+	bt.pending = make(map[tag.ID]*TxMsg, len(pending))
+	if bt.timer != nil {
+		bt.timer.Stop()
+		close(bt.stopped)
+		bt.timer = nil
+	}
+	bt.mu.Unlock()
+
+	var err error
+	for _, contextID := range order {
+		tx := pending[contextID]
+		if err != nil {
+			tx.ReleaseRef()
+			continue
+		}
+		err = bt.send(tx)
+	}
+
+	if err != nil {
+		bt.mu.Lock()
+		if bt.err == nil {
+			bt.err = err
+		}
+		bt.mu.Unlock()
+	}
+	return err
+}
+
+// send compacts, splits, and sends the given tx, taking ownership of it.
+func (bt *batchingTransport) send(tx *TxMsg) error {
+	tx.Compact()
+	parts, err := tx.Split(bt.opts.MaxOps, bt.opts.MaxBytes)
+	if err != nil {
+		tx.ReleaseRef()
+		return err
+	}
+	if len(parts) > 1 {
+		tx.ReleaseRef()
+	}
+
+	for _, part := range parts {
+		if err == nil {
+			err = bt.via.SendTx(part)
+		} else {
+			part.ReleaseRef() // not sent due to a previous error
+		}
+	}
+	return err
+}
+
+func (bt *batchingTransport) Close() error {
+	err := bt.flush()
+	if closeErr := bt.via.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
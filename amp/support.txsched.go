@@ -0,0 +1,358 @@
+package amp
+
+import (
+	"sync"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+// numTxPriorities is the number of TxPriority values.
+const numTxPriorities = 3
+
+// TxSchedulerOpts specifies flow control and scheduling for a TxScheduler.
+type TxSchedulerOpts struct {
+	Window    int64 // flow control window: max bytes sent beyond what the peer has granted back -- both peers must use the same Window
+	MaxQueued int64 // max bytes of pending interactive and bulk txs before SendTx() blocks
+	BulkShare int   // when both are pending, one bulk tx is sent per this many interactive txs
+}
+
+// DefaultTxSchedulerOpts is a suggested set of options.
+func DefaultTxSchedulerOpts() TxSchedulerOpts {
+	return TxSchedulerOpts{
+		Window:    4 << 20,
+		MaxQueued: 16 << 20,
+		BulkShare: 8,
+	}
+}
+
+// TxSchedulerStats is a snapshot of a TxScheduler's queues and flow control state.
+type TxSchedulerStats struct {
+	Queued      [numTxPriorities]int // pending txs, indexed by TxPriority
+	QueuedBytes int64                // flow cost of pending interactive and bulk txs
+	Contexts    int                  // number of contexts (e.g. pins) having pending interactive or bulk txs
+	Credit      int64                // bytes that may be sent before the peer grants more
+	Sent        uint64               // txs sent
+	Blocked     uint64               // SendTx() calls that blocked since MaxQueued was reached
+}
+
+// TxScheduler is a Transport placed in front of another Transport (typically a session's) that sends txs by priority and flow control:
+//   - TxPriority_Control txs are sent ahead of all others and are exempt from flow control,
+//   - interactive and bulk txs are sent while credit remains, where BulkShare keeps bulk txs from starving,
+//   - within a class, contexts (e.g. pins) are served round-robin so that one busy context does not delay others,
+//   - txs of the same context are sent in order, so a tx joins its context's pending queue regardless of its own class, and
+//   - SendTx() blocks while MaxQueued is exceeded, so a slow peer cannot cause unbounded buffering.
+//
+// Each peer must also use a TxScheduler with the same Window: as RecvTx() returns interactive and bulk txs to the caller,
+// a FlowCredit control tx is sent back to the peer, replenishing its credit.  Received FlowCredit txs are consumed by RecvTx().
+//
+// The underlying Transport must deliver txs as sent (e.g. not via a batching Transport) since both peers must agree on each tx's cost.
+// Txs pending when Close() is called are discarded.
+type TxScheduler struct {
+	via  Transport
+	opts TxSchedulerOpts
+
+	mu          sync.Mutex
+	cond        *sync.Cond               // signaled when txs are queued or dequeued, credit is granted, or closing
+	control     []*TxMsg                 // pending control txs
+	classes     [numTxPriorities]txClass // pending interactive and bulk txs
+	byContext   map[tag.ID]*txQueue      // pending queue of each context
+	stats       TxSchedulerStats         // Queued and QueuedBytes are maintained in place
+	unacked     int64                    // bytes received but not yet granted back to the peer
+	bulkWait    int                      // interactive txs sent while bulk txs were pending
+	err         error                    // first error from via.SendTx()
+	closed      bool                     // set when closing or via.SendTx() failed
+	closing     bool                     // set once Close() is called
+	sendingDone chan struct{}            // closed when sendLoop() exits
+}
+
+// txClass holds the pending txs of a TxPriority, served round-robin by context.
+type txClass struct {
+	ring []*txQueue
+	next int
+}
+
+// txQueue holds the pending txs of a context, in the order sent.
+type txQueue struct {
+	contextID tag.ID
+	class     TxPriority
+	txs       []queuedTx
+}
+
+type queuedTx struct {
+	tx   *TxMsg
+	cost int64
+}
+
+// NewTxScheduler returns a TxScheduler sending to the given Transport.
+func NewTxScheduler(via Transport, opts TxSchedulerOpts) *TxScheduler {
+	defaults := DefaultTxSchedulerOpts()
+	if opts.Window <= 0 {
+		opts.Window = defaults.Window
+	}
+	if opts.MaxQueued <= 0 {
+		opts.MaxQueued = defaults.MaxQueued
+	}
+	if opts.BulkShare <= 0 {
+		opts.BulkShare = defaults.BulkShare
+	}
+
+	ts := &TxScheduler{
+		via:         via,
+		opts:        opts,
+		byContext:   make(map[tag.ID]*txQueue),
+		sendingDone: make(chan struct{}),
+	}
+	ts.cond = sync.NewCond(&ts.mu)
+	ts.stats.Credit = opts.Window
+	go ts.sendLoop()
+	return ts
+}
+
+// flowCost returns the flow control cost of a tx, which the sender and receiver compute identically.
+func flowCost(tx *TxMsg) int64 {
+	tx.OpCount = uint64(len(tx.Ops))
+	return int64(Const_TxHeader_Size) + int64(tx.TxEnvelope.Size()) + int64(len(tx.Ops))*16 + int64(len(tx.DataStore))
+}
+
+// Stats returns a snapshot of this scheduler's queues and flow control state.
+func (ts *TxScheduler) Stats() TxSchedulerStats {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	stats := ts.stats
+	stats.Contexts = len(ts.byContext)
+	return stats
+}
+
+func (ts *TxScheduler) Label() string {
+	return ts.via.Label()
+}
+
+// SendTx queues the given tx by its Priority, blocking while MaxQueued bytes of interactive and bulk txs are pending.
+func (ts *TxScheduler) SendTx(tx *TxMsg) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if tx.Priority == TxPriority_Control {
+		if ts.closed {
+			tx.ReleaseRef()
+			return ts.closedErr()
+		}
+		ts.control = append(ts.control, tx)
+		ts.stats.Queued[TxPriority_Control]++
+		ts.cond.Broadcast()
+		return nil
+	}
+
+	cost := flowCost(tx)
+	if !ts.closed && ts.stats.QueuedBytes > 0 && ts.stats.QueuedBytes+cost > ts.opts.MaxQueued {
+		ts.stats.Blocked++
+		for !ts.closed && ts.stats.QueuedBytes > 0 && ts.stats.QueuedBytes+cost > ts.opts.MaxQueued {
+			ts.cond.Wait()
+		}
+	}
+	if ts.closed {
+		tx.ReleaseRef()
+		return ts.closedErr()
+	}
+
+	contextID := tx.ContextID()
+	queue := ts.byContext[contextID]
+	if queue == nil {
+		queue = &txQueue{
+			contextID: contextID,
+			class:     tx.Priority,
+		}
+		if queue.class >= numTxPriorities {
+			queue.class = TxPriority_Bulk
+		}
+		ts.byContext[contextID] = queue
+		class := &ts.classes[queue.class]
+		class.ring = append(class.ring, queue)
+	}
+	queue.txs = append(queue.txs, queuedTx{tx, cost})
+	ts.stats.Queued[queue.class]++
+	ts.stats.QueuedBytes += cost
+	ts.cond.Broadcast()
+	return nil
+}
+
+// RecvTx returns the next tx from the peer, consuming FlowCredit txs and granting credit back to the peer as txs are received.
+func (ts *TxScheduler) RecvTx() (*TxMsg, error) {
+	for {
+		tx, err := ts.via.RecvTx()
+		if err != nil {
+			return nil, err
+		}
+
+		if tx.Priority == TxPriority_Control {
+			if credit, isCredit := flowCreditOf(tx); isCredit {
+				tx.ReleaseRef()
+				ts.mu.Lock()
+				ts.stats.Credit += credit
+				ts.cond.Broadcast()
+				ts.mu.Unlock()
+				continue
+			}
+			return tx, nil
+		}
+
+		var grant int64
+		ts.mu.Lock()
+		ts.unacked += flowCost(tx)
+		if ts.unacked >= ts.opts.Window/2 {
+			grant, ts.unacked = ts.unacked, 0
+		}
+		ts.mu.Unlock()
+
+		if grant > 0 {
+			if credit, err := MarshalAttr(MetaNodeID, (&FlowCredit{}).TagSpec().ID, &FlowCredit{Bytes: uint64(grant)}); err == nil {
+				credit.Priority = TxPriority_Control
+				ts.SendTx(credit) // a send error is reported by SendTx() or Close()
+			}
+		}
+		return tx, nil
+	}
+}
+
+// flowCreditOf returns the credit granted by the given tx if it is a FlowCredit tx.
+func flowCreditOf(tx *TxMsg) (int64, bool) {
+	if len(tx.Ops) != 1 || tx.Ops[0].CellID != MetaNodeID {
+		return 0, false
+	}
+	var credit FlowCredit
+	if tx.Ops[0].AttrID != credit.TagSpec().ID || tx.UnmarshalOpValue(0, &credit) != nil {
+		return 0, false
+	}
+	return int64(credit.Bytes), true
+}
+
+// Close discards pending txs and closes the underlying Transport.
+func (ts *TxScheduler) Close() error {
+	ts.mu.Lock()
+	if ts.closing {
+		ts.mu.Unlock()
+		<-ts.sendingDone
+		return nil
+	}
+	ts.closing = true
+	ts.closed = true
+	ts.cond.Broadcast()
+	ts.mu.Unlock()
+
+	err := ts.via.Close()
+	<-ts.sendingDone
+
+	ts.mu.Lock()
+	pending := ts.control
+	for _, queue := range ts.byContext {
+		for _, entry := range queue.txs {
+			pending = append(pending, entry.tx)
+		}
+	}
+	ts.control = nil
+	ts.byContext = make(map[tag.ID]*txQueue)
+	ts.classes = [numTxPriorities]txClass{}
+	ts.stats.Queued = [numTxPriorities]int{}
+	ts.stats.QueuedBytes = 0
+	ts.mu.Unlock()
+
+	for _, tx := range pending {
+		tx.ReleaseRef()
+	}
+	return err
+}
+
+// closedErr returns the error that closed this scheduler.  Caller must hold ts.mu.
+func (ts *TxScheduler) closedErr() error {
+	if ts.err != nil {
+		return ts.err
+	}
+	return ErrStreamClosed
+}
+
+// sendLoop sends txs to via in scheduled order until closed.
+func (ts *TxScheduler) sendLoop() {
+	defer close(ts.sendingDone)
+
+	for {
+		ts.mu.Lock()
+		tx := ts.nextTx()
+		ts.mu.Unlock()
+		if tx == nil {
+			return
+		}
+
+		if err := ts.via.SendTx(tx); err != nil {
+			ts.mu.Lock()
+			if ts.err == nil {
+				ts.err = err
+			}
+			ts.closed = true
+			ts.cond.Broadcast()
+			ts.mu.Unlock()
+			return
+		}
+	}
+}
+
+// nextTx blocks until a tx may be sent and dequeues it, or returns nil if closed.  Caller must hold ts.mu.
+func (ts *TxScheduler) nextTx() *TxMsg {
+	for !ts.closed {
+		if len(ts.control) > 0 {
+			tx := ts.control[0]
+			ts.control[0] = nil
+			ts.control = ts.control[1:]
+			ts.stats.Queued[TxPriority_Control]--
+			ts.stats.Sent++
+			return tx
+		}
+
+		if ts.stats.Credit > 0 {
+			interactive := &ts.classes[TxPriority_Interactive]
+			bulk := &ts.classes[TxPriority_Bulk]
+
+			var class *txClass
+			switch {
+			case len(interactive.ring) > 0 && (len(bulk.ring) == 0 || ts.bulkWait < ts.opts.BulkShare):
+				class = interactive
+				if len(bulk.ring) > 0 {
+					ts.bulkWait++
+				}
+			case len(bulk.ring) > 0:
+				class = bulk
+				ts.bulkWait = 0
+			}
+			if class != nil {
+				entry := ts.dequeue(class)
+				ts.stats.Credit -= entry.cost
+				ts.stats.QueuedBytes -= entry.cost
+				ts.stats.Sent++
+				ts.cond.Broadcast() // wake senders blocked on MaxQueued
+				return entry.tx
+			}
+		}
+
+		ts.cond.Wait()
+	}
+	return nil
+}
+
+// dequeue pops the next tx of the given class, advancing round-robin to the next context.  Caller must hold ts.mu.
+func (ts *TxScheduler) dequeue(class *txClass) queuedTx {
+	if class.next >= len(class.ring) {
+		class.next = 0
+	}
+	queue := class.ring[class.next]
+	entry := queue.txs[0]
+	queue.txs[0] = queuedTx{}
+	queue.txs = queue.txs[1:]
+	ts.stats.Queued[queue.class]--
+
+	if len(queue.txs) == 0 {
+		delete(ts.byContext, queue.contextID)
+		class.ring = append(class.ring[:class.next], class.ring[class.next+1:]...)
+	} else {
+		class.next++
+	}
+	return entry
+}
@@ -0,0 +1,289 @@
+package amp
+
+import (
+	"io"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+// TxView is a read-only view over a serialized TxMsg (see TxMsg.MarshalToBuffer) that decodes ops directly from the wire buffer.
+//
+// Unlike ReadTxMsg(), a TxView does not allocate a TxOp per op or copy the data store:
+// ops are decoded on the fly as they are iterated and a value is only unmarshalled when asked for.
+// The underlying buffer must not be modified while a TxView (or any slice it returns) is in use.
+type TxView struct {
+	TxEnvelope
+
+	body        []byte         // serialized ops
+	data        []byte         // data store
+	checkpoints []txCheckpoint // decoder state every txViewCheckpointInterval ops -- see index()
+	sorted      bool           // set if ops are in ascending TxOpID order
+	indexed     bool
+}
+
+// txViewCheckpointInterval is the number of ops between checkpoints, trading index size for linear scan length.
+const txViewCheckpointInterval = 32
+
+// txCheckpoint allows decoding to resume at an op without decoding the ops that precede it.
+type txCheckpoint struct {
+	pos int         // body position of the op
+	dec txOpDecoder // decoder state preceding the op
+	op  TxOpID      // the op's ID, used for binary search
+}
+
+// NewTxView returns a TxView over the given serialized TxMsg, which leads with a TxHeader.
+func NewTxView(buf []byte) (*TxView, error) {
+	if len(buf) < int(Const_TxHeader_Size) {
+		return nil, ErrMalformedTx
+	}
+
+	var header TxHeader
+	copy(header[:], buf)
+	if err := header.validate(); err != nil {
+		return nil, err
+	}
+
+	bodyLen := header.TxBodyLen()
+	dataLen := header.TxDataLen()
+	if bodyLen+dataLen > len(buf) {
+		return nil, ErrMalformedTx
+	}
+
+	view := &TxView{
+		body: buf[Const_TxHeader_Size:bodyLen],
+		data: buf[bodyLen : bodyLen+dataLen],
+	}
+	p, err := view.TxEnvelope.unmarshalFromBody(view.body)
+	if err != nil {
+		return nil, err
+	}
+	view.body = view.body[p:]
+	if view.TxEnvelope.OpCount > uint64(len(view.body)/txOpMinSize) {
+		return nil, ErrMalformedTx // more ops than the body can hold
+	}
+	return view, nil
+}
+
+// ReadTxView reads a serialized TxMsg from the given stream into *scrap (growing it as needed) and returns a TxView over it.
+// The returned TxView is valid until *scrap is reused.
+func ReadTxView(stream io.Reader, scrap *[]byte) (*TxView, error) {
+	var header TxHeader
+	if _, err := io.ReadFull(stream, header[:]); err != nil {
+		return nil, err
+	}
+	if err := header.validate(); err != nil {
+		return nil, err
+	}
+
+	needSz := header.TxBodyLen() + header.TxDataLen()
+	buf := *scrap
+	if cap(buf) < needSz {
+		buf = make([]byte, max(needSz, 2048))
+	}
+	buf = buf[:needSz]
+	copy(buf, header[:])
+	if _, err := io.ReadFull(stream, buf[Const_TxHeader_Size:]); err != nil {
+		return nil, err
+	}
+	*scrap = buf
+
+	return NewTxView(buf)
+}
+
+// OpCount returns the number of ops in this view.
+func (view *TxView) OpCount() int {
+	return int(view.TxEnvelope.OpCount)
+}
+
+// Ops returns an iterator over this view's ops:
+//
+//	ops := view.Ops()
+//	for ops.Next() {
+//	    op := ops.Op()
+//	    ...
+//	}
+//	if err := ops.Err(); err != nil { ... }
+func (view *TxView) Ops() TxViewIter {
+	return TxViewIter{
+		view: view,
+		idx:  -1,
+	}
+}
+
+// OpData returns the serialized value of the given op without copying.
+func (view *TxView) OpData(op *TxOp) ([]byte, error) {
+	end := op.DataOfs + op.DataLen
+	if end < op.DataOfs || end > uint64(len(view.data)) {
+		return nil, ErrMalformedTx
+	}
+	return view.data[op.DataOfs:end], nil
+}
+
+// UnmarshalOpValue unmarshals the value of the given op into out.
+func (view *TxView) UnmarshalOpValue(op *TxOp, out tag.Value) error {
+	span, err := view.OpData(op)
+	if err != nil {
+		return err
+	}
+	return out.Unmarshal(span)
+}
+
+// Sorted returns true if this view's ops are in ascending TxOpID order, in which case Load() uses binary search.
+func (view *TxView) Sorted() bool {
+	view.index()
+	return view.sorted
+}
+
+// Load unmarshals the latest revision of the given element into dst -- implements ElementLoader.
+// The first Load() indexes this view (see Sorted), so subsequent loads of a sorted view only decode a few checkpoint intervals.
+func (view *TxView) Load(cellID, attrID, itemID tag.ID, dst tag.Value) error {
+	find := TxOpID{
+		CellID: cellID,
+		AttrID: attrID,
+		ItemID: itemID,
+	}
+
+	var (
+		latest TxOp
+		found  bool
+	)
+
+	ops := view.Ops()
+	if view.Sorted() {
+		ops.seek(&find)
+	}
+	for ops.Next() {
+		op := ops.Op()
+		diff := find.CompareElement(&op.TxOpID)
+		if diff == 0 && (!found || op.EditID.CompareTo(latest.EditID) >= 0) {
+			latest = *op
+			found = true
+		} else if diff < 0 && view.sorted {
+			break
+		}
+	}
+	if err := ops.Err(); err != nil {
+		return err
+	}
+	if !found || latest.OpCode == TxOpCode_DeleteElement {
+		return ErrPropertyNotFound
+	}
+	if latest.OpCode == TxOpCode_UpsertDelta {
+		return ErrUnresolvedDelta
+	}
+	return view.UnmarshalOpValue(&latest, dst)
+}
+
+// ToTxMsg decodes this view into a new TxMsg, copying the data store.
+func (view *TxView) ToTxMsg() (*TxMsg, error) {
+	tx := NewTxMsg(false)
+	tx.TxEnvelope = view.TxEnvelope
+	tx.Ops = tx.Ops[:0]
+
+	ops := view.Ops()
+	for ops.Next() {
+		tx.Ops = append(tx.Ops, *ops.Op())
+	}
+	if err := ops.Err(); err != nil {
+		tx.ReleaseRef()
+		return nil, err
+	}
+	tx.DataStore = append(tx.DataStore[:0], view.data...)
+	return tx, nil
+}
+
+// index performs a single decoding pass to build checkpoints and detect if ops are sorted.
+func (view *TxView) index() {
+	if view.indexed {
+		return
+	}
+	view.indexed = true
+	view.sorted = true
+	view.checkpoints = make([]txCheckpoint, 0, view.OpCount()/txViewCheckpointInterval+1)
+
+	var prev TxOpID
+	ops := view.Ops()
+	for {
+		if ops.idx+1 < view.OpCount() && (ops.idx+1)%txViewCheckpointInterval == 0 {
+			view.checkpoints = append(view.checkpoints, txCheckpoint{
+				pos: ops.pos,
+				dec: ops.dec,
+			})
+		}
+		if !ops.Next() {
+			break
+		}
+		op := ops.Op()
+		if ops.idx%txViewCheckpointInterval == 0 {
+			view.checkpoints[len(view.checkpoints)-1].op = op.TxOpID
+		}
+		if ops.idx > 0 && prev.CompareTo(&op.TxOpID) > 0 {
+			view.sorted = false
+		}
+		prev = op.TxOpID
+	}
+	if ops.Err() != nil {
+		view.sorted = false
+	}
+}
+
+// TxViewIter iterates over the ops of a TxView, decoding each op in place -- see TxView.Ops()
+type TxViewIter struct {
+	view *TxView
+	pos  int // body position of the next op
+	idx  int // index of the current op
+	dec  txOpDecoder
+	op   TxOp
+	err  error
+}
+
+// Next decodes the next op, returning false when there are no more ops or a decoding error occurred.
+func (iter *TxViewIter) Next() bool {
+	if iter.err != nil || iter.idx+1 >= iter.view.OpCount() {
+		return false
+	}
+	iter.pos, iter.err = iter.dec.decodeOp(iter.view.body, iter.pos, &iter.op)
+	if iter.err != nil {
+		return false
+	}
+	iter.idx++
+	return true
+}
+
+// Op returns the current op, which is overwritten by the next call to Next().
+func (iter *TxViewIter) Op() *TxOp {
+	return &iter.op
+}
+
+// Index returns the index of the current op.
+func (iter *TxViewIter) Index() int {
+	return iter.idx
+}
+
+// Err returns the decoding error that stopped iteration, if any.
+func (iter *TxViewIter) Err() error {
+	return iter.err
+}
+
+// seek positions this iterator (of a sorted view) at the last checkpoint preceding the given element.
+func (iter *TxViewIter) seek(find *TxOpID) {
+	cps := iter.view.checkpoints
+
+	// find the first checkpoint at or following the element, then back up one since the element's ops may start before it
+	lo, hi := 0, len(cps)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if cps[mid].op.CompareElement(find) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo == 0 {
+		return
+	}
+	cp := &cps[lo-1]
+	iter.pos = cp.pos
+	iter.dec = cp.dec
+	iter.idx = (lo-1)*txViewCheckpointInterval - 1
+}
@@ -0,0 +1,173 @@
+package amp
+
+import (
+	"reflect"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+// LoadAs unmarshals the latest revision of the given element as a T -- see TxMsg.Load()
+//
+// T is the pointer type of a tag.Value, e.g.:
+//
+//	label, err := amp.LoadAs[*amp.Tag](tx, cellID, attrID, tag.ID{})
+func LoadAs[T tag.Value](tx *TxMsg, cellID, attrID, itemID tag.ID) (T, error) {
+	val, err := NewValue[T]()
+	if err == nil {
+		err = tx.Load(cellID, attrID, itemID, val)
+	}
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return val, nil
+}
+
+// NewValue allocates a new T, where T is the pointer type of a tag.Value (e.g. *amp.Tag).
+func NewValue[T tag.Value]() (T, error) {
+	var zero T
+	typeOf := reflect.TypeOf(zero)
+	if typeOf == nil || typeOf.Kind() != reflect.Ptr {
+		return zero, ErrCode_BadValue.Errorf("NewValue: %v is not a pointer type", reflect.TypeOf(&zero).Elem())
+	}
+	return reflect.New(typeOf.Elem()).Interface().(T), nil
+}
+
+// TxIter iterates over the upserted values of a TxMsg matching a cell and attr, decoding each as a T.
+// Ops are visited in the order they appear in TxMsg.Ops and delete ops are skipped.
+//
+//	iter := amp.Iter[*amp.Tag](tx, cellID, attrID)
+//	for iter.Next() {
+//	    op, val := iter.Op(), iter.Value()
+//	    ...
+//	}
+//	if err := iter.Err(); err != nil { ... }
+type TxIter[T tag.Value] struct {
+	tx     *TxMsg
+	cellID tag.ID
+	attrID tag.ID
+	idx    int
+	val    T
+	err    error
+}
+
+// Iter returns a TxIter over the given TxMsg, where a nil cellID or attrID matches any cell or attr.
+func Iter[T tag.Value](tx *TxMsg, cellID, attrID tag.ID) *TxIter[T] {
+	return &TxIter[T]{
+		tx:     tx,
+		cellID: cellID,
+		attrID: attrID,
+		idx:    -1,
+	}
+}
+
+// Next advances to the next matching op, returning false when there are no more matches or an error occurred.
+func (iter *TxIter[T]) Next() bool {
+	if iter.err != nil {
+		return false
+	}
+	ops := iter.tx.Ops
+	for iter.idx+1 < len(ops) {
+		iter.idx++
+		op := &ops[iter.idx]
+		if op.OpCode != TxOpCode_UpsertElement {
+			continue
+		}
+		if (!iter.cellID.IsNil() && op.CellID != iter.cellID) || (!iter.attrID.IsNil() && op.AttrID != iter.attrID) {
+			continue
+		}
+
+		var val T
+		if val, iter.err = NewValue[T](); iter.err == nil {
+			iter.err = iter.tx.UnmarshalOpValue(iter.idx, val)
+		}
+		if iter.err != nil {
+			return false
+		}
+		iter.val = val
+		return true
+	}
+	return false
+}
+
+// Op returns the current op.
+func (iter *TxIter[T]) Op() *TxOp {
+	return &iter.tx.Ops[iter.idx]
+}
+
+// Value returns the decoded value of the current op.
+func (iter *TxIter[T]) Value() T {
+	return iter.val
+}
+
+// Err returns the error that stopped iteration, if any.
+func (iter *TxIter[T]) Err() error {
+	return iter.err
+}
+
+// TxBuilder appends chained edits to a TxMsg, retaining the first error encountered:
+//
+//	tx, err := amp.NewTxBuilder(nil).
+//	    Upsert(cellID, tag.ID{}, tag.ID{}, &amp.Tag{Text: "hello"}).
+//	    Delete(cellID, staleAttrID, itemID).
+//	    Finish()
+type TxBuilder struct {
+	Tx    *TxMsg
+	err   error
+	owned bool // set if Tx was created by NewTxBuilder()
+}
+
+// NewTxBuilder returns a TxBuilder appending to the given TxMsg, or to a new genesis TxMsg if nil.
+func NewTxBuilder(tx *TxMsg) *TxBuilder {
+	b := &TxBuilder{
+		Tx: tx,
+	}
+	if tx == nil {
+		b.Tx = NewTxMsg(true)
+		b.owned = true
+	}
+	return b
+}
+
+// Upsert appends an upsert of the given element, where a nil attrID implies val.TagSpec().ID.
+func (b *TxBuilder) Upsert(cellID, attrID, itemID tag.ID, val tag.Value) *TxBuilder {
+	if b.err != nil {
+		return b
+	}
+	if attrID.IsNil() && val != nil {
+		attrID = val.TagSpec().ID
+	}
+	if attrID.IsNil() {
+		b.err = ErrCode_BadValue.Error("TxBuilder.Upsert: missing attr ID")
+		return b
+	}
+	b.err = b.Tx.Upsert(cellID, attrID, itemID, val)
+	return b
+}
+
+// Delete appends a delete of the given element.
+func (b *TxBuilder) Delete(cellID, attrID, itemID tag.ID) *TxBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.err = b.Tx.Delete(cellID, attrID, itemID)
+	return b
+}
+
+// Err returns the first error encountered, if any.
+func (b *TxBuilder) Err() error {
+	return b.err
+}
+
+// Finish returns the built TxMsg or the first error encountered.
+// On error, a TxMsg created by NewTxBuilder() is released.
+func (b *TxBuilder) Finish() (*TxMsg, error) {
+	if b.err != nil {
+		if b.owned {
+			b.Tx.ReleaseRef()
+			b.Tx = nil
+		}
+		return nil, b.err
+	}
+	return b.Tx, nil
+}
@@ -0,0 +1,348 @@
+package amp
+
+import (
+	"encoding/base64"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// URL is a parsed URL having a recognized UrlScheme, where the field for its scheme is set -- see ParseURL()
+type URL struct {
+	Scheme UrlScheme  // UrlScheme_Unrecognized if the scheme is not one of the UrlScheme values
+	Raw    string     // the URL as given
+	URL    *url.URL   // generic parse of Raw (opaque schemes such as "data:" and "magnet:" have URL.Opaque set)
+	Values url.Values // query parameters (never nil)
+
+	Amp     *AmpURL     // UrlScheme_Amp
+	Data    *DataURL    // UrlScheme_Data
+	File    *FileURL    // UrlScheme_File
+	Content *ContentURL // UrlScheme_Ipfs, UrlScheme_Ipns
+	Magnet  *MagnetURL  // UrlScheme_Magnet
+	Git     *GitURL     // UrlScheme_Git
+}
+
+// AmpURL is "[amp:[//{app-alias}/]]{cmd}[/{uri}][?{query}]"
+type AmpURL struct {
+	Alias string // app alias, or "" if not given
+	Cmd   string // first path component following the alias
+	URI   string // remaining path following Cmd
+}
+
+// DataURL is "data:[{media-type}][;{param}={value}]*[;base64],{data}" -- see RFC 2397
+type DataURL struct {
+	MediaType string            // lower case media type, defaulting to "text/plain"
+	Params    map[string]string // media type parameters -- e.g. "charset"
+	Data      []byte            // decoded data
+}
+
+// FileURL is "file://[{host}]/{path}" or "file:{path}"
+type FileURL struct {
+	Host string // "" or "localhost" denotes the local file system
+	Path string // unescaped and cleaned, using '/' separators -- see filepath.FromSlash()
+}
+
+// ContentURL is "ipfs://{cid}[/{path}]" or "ipns://{name}[/{path}]"
+type ContentURL struct {
+	CID  string // content ID (ipfs) or name (ipns)
+	Path string // path within the content, without a leading '/'
+}
+
+// MagnetURL is "magnet:?xt={urn}[&dn={name}][&tr={tracker}]*"
+type MagnetURL struct {
+	ExactTopics []string // "xt" params -- e.g. "urn:btih:{info-hash}"
+	InfoHash    string   // BitTorrent info hash from the first "urn:btih:" exact topic, if present
+	DisplayName string   // "dn" param
+	Trackers    []string // "tr" params
+}
+
+// GitURL is "git://{hostname}/{repo}[/{path}]", where a path component ending in ".git" marks the end of the repo
+type GitURL struct {
+	Host string
+	Repo string // e.g. "amp-sdk-go" or "art-media-platform/amp-sdk-go.git"
+	Path string // path within the repo
+}
+
+// ParseURL parses a URL into its UrlScheme and scheme-specific fields.
+// A URL without a scheme is parsed as an amp URL per the UrlScheme_Amp grammar.
+// ErrCode_InvalidURI is returned if the URL is malformed for its scheme.
+func ParseURL(rawURL string) (*URL, error) {
+	scheme, rest := splitScheme(rawURL)
+	u := &URL{
+		Raw:    rawURL,
+		Scheme: SchemeForName(scheme),
+	}
+	if scheme == "" {
+		u.Scheme = UrlScheme_Amp
+	}
+
+	var err error
+	if u.URL, err = url.Parse(rawURL); err != nil {
+		return nil, ErrCode_InvalidURI.Errorf("ParseURL: %v", err)
+	}
+	if u.Scheme == UrlScheme_Data { // a data URL's payload is not a query
+		u.Values = url.Values{}
+	} else if u.Values, err = url.ParseQuery(u.URL.RawQuery); err != nil {
+		return nil, ErrCode_InvalidURI.Errorf("ParseURL: %q: %v", rawURL, err)
+	}
+
+	switch u.Scheme {
+	case UrlScheme_Amp:
+		u.Amp = parseAmpURL(u.URL, rest)
+	case UrlScheme_Data:
+		u.Data, err = parseDataURL(rest)
+	case UrlScheme_File:
+		u.File, err = parseFileURL(u.URL)
+	case UrlScheme_Ipfs, UrlScheme_Ipns:
+		u.Content, err = parseContentURL(u.URL)
+	case UrlScheme_Magnet:
+		u.Magnet, err = parseMagnetURL(u.Values)
+	case UrlScheme_Git:
+		u.Git, err = parseGitURL(u.URL)
+	}
+	if err != nil {
+		return nil, ErrCode_InvalidURI.Errorf("ParseURL: %q: %v", rawURL, err)
+	}
+	return u, nil
+}
+
+// RouteRequest parses the request's PinTarget.URL (if present) into req.Target, req.URL, and req.Values
+// and returns the app that best handles it -- see Registry.GetAppsForInvocation()
+//
+// If the request has no target URL, (nil, nil) is returned since the request targets a cell by tag ID.
+func RouteRequest(reg Registry, req *Request) (*App, error) {
+	rawURL := req.PinTarget.GetURL()
+	if rawURL == "" {
+		return nil, nil
+	}
+
+	target, err := ParseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	req.Target = target
+	req.URL = target.URL
+	req.Values = target.Values
+
+	return reg.GetAppForInvocation(rawURL)
+}
+
+// SchemeForName returns the UrlScheme for a URL scheme name (e.g. "https") or UrlScheme_Unrecognized.
+func SchemeForName(scheme string) UrlScheme {
+	switch strings.ToLower(scheme) {
+	case "http", "https":
+		return UrlScheme_Http
+	case "data":
+		return UrlScheme_Data
+	case "file":
+		return UrlScheme_File
+	case "amp":
+		return UrlScheme_Amp
+	case "ipfs":
+		return UrlScheme_Ipfs
+	case "ipns":
+		return UrlScheme_Ipns
+	case "magnet":
+		return UrlScheme_Magnet
+	case "git":
+		return UrlScheme_Git
+	}
+	return UrlScheme_Unrecognized
+}
+
+// MediaType returns the media type this URL refers to (e.g. "image/png") as stated by a data URL or implied by a path's file extension -- or "" if unknown.
+func (u *URL) MediaType() string {
+	var pathname string
+	switch {
+	case u.Data != nil:
+		return u.Data.MediaType
+	case u.File != nil:
+		pathname = u.File.Path
+	case u.Content != nil:
+		pathname = u.Content.Path
+	case u.Git != nil:
+		pathname = u.Git.Path
+	case u.Scheme == UrlScheme_Http:
+		pathname = u.URL.Path
+	}
+	ext := path.Ext(pathname)
+	if ext == "" {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(mime.TypeByExtension(ext))
+	return mediaType
+}
+
+// splitScheme returns the scheme of a URL and what follows the ':', or ("", rawURL) if there is no scheme.
+func splitScheme(rawURL string) (scheme, rest string) {
+	for i := 0; i < len(rawURL); i++ {
+		c := rawURL[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case '0' <= c && c <= '9', c == '+', c == '-', c == '.':
+			if i == 0 {
+				return "", rawURL
+			}
+		case c == ':' && i > 0:
+			return rawURL[:i], rawURL[i+1:]
+		default:
+			return "", rawURL
+		}
+	}
+	return "", rawURL
+}
+
+func parseAmpURL(u *url.URL, rest string) *AmpURL {
+	amp := &AmpURL{}
+
+	var pathname string
+	if strings.HasPrefix(rest, "//") {
+		amp.Alias = u.Host
+		pathname = u.Path
+		if u.User != nil { // e.g. "amp://posix@v1/ls"
+			amp.Alias = u.User.String() + "@" + u.Host
+		}
+	} else {
+		pathname, _, _ = strings.Cut(rest, "?")
+		pathname, _, _ = strings.Cut(pathname, "#")
+		if unescaped, err := url.PathUnescape(pathname); err == nil {
+			pathname = unescaped
+		}
+	}
+
+	pathname = strings.TrimPrefix(pathname, "/")
+	amp.Cmd, amp.URI, _ = strings.Cut(pathname, "/")
+	return amp
+}
+
+func parseDataURL(rest string) (*DataURL, error) {
+	header, payload, ok := strings.Cut(rest, ",")
+	if !ok {
+		return nil, ErrCode_InvalidURI.Error("data URL missing ','")
+	}
+
+	data := &DataURL{
+		MediaType: "text/plain",
+		Params:    map[string]string{},
+	}
+
+	isBase64 := false
+	params := strings.Split(header, ";")
+	if mediaType := strings.TrimSpace(params[0]); mediaType != "" {
+		data.MediaType = strings.ToLower(mediaType)
+	}
+	for _, param := range params[1:] {
+		key, val, _ := strings.Cut(param, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "base64" && val == "" {
+			isBase64 = true
+		} else if key != "" {
+			data.Params[key] = val
+		}
+	}
+
+	decoded, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, err
+	}
+	if isBase64 {
+		decoded = strings.TrimRight(decoded, "=")
+		buf, err := base64.RawStdEncoding.DecodeString(decoded)
+		if err != nil {
+			buf, err = base64.RawURLEncoding.DecodeString(decoded)
+		}
+		if err != nil {
+			return nil, err
+		}
+		data.Data = buf
+	} else {
+		data.Data = []byte(decoded)
+	}
+	return data, nil
+}
+
+func parseFileURL(u *url.URL) (*FileURL, error) {
+	pathname := u.Path
+	if u.Opaque != "" { // "file:relative/path"
+		var err error
+		if pathname, err = url.PathUnescape(u.Opaque); err != nil {
+			return nil, err
+		}
+	}
+	if pathname == "" {
+		return nil, ErrCode_InvalidURI.Error("file URL missing path")
+	}
+
+	pathname = strings.ReplaceAll(pathname, "\\", "/")
+
+	// "/C:/dir" => "C:/dir"
+	if len(pathname) >= 3 && pathname[0] == '/' && pathname[2] == ':' {
+		pathname = pathname[1:]
+	}
+	return &FileURL{
+		Host: u.Host,
+		Path: path.Clean(pathname),
+	}, nil
+}
+
+func parseContentURL(u *url.URL) (*ContentURL, error) {
+	cid := u.Host
+	pathname := strings.TrimPrefix(u.Path, "/")
+	if u.Opaque != "" { // "ipfs:{cid}[/{path}]"
+		cid, pathname, _ = strings.Cut(u.Opaque, "/")
+	}
+	if cid == "" {
+		return nil, ErrCode_InvalidURI.Error("missing content ID")
+	}
+	for _, c := range cid {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '.') {
+			return nil, ErrCode_InvalidURI.Errorf("invalid content ID %q", cid)
+		}
+	}
+	return &ContentURL{
+		CID:  cid,
+		Path: pathname,
+	}, nil
+}
+
+func parseMagnetURL(values url.Values) (*MagnetURL, error) {
+	magnet := &MagnetURL{
+		ExactTopics: values["xt"],
+		DisplayName: values.Get("dn"),
+		Trackers:    values["tr"],
+	}
+	if len(magnet.ExactTopics) == 0 {
+		return nil, ErrCode_InvalidURI.Error("magnet URL missing exact topic (xt)")
+	}
+	for _, xt := range magnet.ExactTopics {
+		if len(xt) > 9 && strings.EqualFold(xt[:9], "urn:btih:") {
+			magnet.InfoHash = xt[9:]
+			break
+		}
+	}
+	return magnet, nil
+}
+
+func parseGitURL(u *url.URL) (*GitURL, error) {
+	if u.Host == "" {
+		return nil, ErrCode_InvalidURI.Error("git URL missing hostname")
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if parts[0] == "" {
+		return nil, ErrCode_InvalidURI.Error("git URL missing repo")
+	}
+
+	repoLen := 1
+	for i, part := range parts {
+		if strings.HasSuffix(part, ".git") {
+			repoLen = i + 1
+			break
+		}
+	}
+	return &GitURL{
+		Host: u.Host,
+		Repo: strings.Join(parts[:repoLen], "/"),
+		Path: strings.Join(parts[repoLen:], "/"),
+	}, nil
+}
@@ -0,0 +1,136 @@
+package amp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version -- see https://semver.org
+//
+//	"v{Major}.{Minor}.{Patch}[-{Pre}][+{Build}]"
+type Version struct {
+	Major uint32
+	Minor uint32
+	Patch uint32
+	Pre   string // pre-release identifiers, e.g. "beta.2"
+	Build string // build metadata, ignored for precedence
+}
+
+// ParseVersion parses a semantic version, where the leading 'v' is optional.
+// An empty string yields v0.0.0 and omitted minor or patch components are zero -- e.g. "v2" => v2.0.0.
+func ParseVersion(str string) (Version, error) {
+	vers, _, err := parseVersionPrefix(str)
+	return vers, err
+}
+
+// parseVersionPrefix is ParseVersion() but also returns how many numeric components were given (0..3).
+func parseVersionPrefix(str string) (vers Version, parts int, err error) {
+	expr := strings.TrimPrefix(strings.TrimSpace(str), "v")
+	if expr == "" {
+		return Version{}, 0, nil
+	}
+
+	if idx := strings.IndexByte(expr, '+'); idx >= 0 {
+		vers.Build = expr[idx+1:]
+		expr = expr[:idx]
+	}
+	if idx := strings.IndexByte(expr, '-'); idx >= 0 {
+		vers.Pre = expr[idx+1:]
+		expr = expr[:idx]
+	}
+
+	nums := strings.Split(expr, ".")
+	if len(nums) > 3 {
+		return Version{}, 0, ErrCode_BadValue.Errorf("invalid version %q", str)
+	}
+	dst := [3]*uint32{&vers.Major, &vers.Minor, &vers.Patch}
+	for i, num := range nums {
+		n, err := strconv.ParseUint(num, 10, 32)
+		if err != nil {
+			return Version{}, 0, ErrCode_BadValue.Errorf("invalid version %q", str)
+		}
+		*dst[i] = uint32(n)
+	}
+	return vers, len(nums), nil
+}
+
+func (vers Version) String() string {
+	str := "v" + strconv.FormatUint(uint64(vers.Major), 10) + "." + strconv.FormatUint(uint64(vers.Minor), 10) + "." + strconv.FormatUint(uint64(vers.Patch), 10)
+	if vers.Pre != "" {
+		str += "-" + vers.Pre
+	}
+	if vers.Build != "" {
+		str += "+" + vers.Build
+	}
+	return str
+}
+
+// Compare returns -1, 0, or 1 per semver precedence, where a pre-release version precedes its release version.
+func (vers Version) Compare(oth Version) int {
+	if diff := compareUint(vers.Major, oth.Major); diff != 0 {
+		return diff
+	}
+	if diff := compareUint(vers.Minor, oth.Minor); diff != 0 {
+		return diff
+	}
+	if diff := compareUint(vers.Patch, oth.Patch); diff != 0 {
+		return diff
+	}
+
+	switch {
+	case vers.Pre == oth.Pre:
+		return 0
+	case vers.Pre == "":
+		return 1
+	case oth.Pre == "":
+		return -1
+	}
+
+	ids := strings.Split(vers.Pre, ".")
+	othIDs := strings.Split(oth.Pre, ".")
+	for i := 0; i < len(ids) && i < len(othIDs); i++ {
+		n, numErr := strconv.ParseUint(ids[i], 10, 64)
+		m, othNumErr := strconv.ParseUint(othIDs[i], 10, 64)
+		switch {
+		case numErr == nil && othNumErr == nil:
+			if n != m {
+				if n < m {
+					return -1
+				}
+				return 1
+			}
+		case numErr == nil: // numeric identifiers precede alphanumeric ones
+			return -1
+		case othNumErr == nil:
+			return 1
+		default:
+			if diff := strings.Compare(ids[i], othIDs[i]); diff != 0 {
+				return diff
+			}
+		}
+	}
+	return compareUint(uint32(len(ids)), uint32(len(othIDs)))
+}
+
+// matchesPrefix returns true if the leading numeric components of this version equal those of the given version prefix.
+func (vers Version) matchesPrefix(prefix Version, parts int) bool {
+	switch {
+	case parts >= 3 && vers.Compare(prefix) != 0:
+		return false
+	case parts >= 2 && vers.Minor != prefix.Minor:
+		return false
+	case parts >= 1 && vers.Major != prefix.Major:
+		return false
+	}
+	return true
+}
+
+func compareUint(a, b uint32) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
@@ -2,12 +2,20 @@ package amp
 
 import (
 	"bytes"
+	"encoding/binary"
 	fmt "fmt"
 	io "io"
+	"math"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/media"
 	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/task"
 )
 
 func TestTxSerialize(t *testing.T) {
@@ -142,3 +150,1637 @@ func TestRegistry(t *testing.T) {
 		t.Fatalf("MakeValue returned wrong type: %v", reflect.TypeOf(elem))
 	}
 }
+
+func TestAppVersions(t *testing.T) {
+	reg := NewRegistry()
+	posixSpec := AppSpec.With("filesys.posix")
+
+	register := func(spec tag.Spec, vers string, invocations ...string) *App {
+		app := &App{
+			AppSpec:     spec,
+			Version:     vers,
+			Invocations: invocations,
+		}
+		if err := reg.RegisterApp(app); err != nil {
+			t.Fatalf("RegisterApp %q %s failed: %v", spec.Canonic, vers, err)
+		}
+		return app
+	}
+
+	v1 := register(posixSpec, "v1.2.0", "fs")
+	v2 := register(posixSpec, "v2.0.0-beta.1")
+
+	if app, _ := reg.GetAppByTag(posixSpec.ID); app != v2 {
+		t.Fatal("GetAppByTag should return the latest version")
+	}
+	if app, _ := reg.GetAppForInvocation("posix@v1"); app != v1 {
+		t.Fatal("GetAppForInvocation should match version prefix")
+	}
+	if app, _ := reg.GetAppForInvocation("fs"); app != v2 {
+		t.Fatal("GetAppForInvocation should resolve alias to latest version")
+	}
+	if _, err := reg.GetAppForInvocation("posix@v3"); GetErrCode(err) != ErrCode_AppNotFound {
+		t.Fatalf("expected ErrCode_AppNotFound, got %v", err)
+	}
+	if len(reg.Apps()) != 2 {
+		t.Fatal("Apps() should return every version")
+	}
+
+	// conflicts
+	if err := reg.RegisterApp(&App{AppSpec: posixSpec, Version: "1.2"}); GetErrCode(err) != ErrCode_AppConflict {
+		t.Fatalf("expected ErrCode_AppConflict for duplicate version, got %v", err)
+	}
+	if err := reg.RegisterApp(&App{AppSpec: AppSpec.With("other.app"), Invocations: []string{"fs"}}); GetErrCode(err) != ErrCode_AppConflict {
+		t.Fatalf("expected ErrCode_AppConflict for claimed alias, got %v", err)
+	}
+	if err := reg.RegisterApp(&App{AppSpec: AppSpec.With("other.app"), Version: "1.x"}); GetErrCode(err) != ErrCode_BadValue {
+		t.Fatalf("expected ErrCode_BadValue for invalid version, got %v", err)
+	}
+
+	// an explicit alias overrides another app's implicit leaf alias
+	shell := register(AppSpec.With("shell"), "v1.0.0", "posix")
+	if app, _ := reg.GetAppForInvocation("posix"); app != shell {
+		t.Fatal("explicit alias should override implicit leaf alias")
+	}
+
+	// dependency validation
+	if err := ValidateApps(reg); err != nil {
+		t.Fatalf("ValidateApps failed: %v", err)
+	}
+	a, b := AppSpec.With("dep.a"), AppSpec.With("dep.b")
+	reg.RegisterApp(&App{AppSpec: a, Dependencies: []tag.ID{b.ID}})
+	reg.RegisterApp(&App{AppSpec: b, Dependencies: []tag.ID{a.ID, AppSpec.With("missing").ID}})
+	err := ValidateApps(reg)
+	if GetErrCode(err) != ErrCode_DependencyFailed {
+		t.Fatalf("expected ErrCode_DependencyFailed, got %v", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "cycle") || !strings.Contains(msg, "missing") {
+		t.Fatalf("ValidateApps should report cycles and missing deps: %v", msg)
+	}
+
+	for _, vers := range []string{"v1.0.0-alpha", "v1.0.0-alpha.1", "v1.0.0-beta", "v1.0.0-beta.2", "v1.0.0-beta.11", "v1.0.0"} {
+		if _, err := ParseVersion(vers); err != nil {
+			t.Fatalf("ParseVersion(%q) failed: %v", vers, err)
+		}
+	}
+	prev, _ := ParseVersion("v1.0.0-alpha")
+	for _, vers := range []string{"v1.0.0-alpha.1", "v1.0.0-beta", "v1.0.0-beta.2", "v1.0.0-beta.11", "v1.0.0", "v1.0.1"} {
+		next, _ := ParseVersion(vers)
+		if prev.Compare(next) >= 0 {
+			t.Fatalf("expected %v < %v", prev, next)
+		}
+		prev = next
+	}
+}
+
+func TestAppReplace(t *testing.T) {
+	reg := NewRegistry()
+	spec := AppSpec.With("filesys.posix")
+
+	v1 := &App{AppSpec: spec, Version: "v1.0.0", Invocations: []string{"fs"}}
+	if err := reg.RegisterApp(v1); err != nil {
+		t.Fatal(err)
+	}
+	other := &App{AppSpec: AppSpec.With("other"), Invocations: []string{"cloud"}}
+	if err := reg.RegisterApp(other); err != nil {
+		t.Fatal(err)
+	}
+
+	// a failed replace leaves the previous registration intact
+	if _, err := reg.ReplaceApp(&App{AppSpec: spec, Version: "v2.0.0", Invocations: []string{"cloud"}}); GetErrCode(err) != ErrCode_AppConflict {
+		t.Fatalf("expected ErrCode_AppConflict, got %v", err)
+	}
+	if app, _ := reg.GetAppForInvocation("fs"); app != v1 {
+		t.Fatal("failed ReplaceApp should restore previous aliases")
+	}
+
+	v2 := &App{AppSpec: spec, Version: "v2.0.0", Invocations: []string{"files"}}
+	prev, err := reg.ReplaceApp(v2)
+	if err != nil || prev != v1 {
+		t.Fatalf("ReplaceApp failed: %v", err)
+	}
+	if _, err := reg.GetAppForInvocation("fs"); GetErrCode(err) != ErrCode_AppNotFound {
+		t.Fatal("ReplaceApp should release aliases of the previous app")
+	}
+	if app, _ := reg.GetAppForInvocation("files"); app != v2 {
+		t.Fatal("ReplaceApp should claim aliases of the new app")
+	}
+
+	if err := reg.UnregisterApp(spec.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reg.GetAppByTag(spec.ID); GetErrCode(err) != ErrCode_AppNotFound {
+		t.Fatal("UnregisterApp should remove the app")
+	}
+	if _, err := reg.GetAppForInvocation("posix"); GetErrCode(err) != ErrCode_AppNotFound {
+		t.Fatal("UnregisterApp should release the leaf alias")
+	}
+	if err := reg.UnregisterApp(spec.ID); GetErrCode(err) != ErrCode_AppNotFound {
+		t.Fatalf("expected ErrCode_AppNotFound, got %v", err)
+	}
+}
+
+// testSession is a Session whose app instances are children of its Context, where each instance drains once released.
+type testSession struct {
+	task.Context
+	Registry
+	mu        sync.Mutex
+	instances map[tag.ID]*testAppInstance
+	sent      []*TxMsg
+	lookupErr error
+}
+
+func newTestSession(t *testing.T, host Registry, clk task.Clock) *testSession {
+	opts := DefaultRegistryOpts()
+	opts.Parent = host
+	ctx, err := task.Start(&task.Task{
+		Info: task.Info{
+			Label: "session",
+			Clock: clk,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ctx.Close() })
+	return &testSession{
+		Context:   ctx,
+		Registry:  opts.CreateRegistry(),
+		instances: make(map[tag.ID]*testAppInstance),
+	}
+}
+
+func (sess *testSession) AssetPublisher() media.Publisher { return nil }
+func (sess *testSession) LoginInfo() Login                { return Login{} }
+
+func (sess *testSession) SendTx(tx *TxMsg) error {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	sess.sent = append(sess.sent, tx)
+	return nil
+}
+
+func (sess *testSession) GetAppInstance(appID tag.ID, autoCreate bool) (AppInstance, error) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if sess.lookupErr != nil {
+		return nil, sess.lookupErr
+	}
+	if inst := sess.instances[appID]; inst != nil {
+		select {
+		case <-inst.Done():
+		default:
+			return inst, nil
+		}
+	}
+	if !autoCreate {
+		return nil, nil
+	}
+	app, err := sess.GetAppByTag(appID)
+	if err != nil {
+		return nil, err
+	}
+	inst := &testAppInstance{
+		app:     app,
+		release: make(chan struct{}),
+	}
+	if inst.Context, err = sess.StartChild(&task.Task{
+		Info: task.Info{
+			Label: app.AppSpec.Canonic,
+		},
+	}); err != nil {
+		return nil, err
+	}
+	task.Go(inst.Context, "work", func(task.Context) { <-inst.release })
+	sess.instances[appID] = inst
+	return inst, nil
+}
+
+// reloaded returns the AppReloaded meta attrs sent to the client.
+func (sess *testSession) reloaded(t *testing.T) []*AppReloaded {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	var attrs []*AppReloaded
+	for _, tx := range sess.sent {
+		val, err := tx.CheckMetaAttr(sess)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if attr, ok := val.(*AppReloaded); ok {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// testAppInstance is an AppInstance that is done once release is closed.
+type testAppInstance struct {
+	task.Context
+	media.Publisher
+	app     *App
+	release chan struct{}
+}
+
+func (inst *testAppInstance) Session() Session                    { return nil }
+func (inst *testAppInstance) LocalDataPath() string               { return "" }
+func (inst *testAppInstance) GetAppAttr(tag.ID, tag.Value) error  { return ErrUnimplemented }
+func (inst *testAppInstance) PutAppAttr(tag.ID, tag.Value) error  { return ErrUnimplemented }
+func (inst *testAppInstance) Bus() *task.Bus                      { return nil }
+func (inst *testAppInstance) ServeRequest(Requester) (Pin, error) { return nil, ErrUnimplemented }
+func (inst *testAppInstance) MakeReady(Requester) error           { return nil }
+func (inst *testAppInstance) OnClosing()                          {}
+
+func TestReloadApp(t *testing.T) {
+	host := NewRegistry()
+	RegisterBuiltinTypes(host)
+	spec := AppSpec.With("filesys.posix")
+	v1 := &App{AppSpec: spec, Version: "v1.0.0"}
+	if err := host.RegisterApp(v1); err != nil {
+		t.Fatal(err)
+	}
+	clk := clock.NewVirtual(time.Time{})
+	sess := newTestSession(t, host, clk)
+
+	// not running: the registry is updated but no instance is started
+	v2 := &App{AppSpec: spec, Version: "v2.0.0"}
+	if err := ReloadApp(sess, v2, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if inst, _ := sess.GetAppInstance(spec.ID, false); inst != nil {
+		t.Fatal("ReloadApp should not start an app that was not running")
+	}
+	if app, _ := sess.GetAppByTag(spec.ID); app != v2 {
+		t.Fatal("ReloadApp should replace the session's app")
+	}
+	if len(sess.reloaded(t)) != 0 {
+		t.Fatal("ReloadApp should not notify the client of an app that was not running")
+	}
+
+	// reload: the running instance is drained and replaced
+	inst, err := sess.GetAppInstance(spec.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(inst.(*testAppInstance).release)
+	v3 := &App{AppSpec: spec, Version: "v3.0.0"}
+	if err := ReloadApp(sess, v3, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if next, _ := sess.GetAppInstance(spec.ID, false); next == nil || next.(*testAppInstance).app != v3 {
+		t.Fatal("ReloadApp should start a new instance of the replacing app")
+	}
+	if attrs := sess.reloaded(t); len(attrs) != 1 || attrs[0].Version != "v3.0.0" || attrs[0].PrevVersion != "v2.0.0" {
+		t.Fatal("ReloadApp should notify the client of the reload")
+	}
+
+	// drain timeout, measured by the session's clock
+	inst, _ = sess.GetAppInstance(spec.ID, false)
+	reloadErr := make(chan error, 1)
+	go func() {
+		reloadErr <- ReloadApp(sess, &App{AppSpec: spec, Version: "v4.0.0"}, time.Second)
+	}()
+	if !clk.WaitForTimers(1, 5*time.Second) {
+		t.Fatal("ReloadApp should wait on the session's clock")
+	}
+	clk.Advance(time.Second)
+	if err := <-reloadErr; GetErrCode(err) != ErrCode_Timeout {
+		t.Fatalf("expected ErrCode_Timeout, got %v", err)
+	}
+	close(inst.(*testAppInstance).release)
+	<-inst.Done()
+
+	// a failed instance lookup is returned rather than treated as not running
+	sess.lookupErr = ErrCode_AppNotFound.Error("lookup failed")
+	if err := UnloadApp(sess, spec.ID, time.Second); err != sess.lookupErr {
+		t.Fatalf("expected lookup error, got %v", err)
+	}
+	sess.lookupErr = nil
+
+	// unload an app inherited from the host registry
+	sess = newTestSession(t, host, clk)
+	inst, err = sess.GetAppInstance(spec.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(inst.(*testAppInstance).release)
+	if err := UnloadApp(sess, spec.ID, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sess.GetAppByTag(spec.ID); GetErrCode(err) != ErrCode_AppNotFound {
+		t.Fatal("UnloadApp should unregister the app from the session")
+	}
+	if attrs := sess.reloaded(t); len(attrs) != 1 || attrs[0].Version != "" || attrs[0].PrevVersion != "v1.0.0" {
+		t.Fatal("UnloadApp should notify the client of the unload")
+	}
+	if app, _ := host.GetAppByTag(spec.ID); app != v1 {
+		t.Fatal("UnloadApp should not affect the host registry")
+	}
+	if err := UnloadApp(sess, spec.ID, time.Second); GetErrCode(err) != ErrCode_AppNotFound {
+		t.Fatalf("expected ErrCode_AppNotFound, got %v", err)
+	}
+}
+
+func TestAppInvocation(t *testing.T) {
+	reg := NewRegistry()
+
+	posix := &App{AppSpec: AppSpec.With("filesys.posix"), Invocations: []string{"fs"}, ContentTypes: []string{"application/x-directory"}}
+	viewer := &App{AppSpec: AppSpec.With("media.viewer"), Invocations: []string{"fs/view"}, ContentTypes: []string{"image/*"}}
+	editor := &App{AppSpec: AppSpec.With("media.editor"), ContentTypes: []string{"image/*"}}
+	for _, app := range []*App{posix, viewer, editor} {
+		if err := reg.RegisterApp(app); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expect := func(invocation string, app *App, remain string) {
+		t.Helper()
+		matches, err := reg.GetAppsForInvocation(invocation)
+		if err != nil {
+			t.Fatalf("GetAppsForInvocation(%q) failed: %v", invocation, err)
+		}
+		if matches[0].App != app || matches[0].Remain != remain {
+			t.Fatalf("GetAppsForInvocation(%q): got %q remain %q", invocation, matches[0].App.AppSpec.Canonic, matches[0].Remain)
+		}
+	}
+
+	expect("fs", posix, "")
+	expect("amp://fs/ls/home?depth=1", posix, "ls/home?depth=1")
+	expect("amp://fs/view/cat.png", viewer, "cat.png") // longest alias prefix wins
+	expect("amp:posix/ls", posix, "ls")
+	expect("application/x-directory", posix, "")
+
+	// two apps handle images equally well
+	matches, err := reg.GetAppsForInvocation("data:image/png;base64,iVBORw0KGgo=")
+	if err != nil || len(matches) != 2 {
+		t.Fatalf("expected 2 candidates, got %v (%v)", len(matches), err)
+	}
+	if _, err := reg.GetAppForInvocation("image/png"); GetErrCode(err) != ErrCode_AppConflict {
+		t.Fatalf("expected ErrCode_AppConflict, got %v", err)
+	}
+	if _, err := reg.GetAppForInvocation("amp://nope/cmd"); GetErrCode(err) != ErrCode_AppNotFound {
+		t.Fatalf("expected ErrCode_AppNotFound, got %v", err)
+	}
+
+	// an alias route is not also treated as a media type
+	anyType := &App{AppSpec: AppSpec.With("media.inspector"), ContentTypes: []string{"*/*"}}
+	if err := reg.RegisterApp(anyType); err != nil {
+		t.Fatal(err)
+	}
+	if matches, err := reg.GetAppsForInvocation("fs/ls"); err != nil || len(matches) != 1 || matches[0].App != posix {
+		t.Fatalf("GetAppsForInvocation(\"fs/ls\") should only match the alias route, got %d matches (%v)", len(matches), err)
+	}
+	expect("text/plain", anyType, "")
+}
+
+func TestParseURL(t *testing.T) {
+	parse := func(rawURL string, scheme UrlScheme) *URL {
+		t.Helper()
+		u, err := ParseURL(rawURL)
+		if err != nil {
+			t.Fatalf("ParseURL(%q) failed: %v", rawURL, err)
+		}
+		if u.Scheme != scheme {
+			t.Fatalf("ParseURL(%q): expected %v, got %v", rawURL, scheme, u.Scheme)
+		}
+		return u
+	}
+
+	u := parse("amp://posix@v1/ls/home/docs?depth=2", UrlScheme_Amp)
+	if u.Amp.Alias != "posix@v1" || u.Amp.Cmd != "ls" || u.Amp.URI != "home/docs" || u.Values.Get("depth") != "2" {
+		t.Fatalf("bad amp URL: %+v", u.Amp)
+	}
+	u = parse("amp:glyph/application/x-directory", UrlScheme_Amp)
+	if u.Amp.Alias != "" || u.Amp.Cmd != "glyph" || u.Amp.URI != "application/x-directory" {
+		t.Fatalf("bad amp URL: %+v", u.Amp)
+	}
+
+	u = parse("data:text/plain;charset=utf-8;base64,aGVsbG8gd29ybGQ", UrlScheme_Data)
+	if u.Data.MediaType != "text/plain" || u.Data.Params["charset"] != "utf-8" || string(u.Data.Data) != "hello world" {
+		t.Fatalf("bad data URL: %+v", u.Data)
+	}
+	u = parse("data:,hello%20world", UrlScheme_Data)
+	if u.Data.MediaType != "text/plain" || string(u.Data.Data) != "hello world" {
+		t.Fatalf("bad data URL: %+v", u.Data)
+	}
+
+	u = parse("file:///C:/Users/me/../pics/cat.PNG", UrlScheme_File)
+	if u.File.Path != "C:/Users/pics/cat.PNG" || u.MediaType() != "image/png" {
+		t.Fatalf("bad file URL: %+v", u.File)
+	}
+	u = parse("file://localhost/tmp//a%20b/./c.txt", UrlScheme_File)
+	if u.File.Host != "localhost" || u.File.Path != "/tmp/a b/c.txt" {
+		t.Fatalf("bad file URL: %+v", u.File)
+	}
+
+	u = parse("ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi/wiki/index.html", UrlScheme_Ipfs)
+	if u.Content.CID != "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi" || u.Content.Path != "wiki/index.html" {
+		t.Fatalf("bad ipfs URL: %+v", u.Content)
+	}
+	u = parse("magnet:?xt=urn:btih:c12fe1c06bba254a9dc9f519b335aa7c1367a88a&dn=amp&tr=udp%3A%2F%2Ftracker.example.org%3A1337", UrlScheme_Magnet)
+	if u.Magnet.InfoHash != "c12fe1c06bba254a9dc9f519b335aa7c1367a88a" || u.Magnet.DisplayName != "amp" || u.Magnet.Trackers[0] != "udp://tracker.example.org:1337" {
+		t.Fatalf("bad magnet URL: %+v", u.Magnet)
+	}
+	u = parse("git://github.com/art-media-platform/amp-sdk-go.git/amp/amp.proto", UrlScheme_Git)
+	if u.Git.Repo != "art-media-platform/amp-sdk-go.git" || u.Git.Path != "amp/amp.proto" {
+		t.Fatalf("bad git URL: %+v", u.Git)
+	}
+	parse("https://example.org/a?b=c", UrlScheme_Http)
+	parse("mailto:someone@example.org", UrlScheme_Unrecognized)
+
+	for _, bad := range []string{"data:image/png;base64,!!!", "ipfs://", "magnet:?dn=nope", "git:///repo"} {
+		if _, err := ParseURL(bad); GetErrCode(err) != ErrCode_InvalidURI {
+			t.Fatalf("ParseURL(%q): expected ErrCode_InvalidURI, got %v", bad, err)
+		}
+	}
+
+	// routing
+	reg := NewRegistry()
+	ipfs := &App{AppSpec: AppSpec.With("ipfs.gateway"), Invocations: []string{"ipfs:"}}
+	viewer := &App{AppSpec: AppSpec.With("media.viewer"), ContentTypes: []string{"image/*"}}
+	for _, app := range []*App{ipfs, viewer} {
+		if err := reg.RegisterApp(app); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := &Request{}
+	req.PinTarget = &Tag{URL: "ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi/readme?x=1"}
+	if app, err := RouteRequest(reg, req); err != nil || app != ipfs {
+		t.Fatalf("RouteRequest failed: %v", err)
+	}
+	if req.Target.Content == nil || req.URL.Host != req.Target.Content.CID || req.Values.Get("x") != "1" {
+		t.Fatal("RouteRequest should populate Target, URL, and Values")
+	}
+	req.PinTarget.URL = "ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi/cat.jpg"
+	if app, err := RouteRequest(reg, req); err != nil || app != viewer {
+		t.Fatalf("RouteRequest should prefer a content type handler: %v", err)
+	}
+}
+
+// foreignRegistry is a Registry implementation other than the built-in one.
+type foreignRegistry struct {
+	Registry
+}
+
+func TestRegistryLayers(t *testing.T) {
+	host := NewRegistry()
+	RegisterBuiltinTypes(host)
+	posix := &App{AppSpec: AppSpec.With("filesys.posix"), Version: "v1.0.0", Invocations: []string{"fs"}}
+	viewer := &App{AppSpec: AppSpec.With("media.viewer"), ContentTypes: []string{"image/*"}}
+	for _, app := range []*App{posix, viewer} {
+		if err := host.RegisterApp(app); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := DefaultRegistryOpts()
+	opts.Parent = host
+	sess := opts.CreateRegistry()
+
+	// falls back to parent
+	if app, err := sess.GetAppForInvocation("fs"); err != nil || app != posix {
+		t.Fatalf("expected fallback to parent: %v", err)
+	}
+	if _, err := sess.MakeValue((&Login{}).TagSpec().ID); err != nil {
+		t.Fatalf("MakeValue should fall back to parent: %v", err)
+	}
+
+	// session-local override of an app and an alias
+	posix2 := &App{AppSpec: posix.AppSpec, Version: "v2.0.0", Invocations: []string{"fs"}}
+	if err := sess.RegisterApp(posix2); err != nil {
+		t.Fatal(err)
+	}
+	editor := &App{AppSpec: AppSpec.With("media.editor"), ContentTypes: []string{"image/*"}}
+	if err := sess.RegisterApp(editor); err != nil {
+		t.Fatal(err)
+	}
+	if app, _ := sess.GetAppForInvocation("fs"); app != posix2 {
+		t.Fatal("local app should override parent")
+	}
+	if app, _ := sess.GetAppByTag(posix.AppSpec.ID); app != posix2 {
+		t.Fatal("local app should override parent")
+	}
+	if app, _ := sess.GetAppForInvocation("image/png"); app != editor {
+		t.Fatal("local content type handler should win a tie with the parent")
+	}
+	if app, _ := host.GetAppForInvocation("fs"); app != posix {
+		t.Fatal("local registration should not affect parent")
+	}
+	if n := len(sess.Apps()); n != 3 {
+		t.Fatalf("expected 3 apps, got %d", n)
+	}
+
+	// import from any Registry implementation
+	dst := NewRegistry()
+	if err := dst.Import(foreignRegistry{sess}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if app, _ := dst.GetAppForInvocation("fs"); app != posix2 {
+		t.Fatal("Import should copy apps")
+	}
+	if _, err := dst.MakeValue((&Login{}).TagSpec().ID); err != nil {
+		t.Fatalf("Import should copy attr defs: %v", err)
+	}
+
+	// replacing and unregistering parent apps only affects the local layer
+	viewer2 := &App{AppSpec: viewer.AppSpec, Version: "v2.0.0", ContentTypes: []string{"image/*"}}
+	if prev, err := sess.ReplaceApp(viewer2); err != nil || prev != viewer {
+		t.Fatalf("ReplaceApp should return the parent's app: %v", err)
+	}
+	if err := sess.UnregisterApp(viewer.AppSpec.ID); err != nil {
+		t.Fatalf("UnregisterApp failed: %v", err)
+	}
+	if _, err := sess.GetAppByTag(viewer.AppSpec.ID); err == nil {
+		t.Fatal("unregistered parent app should be masked")
+	}
+	if app, _ := sess.GetAppForInvocation("image/png"); app != editor {
+		t.Fatal("unregistered parent app should not match invocations")
+	}
+	if n := len(sess.Apps()); n != 2 {
+		t.Fatalf("expected 2 apps, got %d", n)
+	}
+	if err := sess.UnregisterApp(viewer.AppSpec.ID); err == nil {
+		t.Fatal("UnregisterApp should fail for a masked app")
+	}
+	if app, _ := host.GetAppByTag(viewer.AppSpec.ID); app != viewer {
+		t.Fatal("unregistering from a layer should not affect parent")
+	}
+	if prev, err := sess.ReplaceApp(viewer2); err != nil || prev != nil {
+		t.Fatalf("ReplaceApp of a masked app should return no previous app: %v", err)
+	}
+	if app, _ := sess.GetAppByTag(viewer.AppSpec.ID); app != viewer2 {
+		t.Fatal("registering a masked app should unmask it")
+	}
+}
+
+// TestRegistryConcurrency is intended to be run with -race.
+func TestRegistryConcurrency(t *testing.T) {
+	host := NewRegistry()
+	RegisterBuiltinTypes(host)
+
+	opts := DefaultRegistryOpts()
+	opts.Parent = host
+	sess := opts.CreateRegistry()
+
+	loginID := (&Login{}).TagSpec().ID
+	context := AttrSpec.With("stress")
+
+	const workers = 8
+	const iters = 200
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*2)
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+
+		// writers
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iters; i++ {
+				subTags := fmt.Sprintf("w%d.i%d", w, i)
+				sess.RegisterPrototype(context, &Tag{}, subTags)
+				if i%20 == 0 {
+					app := &App{AppSpec: AppSpec.With(subTags)}
+					if err := sess.RegisterApp(app); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}
+		}(w)
+
+		// readers
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iters; i++ {
+				if _, err := sess.MakeValue(loginID); err != nil {
+					errs <- err
+					return
+				}
+				sess.GetAppsForInvocation("amp://w0.i0/cmd")
+				if i%50 == 0 {
+					sess.AttrDefs()
+					NewRegistry().Import(sess)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	for w := 0; w < workers; w++ {
+		for i := 0; i < iters; i++ {
+			if _, err := sess.MakeValue(context.With(fmt.Sprintf("w%d.i%d", w, i)).ID); err != nil {
+				t.Fatalf("registered prototype lost: %v", err)
+			}
+		}
+	}
+}
+
+func BenchmarkMakeValue(b *testing.B) {
+	reg := NewRegistry()
+	RegisterBuiltinTypes(reg)
+	loginID := (&Login{}).TagSpec().ID
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := reg.MakeValue(loginID); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestTypedAccessors(t *testing.T) {
+	cellA := tag.Now()
+	cellB := tag.Now()
+	labelID := AttrSpec.With("label.Tag").ID
+
+	tx, err := NewTxBuilder(nil).
+		Upsert(cellA, labelID, tag.ID{}, &Tag{Text: "a"}).
+		Upsert(cellB, labelID, tag.ID{}, &Tag{Text: "b"}).
+		Upsert(cellB, tag.ID{}, tag.ID{}, &Login{UserLabel: "b"}).
+		Delete(cellA, labelID, tag.ID{1}).
+		Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	label, err := LoadAs[*Tag](tx, cellB, labelID, tag.ID{})
+	if err != nil || label.Text != "b" {
+		t.Fatalf("LoadAs failed: %v", err)
+	}
+	login, err := LoadAs[*Login](tx, cellB, (&Login{}).TagSpec().ID, tag.ID{})
+	if err != nil || login.UserLabel != "b" {
+		t.Fatalf("LoadAs with implied attr ID failed: %v", err)
+	}
+	if _, err = LoadAs[*Tag](tx, cellA, labelID, tag.ID{1}); err != ErrPropertyNotFound {
+		t.Fatalf("LoadAs of deleted element should fail, got %v", err)
+	}
+	if _, err = LoadAs[tag.Value](tx, cellA, labelID, tag.ID{}); GetErrCode(err) != ErrCode_BadValue {
+		t.Fatalf("LoadAs with interface type should fail, got %v", err)
+	}
+
+	var texts []string
+	iter := Iter[*Tag](tx, tag.ID{}, labelID)
+	for iter.Next() {
+		texts = append(texts, iter.Value().Text)
+		if iter.Op().AttrID != labelID {
+			t.Fatal("Iter returned wrong attr")
+		}
+	}
+	if iter.Err() != nil || len(texts) != 2 {
+		t.Fatalf("Iter failed: %v %v", iter.Err(), texts)
+	}
+
+	// an upsert and delete of the same element in the same tx share an EditID, so the op added last wins
+	edits := NewTxMsg(true)
+	for i := 63; i >= 0; i-- {
+		itemID := tag.ID{0, 0, uint64(i)}
+		if i%2 == 0 {
+			edits.Upsert(cellA, labelID, itemID, &Tag{Text: "deleted"})
+			edits.Delete(cellA, labelID, itemID)
+		} else {
+			edits.Delete(cellA, labelID, itemID)
+			edits.Upsert(cellA, labelID, itemID, &Tag{Text: "upserted"})
+		}
+	}
+	for i := 0; i < 64; i++ {
+		var label Tag
+		err := edits.Load(cellA, labelID, tag.ID{0, 0, uint64(i)}, &label)
+		if i%2 == 0 && err != ErrPropertyNotFound {
+			t.Fatalf("upsert then delete of item %d should load as deleted, got %v", i, err)
+		}
+		if i%2 == 1 && (err != nil || label.Text != "upserted") {
+			t.Fatalf("delete then upsert of item %d should load the upsert, got %v", i, err)
+		}
+	}
+
+	if _, err = NewTxBuilder(nil).Upsert(cellA, tag.ID{}, tag.ID{}, nil).Delete(cellA, labelID, tag.ID{}).Finish(); GetErrCode(err) != ErrCode_BadValue {
+		t.Fatalf("TxBuilder should retain first error, got %v", err)
+	}
+}
+
+func makeTelemetryTx(numOps int, sorted bool) *TxMsg {
+	tx := NewTxMsg(true)
+	cellID := tag.ID{7, 7, 7}
+	attrID := AttrSpec.With("telemetry.Tag").ID
+	for i := 0; i < numOps; i++ {
+		itemID := tag.ID{0, 0, uint64(i)}
+		if !sorted {
+			itemID[2] = uint64((i * 7919) % numOps) // scrambled
+		}
+		tx.Upsert(cellID, attrID, itemID, &Tag{Text: "sample", TagID_2: itemID[2]})
+	}
+	tx.OpsSorted = false
+	if sorted {
+		tx.sortOps()
+	}
+	return tx
+}
+
+func TestTxView(t *testing.T) {
+	attrID := AttrSpec.With("telemetry.Tag").ID
+	for _, sorted := range []bool{true, false} {
+		tx := makeTelemetryTx(1000, sorted)
+		var buf []byte
+		tx.MarshalToBuffer(&buf)
+
+		view, err := ReadTxView(bytes.NewReader(buf), new([]byte))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if view.Sorted() != sorted || view.OpCount() != len(tx.Ops) || view.GenesisID() != tx.GenesisID() {
+			t.Fatalf("TxView header mismatch (sorted=%v)", sorted)
+		}
+
+		ops := view.Ops()
+		for ops.Next() {
+			if *ops.Op() != tx.Ops[ops.Index()] {
+				t.Fatalf("TxView op %d mismatch", ops.Index())
+			}
+		}
+		if ops.Err() != nil || ops.Index() != len(tx.Ops)-1 {
+			t.Fatalf("TxView iteration failed: %v", ops.Err())
+		}
+
+		for _, i := range []uint64{0, 1, 31, 32, 33, 500, 999} {
+			val := &Tag{}
+			if err = view.Load(tag.ID{7, 7, 7}, attrID, tag.ID{0, 0, i}, val); err != nil || val.TagID_2 != i {
+				t.Fatalf("TxView.Load(%d) failed (sorted=%v): %v", i, sorted, err)
+			}
+		}
+		if err = view.Load(tag.ID{7, 7, 7}, attrID, tag.ID{0, 0, 1000}, &Tag{}); err != ErrPropertyNotFound {
+			t.Fatalf("expected ErrPropertyNotFound, got %v", err)
+		}
+
+		copied, err := view.ToTxMsg()
+		if err != nil || !reflect.DeepEqual(copied.Ops, tx.Ops) || !bytes.Equal(copied.DataStore, tx.DataStore) {
+			t.Fatalf("TxView.ToTxMsg failed: %v", err)
+		}
+	}
+
+	if _, err := NewTxView([]byte("not a tx")); err != ErrMalformedTx {
+		t.Fatalf("expected ErrMalformedTx, got %v", err)
+	}
+}
+
+// makeRawTx returns a serialized tx having the given body and data store, allowing malformed bodies to be tested.
+func makeRawTx(body, data []byte) []byte {
+	var header TxHeader
+	header[0] = byte((Const_TxHeader_Marker >> 16) & 0xFF)
+	header[1] = byte((Const_TxHeader_Marker >> 8) & 0xFF)
+	header[2] = byte((Const_TxHeader_Marker >> 0) & 0xFF)
+	header[3] = byte(Const_TxHeader_Version)
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(header)+len(body)))
+	binary.LittleEndian.PutUint32(header[8:12], uint32(len(data)))
+
+	buf := append(header[:], body...)
+	return append(buf, data...)
+}
+
+// decodeTx decodes the given serialized tx via ReadTxMsg() and NewTxView(), visiting each op.
+func decodeTx(buf []byte) (msgErr, viewErr error) {
+	var header TxHeader
+	copy(header[:], buf)
+	if header.TxBodyLen()+header.TxDataLen() <= len(buf) { // otherwise ReadTxMsg() allocates the claimed size before reaching EOF
+		if msg, err := ReadTxMsg(bytes.NewReader(buf)); err != nil {
+			msgErr = err
+		} else {
+			msg.ReleaseRef()
+		}
+	}
+	view, err := NewTxView(buf)
+	if err != nil {
+		return msgErr, err
+	}
+	view.Sorted() // builds the view's index
+	ops := view.Ops()
+	for ops.Next() {
+		view.OpData(ops.Op())
+	}
+	return msgErr, ops.Err()
+}
+
+func TestTxMalformed(t *testing.T) {
+	hugeLen := binary.AppendUvarint(nil, math.MaxUint64)
+
+	envelope := TxEnvelope{OpCount: 1}
+	envBuf, err := envelope.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	withOp := func(op ...byte) []byte {
+		body := binary.AppendUvarint(nil, uint64(len(envBuf)))
+		body = append(body, envBuf...)
+		return append(body, op...)
+	}
+
+	withForgedOpCount := func(opCount uint64) []byte {
+		forged, err := (&TxEnvelope{OpCount: opCount}).Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body := binary.AppendUvarint(nil, uint64(len(forged)))
+		body = append(body, forged...)
+		return append(body, 0, 1, 0, 0, 0) // one valid op
+	}
+
+	bodies := map[string][]byte{
+		"huge envelope len": append(hugeLen, 0, 0),
+		"huge op skip":      withOp(append(hugeLen, 0, 0, 0, 0)...),
+		"large op skip":     withOp(append(binary.AppendUvarint(nil, math.MaxInt64), 0, 0, 0, 0)...),
+		"truncated op":      withOp(0, 1),
+		"truncated fields":  withOp(0, 1, 0, 0, 1, 0xAA),
+		"forged op count":   withForgedOpCount(1 << 62),
+	}
+	for name, body := range bodies {
+		msgErr, viewErr := decodeTx(makeRawTx(body, nil))
+		if msgErr != ErrMalformedTx || viewErr != ErrMalformedTx {
+			t.Errorf("%s: expected ErrMalformedTx, got %v and %v", name, msgErr, viewErr)
+		}
+	}
+}
+
+func FuzzTxDecode(f *testing.F) {
+	var buf []byte
+	makeTelemetryTx(10, true).MarshalToBuffer(&buf)
+	f.Add(buf)
+	f.Add(makeRawTx(binary.AppendUvarint(nil, math.MaxUint64), nil))
+
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		decodeTx(buf) // must not panic
+	})
+}
+
+func TestRecordTxSent(t *testing.T) {
+	app, err := task.Start(&task.Task{
+		Info: task.Info{
+			Label:   "app",
+			Metrics: task.NewMetrics(),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+	pin, _ := app.StartChild(&task.Task{})
+
+	tx, err := MarshalAttr(MetaNodeID, AttrSpec.With("label.Tag").ID, &Tag{Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	RecordTxSent(pin, tx)
+	RecordTxSent(pin, tx)
+
+	m := task.MetricsOf(app)
+	if m.Counter(MetricTxSent).Value() != 2 || m.Counter(MetricTxBytes).Value() != float64(2*flowCost(tx)) {
+		t.Fatalf("RecordTxSent failed: %v", m.Values())
+	}
+	tx.ReleaseRef()
+}
+
+func FuzzValueDelta(f *testing.F) {
+	base := []byte("the quick brown fox jumps over the lazy dog")
+	f.Add(base, appendValueDelta(nil, base, []byte("the quick brown cat jumps over the lazy dog!!")))
+	f.Add(base, []byte{4, 0, 1, 'x', 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 2, 'y', 'z'})
+
+	f.Fuzz(func(t *testing.T, base, delta []byte) {
+		out, err := applyValueDelta(nil, base, delta) // must not panic
+		if err == nil {
+			if targetLen, _ := binary.Uvarint(delta); uint64(len(out)) != targetLen {
+				t.Fatalf("applyValueDelta returned %d bytes, expected %d", len(out), targetLen)
+			}
+		}
+	})
+}
+
+func BenchmarkTxDecode(b *testing.B) {
+	tx := makeTelemetryTx(1000, true)
+	var buf []byte
+	tx.MarshalToBuffer(&buf)
+	attrID := AttrSpec.With("telemetry.Tag").ID
+
+	b.Run("ReadTxMsg/Load10", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			msg, err := ReadTxMsg(bytes.NewReader(buf))
+			if err != nil {
+				b.Fatal(err)
+			}
+			for j := uint64(0); j < 1000; j += 100 {
+				if err = msg.Load(tag.ID{7, 7, 7}, attrID, tag.ID{0, 0, j}, &Tag{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			msg.ReleaseRef()
+		}
+	})
+	b.Run("TxView/Load10", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			view, err := NewTxView(buf)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for j := uint64(0); j < 1000; j += 100 {
+				if err = view.Load(tag.ID{7, 7, 7}, attrID, tag.ID{0, 0, j}, &Tag{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("ReadTxMsg/Iterate", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			msg, err := ReadTxMsg(bytes.NewReader(buf))
+			if err != nil {
+				b.Fatal(err)
+			}
+			msg.ReleaseRef()
+		}
+	})
+	b.Run("TxView/Iterate", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			view, err := NewTxView(buf)
+			if err != nil {
+				b.Fatal(err)
+			}
+			ops := view.Ops()
+			for ops.Next() {
+			}
+		}
+	})
+}
+
+// testTransport records sent txs.
+type testTransport struct {
+	mu   sync.Mutex
+	sent []*TxMsg
+}
+
+func (tt *testTransport) Label() string           { return "test" }
+func (tt *testTransport) Close() error            { return nil }
+func (tt *testTransport) RecvTx() (*TxMsg, error) { return nil, ErrStreamClosed }
+func (tt *testTransport) SendTx(tx *TxMsg) error {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	tt.sent = append(tt.sent, tx)
+	return nil
+}
+
+func TestTxMergeSplit(t *testing.T) {
+	attrID := AttrSpec.With("label.Tag").ID
+	contextID := tag.Now()
+	newTx := func(cellID tag.ID, text string) *TxMsg {
+		tx := NewTxMsg(true)
+		tx.SetContextID(contextID)
+		tx.Upsert(cellID, attrID, tag.ID{}, &Tag{Text: text})
+		return tx
+	}
+
+	cellA, cellB := tag.ID{0, 0, 1}, tag.ID{0, 0, 2}
+	tx := newTx(cellA, "a1")
+	for _, src := range []*TxMsg{newTx(cellB, "b1"), newTx(cellA, "a2"), newTx(cellB, "b2")} {
+		src.Status = OpStatus_Synced
+		if err := tx.Merge(src); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(tx.Ops) != 4 || tx.Status != OpStatus_Synced {
+		t.Fatal("Merge failed")
+	}
+	other := NewTxMsg(true)
+	if err := tx.Merge(other); GetErrCode(err) != ErrCode_BadValue {
+		t.Fatalf("Merge should reject a different context, got %v", err)
+	}
+
+	tx.Compact()
+	if len(tx.Ops) != 2 {
+		t.Fatalf("Compact should remove superseded ops, got %d ops", len(tx.Ops))
+	}
+	for cellID, text := range map[tag.ID]string{cellA: "a2", cellB: "b2"} {
+		if val, err := LoadAs[*Tag](tx, cellID, attrID, tag.ID{}); err != nil || val.Text != text {
+			t.Fatalf("Compact lost the latest revision: %v", err)
+		}
+	}
+
+	// split keeps cells together
+	big := NewTxMsg(true)
+	big.Status = OpStatus_Synced
+	for i := 0; i < 30; i++ {
+		big.Upsert(tag.ID{0, 0, uint64(i % 3)}, attrID, tag.ID{0, 0, uint64(i)}, &Tag{Text: "x"})
+	}
+	parts, err := big.Split(10, 0)
+	if err != nil || len(parts) != 3 {
+		t.Fatalf("Split failed: %v", err)
+	}
+	for i, part := range parts {
+		cellID := part.Ops[0].CellID
+		for _, op := range part.Ops {
+			if op.CellID != cellID {
+				t.Fatal("Split should keep ops of a cell together")
+			}
+			if val, err := LoadAs[*Tag](part, op.CellID, attrID, op.ItemID); err != nil || val.Text != "x" {
+				t.Fatalf("Split part has bad data: %v", err)
+			}
+		}
+		if (i < len(parts)-1) != (part.Status == OpStatus_Syncing) {
+			t.Fatal("Split should mark non-final parts as syncing")
+		}
+	}
+	if parts[2].Status != OpStatus_Synced {
+		t.Fatal("Split should retain the status of the final part")
+	}
+
+	parts, err = big.Split(0, 1024)
+	if err != nil || len(parts) < 2 {
+		t.Fatalf("Split by bytes failed: %v", err)
+	}
+	for _, part := range parts {
+		var buf []byte
+		part.MarshalToBuffer(&buf)
+		if len(buf) > 1024 {
+			t.Fatalf("Split part exceeds max bytes: %d", len(buf))
+		}
+	}
+	if _, err = big.Split(0, 100); GetErrCode(err) != ErrCode_BadValue {
+		t.Fatalf("expected ErrCode_BadValue for oversized op, got %v", err)
+	}
+
+	// batching transport
+	via := &testTransport{}
+	clk := clock.NewVirtual(time.Time{})
+	bt := NewBatchingTransport(via, BatchOpts{Latency: 20 * time.Millisecond, MaxOps: 100, Clock: clk})
+	numSent := func() int {
+		via.mu.Lock()
+		defer via.mu.Unlock()
+		return len(via.sent)
+	}
+	for i := 0; i < 10; i++ {
+		bt.SendTx(newTx(cellA, fmt.Sprint(i)))
+	}
+	clk.Advance(19 * time.Millisecond)
+	if numSent() != 0 {
+		t.Fatal("batching transport should hold txs within its latency budget")
+	}
+	clk.Advance(time.Millisecond)
+	for start := time.Now(); numSent() == 0 && time.Since(start) < 2*time.Second; time.Sleep(time.Millisecond) {
+	}
+	via.mu.Lock()
+	if len(via.sent) != 1 || len(via.sent[0].Ops) != 1 {
+		t.Fatalf("batching transport should send one compacted tx, sent %d", len(via.sent))
+	}
+	via.mu.Unlock()
+
+	// a tx the caller still references is not modified by later txs merged into its batch
+	held := newTx(cellB, "held")
+	held.AddRef()
+	bt.SendTx(held)
+	bt.SendTx(newTx(cellA, "after"))
+	if len(held.Ops) != 1 || held.Ops[0].CellID != cellB {
+		t.Fatal("batching transport should not modify a sent tx")
+	}
+	held.ReleaseRef()
+	clk.Advance(20 * time.Millisecond)
+	for start := time.Now(); numSent() < 2 && time.Since(start) < 2*time.Second; time.Sleep(time.Millisecond) {
+	}
+	via.mu.Lock()
+	if len(via.sent) != 2 || len(via.sent[1].Ops) != 2 {
+		t.Fatalf("batching transport should send one tx merging both, sent %d", len(via.sent))
+	}
+	via.mu.Unlock()
+
+	closing := newTx(cellB, "bye")
+	closing.Status = OpStatus_Closed
+	bt.SendTx(closing)
+	if len(via.sent) != 3 {
+		t.Fatal("batching transport should send a closing tx without delay")
+	}
+	bt.Close()
+}
+
+func TestValueDelta(t *testing.T) {
+	attrID := AttrSpec.With("label.Tag").ID
+	cellID := tag.ID{0, 0, 1}
+	sender, receiver := NewValueCache(), NewValueCache()
+
+	roundTrip := func(tx *TxMsg) *TxMsg {
+		var buf []byte
+		tx.MarshalToBuffer(&buf)
+		tx.ReleaseRef()
+		rx, err := ReadTxMsg(bytes.NewReader(buf))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rx
+	}
+	send := func(text string) *TxMsg {
+		tx := NewTxMsg(true)
+		val := &Tag{Text: text, URL: "amp://" + strings.Repeat("x", 100)}
+		if err := tx.UpsertDelta(sender, cellID, attrID, tag.ID{}, val); err != nil {
+			t.Fatal(err)
+		}
+		return roundTrip(tx)
+	}
+	recv := func(rx *TxMsg, receiver *ValueCache, wantText string) []ElementID {
+		missed, err := receiver.Resolve(rx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(missed) == 0 {
+			val, err := LoadAs[*Tag](rx, cellID, attrID, tag.ID{})
+			if err != nil || val.Text != wantText {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+		}
+		return missed
+	}
+
+	rx := send("position 1")
+	if rx.Ops[0].OpCode != TxOpCode_UpsertElement {
+		t.Fatal("first upsert should be sent in full")
+	}
+	recv(rx, receiver, "position 1")
+
+	rx = send("position 2")
+	if rx.Ops[0].OpCode != TxOpCode_UpsertDelta || rx.Ops[0].DataLen > 40 {
+		t.Fatal("later upsert should be sent as a small delta")
+	}
+	if _, err := LoadAs[*Tag](rx, cellID, attrID, tag.ID{}); err != ErrUnresolvedDelta {
+		t.Fatalf("expected ErrUnresolvedDelta, got %v", err)
+	}
+	recv(rx, receiver, "position 2")
+
+	// a receiver lacking the base revision reports a miss, and the sender then resends in full
+	other := NewValueCache()
+	missed := recv(send("position 3"), other, "")
+	if len(missed) != 1 || missed[0] != (ElementID{cellID, attrID, {}}) {
+		t.Fatal("Resolve should report the missed element")
+	}
+	resync := NewDeltaResync(missed)
+	buf, _ := resync.MarshalToStore(nil)
+	resync = &DeltaResync{}
+	if err := resync.Unmarshal(buf); err != nil {
+		t.Fatal(err)
+	}
+	if elemIDs := sender.Resync(resync); len(elemIDs) != 1 || elemIDs[0] != missed[0] {
+		t.Fatal("Resync failed")
+	}
+	rx = send("position 4")
+	if rx.Ops[0].OpCode != TxOpCode_UpsertElement {
+		t.Fatal("upsert following a resync should be sent in full")
+	}
+	recv(rx, other, "position 4")
+
+	// ops whose data span is out of range are rejected rather than panic
+	for _, span := range [][2]uint64{{0, 1 << 20}, {math.MaxUint64, 2}, {1, math.MaxUint64}} {
+		for _, opCode := range []TxOpCode{TxOpCode_UpsertElement, TxOpCode_UpsertDelta} {
+			bad := &TxMsg{DataStore: make([]byte, 32)}
+			bad.Ops = append(bad.Ops, TxOp{OpCode: opCode, DataOfs: span[0], DataLen: span[1]})
+			if _, err := NewValueCache().Resolve(bad); err == nil {
+				t.Fatalf("Resolve should reject %v span %v", opCode, span)
+			}
+		}
+	}
+
+	// a corrupt delta is rejected and leaves the tx and cache unchanged
+	forged := binary.AppendUvarint(nil, 4)                // target len
+	forged = append(forged, 0, 1, 'x')                    // copy 0, literal "x"
+	forged = binary.AppendUvarint(forged, math.MaxUint64) // copy len that wraps when added to the position
+	forged = append(forged, 2, 'y', 'z')                  // literal "yz"
+	if _, err := applyValueDelta(nil, []byte("abcd"), forged); err == nil {
+		t.Fatal("applyValueDelta should reject a wrapping copy length")
+	}
+
+	cache := NewValueCache()
+	editA, editB := tag.ID{1}, tag.ID{2}
+	cache.put(ElementID{cellID, attrID, {}}, editA, []byte("abcd"))
+	bad := &TxMsg{DataStore: []byte("full")}
+	bad.Ops = append(bad.Ops, TxOp{OpCode: TxOpCode_UpsertElement, DataLen: 4})
+	bad.Ops[0].CellID, bad.Ops[0].AttrID, bad.Ops[0].EditID = tag.ID{0, 0, 2}, attrID, editB
+	deltaOp := TxOp{OpCode: TxOpCode_UpsertDelta, DataOfs: uint64(len(bad.DataStore))}
+	deltaOp.CellID, deltaOp.AttrID, deltaOp.EditID = cellID, attrID, editB
+	for _, word := range editA {
+		bad.DataStore = binary.LittleEndian.AppendUint64(bad.DataStore, word)
+	}
+	bad.DataStore = append(bad.DataStore, forged...)
+	deltaOp.DataLen = uint64(len(bad.DataStore)) - deltaOp.DataOfs
+	bad.Ops = append(bad.Ops, deltaOp)
+	opsBefore, dataBefore := append([]TxOp(nil), bad.Ops...), append([]byte(nil), bad.DataStore...)
+	if _, err := cache.Resolve(bad); GetErrCode(err) != ErrCode_MalformedTx {
+		t.Fatalf("Resolve should reject a corrupt delta, got %v", err)
+	}
+	if !reflect.DeepEqual(bad.Ops, opsBefore) || !bytes.Equal(bad.DataStore, dataBefore) || cache.Len() != 1 {
+		t.Fatal("a failed Resolve should leave the tx and cache unchanged")
+	}
+	if entry := cache.elems[ElementID{cellID, attrID, {}}]; entry.editID != editA || string(entry.data) != "abcd" {
+		t.Fatal("a failed Resolve should leave cached values unchanged")
+	}
+
+	// deltas of arbitrary values reconstruct exactly
+	base := []byte("the quick brown fox jumps over the lazy dog")
+	for _, target := range []string{"", "the", "the quick brown cat jumps over the lazy dog!!", "THE QUICK brown fox", string(base)} {
+		delta := appendValueDelta(nil, base, []byte(target))
+		out, err := applyValueDelta(nil, base, delta)
+		if err != nil || string(out) != target {
+			t.Fatalf("delta of %q failed: %q, %v", target, out, err)
+		}
+	}
+}
+
+// testPipe is one end of an in-memory Transport pair.
+type testPipe struct {
+	in, out chan *TxMsg
+	done    chan struct{}
+}
+
+func newTestPipes() (*testPipe, *testPipe) {
+	ab, ba, done := make(chan *TxMsg, 100), make(chan *TxMsg, 100), make(chan struct{})
+	return &testPipe{in: ba, out: ab, done: done}, &testPipe{in: ab, out: ba, done: done}
+}
+
+func (p *testPipe) Label() string { return "pipe" }
+func (p *testPipe) Close() error  { return nil }
+
+func (p *testPipe) SendTx(tx *TxMsg) error {
+	select {
+	case p.out <- tx:
+		return nil
+	case <-p.done:
+		return ErrStreamClosed
+	}
+}
+
+func (p *testPipe) RecvTx() (*TxMsg, error) {
+	select {
+	case tx := <-p.in:
+		return tx, nil
+	case <-p.done:
+		return nil, ErrStreamClosed
+	}
+}
+
+func TestTxScheduler(t *testing.T) {
+	attrID := AttrSpec.With("label.Tag").ID
+	newTx := func(contextID tag.ID, priority TxPriority, text string) *TxMsg {
+		tx := NewTxMsg(true)
+		tx.SetContextID(contextID)
+		tx.Priority = priority
+		tx.Upsert(tag.ID{0, 0, 1}, attrID, tag.ID{}, &Tag{Text: text, URL: strings.Repeat("x", 200)})
+		return tx
+	}
+	waitFor := func(cond func() bool) {
+		for start := time.Now(); !cond(); time.Sleep(time.Millisecond) {
+			if time.Since(start) > 5*time.Second {
+				t.Fatal("timed out")
+			}
+		}
+	}
+
+	a, b := newTestPipes()
+	defer close(a.done)
+	opts := TxSchedulerOpts{
+		Window:    1000,
+		BulkShare: 2,
+	}
+	host := NewTxScheduler(a, opts)
+	client := NewTxScheduler(b, opts)
+	defer host.Close()
+	defer client.Close()
+
+	go func() {
+		for {
+			if _, err := host.RecvTx(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// exhaust the host's credit while the client is not receiving
+	for i := 0; i < 4; i++ {
+		host.SendTx(newTx(tag.ID{0, 0, 99}, TxPriority_Interactive, "filler"))
+	}
+	waitFor(func() bool {
+		stats := host.Stats()
+		return stats.Credit <= 0 && stats.Queued[TxPriority_Interactive] == 0
+	})
+
+	pinA, pinB, media := tag.ID{0, 0, 100}, tag.ID{0, 0, 101}, tag.ID{0, 0, 102}
+	for i := 1; i <= 3; i++ {
+		host.SendTx(newTx(media, TxPriority_Bulk, fmt.Sprint("media", i)))
+	}
+	for i := 1; i <= 3; i++ {
+		host.SendTx(newTx(pinA, TxPriority_Interactive, fmt.Sprint("a", i)))
+	}
+	host.SendTx(newTx(pinB, TxPriority_Interactive, "b1"))
+	host.SendTx(newTx(media, TxPriority_Interactive, "media4")) // joins its context's bulk queue to retain order
+
+	stats := host.Stats()
+	if stats.Queued[TxPriority_Interactive] != 4 || stats.Queued[TxPriority_Bulk] != 4 || stats.Contexts != 3 || stats.QueuedBytes <= 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	// a control tx is sent without credit
+	ctrl := newTx(tag.ID{}, TxPriority_Control, "ctrl")
+	host.SendTx(ctrl)
+
+	var got []string
+	for len(got) < 9 {
+		tx, err := client.RecvTx()
+		if err != nil {
+			t.Fatal(err)
+		}
+		val, err := LoadAs[*Tag](tx, tag.ID{0, 0, 1}, attrID, tag.ID{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val.Text != "filler" {
+			got = append(got, val.Text)
+		}
+	}
+	want := []string{"ctrl", "a1", "b1", "media1", "a2", "a3", "media2", "media3", "media4"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected send order:\n got %v\nwant %v", got, want)
+	}
+	waitFor(func() bool { return host.Stats().QueuedBytes == 0 })
+
+	// a sender blocks while MaxQueued is reached
+	c, d := newTestPipes()
+	defer close(c.done)
+	blocking := NewTxScheduler(c, TxSchedulerOpts{Window: 1, MaxQueued: 1})
+	defer blocking.Close()
+	blocking.SendTx(newTx(pinA, TxPriority_Interactive, "x")) // sent, exhausting credit
+	waitFor(func() bool { return blocking.Stats().Sent == 1 })
+	blocking.SendTx(newTx(pinA, TxPriority_Interactive, "y")) // queued
+	sent := make(chan struct{})
+	go func() {
+		blocking.SendTx(newTx(pinA, TxPriority_Interactive, "z"))
+		close(sent)
+	}()
+	waitFor(func() bool { return blocking.Stats().Blocked == 1 })
+	select {
+	case <-sent:
+		t.Fatal("SendTx should block while MaxQueued is reached")
+	default:
+	}
+	receiver := NewTxScheduler(d, TxSchedulerOpts{Window: 1})
+	defer receiver.Close()
+	go func() {
+		for {
+			if _, err := blocking.RecvTx(); err != nil {
+				return
+			}
+		}
+	}()
+	for i := 0; i < 3; i++ {
+		if _, err := receiver.RecvTx(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-sent
+}
+
+// testRequester records the error its request completed with.
+type testRequester struct {
+	req  Request
+	once sync.Once
+	done chan error
+}
+
+func newTestRequester(timeout time.Duration, received time.Time) *testRequester {
+	op := &testRequester{
+		req: Request{
+			ID: tag.Now(),
+		},
+		done: make(chan error, 1),
+	}
+	op.req.SetTimeout(timeout)
+	op.req.InitDeadline(received)
+	return op
+}
+
+func (op *testRequester) Request() *Request      { return &op.req }
+func (op *testRequester) PushTx(tx *TxMsg) error { tx.ReleaseRef(); return nil }
+func (op *testRequester) OnComplete(err error)   { op.once.Do(func() { op.done <- err }) }
+
+// testPin is a Pin having only a task.Context.
+type testPin struct {
+	ctx task.Context
+}
+
+func (pin *testPin) ServeRequest(op Requester) (Pin, error) { return nil, ErrUnimplemented }
+func (pin *testPin) Context() task.Context                  { return pin.ctx }
+
+func TestPinTable(t *testing.T) {
+	pins := NewPinTable()
+	clk := clock.NewVirtual(time.Time{})
+	serve := func(op *testRequester, deadline time.Time) *testPin {
+		ctx, err := task.Start(&task.Task{
+			Info: task.Info{
+				Label:    "pin",
+				Deadline: deadline,
+				Clock:    clk,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pin := &testPin{ctx: ctx}
+		if err := pins.Add(op, pin); err != nil {
+			t.Fatal(err)
+		}
+		return pin
+	}
+	await := func(op *testRequester, pin *testPin, want error) {
+		select {
+		case err := <-op.done:
+			if err != want {
+				t.Fatalf("expected %v, got %v", want, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("request did not complete")
+		}
+		<-pin.ctx.Done()
+	}
+
+	// cancel via a CancelRequest meta tx
+	op := newTestRequester(0, clk.Now())
+	pin := serve(op, op.req.Deadline)
+	if !op.req.Deadline.IsZero() {
+		t.Fatal("request without a timeout should have no deadline")
+	}
+	if err := pins.Add(op, pin); GetErrCode(err) != ErrCode_BadRequest {
+		t.Fatal("Add should reject a duplicate request ID")
+	}
+	cancel, err := NewCancelTx(op.req.ID, "user navigated away")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pins.HandleTx(NewTxMsg(true)) || !pins.HandleTx(cancel) {
+		t.Fatal("HandleTx should only handle CancelRequest txs")
+	}
+	await(op, pin, ErrRequestClosed)
+	if pins.Cancel(op.req.ID) {
+		t.Fatal("Cancel of a completed request should return false")
+	}
+
+	// deadline enforced by the table and surfaced by the pin's context
+	op = newTestRequester(20*time.Millisecond, clk.Now())
+	pin = serve(op, op.req.Deadline)
+	if deadline, ok := pin.ctx.Deadline(); !ok || deadline != op.req.Deadline {
+		t.Fatal("pin context should have the request deadline")
+	}
+	clk.Advance(20 * time.Millisecond)
+	await(op, pin, ErrTimeout)
+
+	// deadline enforced by the table alone, measured by the pin context's clock
+	op = newTestRequester(20*time.Millisecond, clk.Now())
+	pin = serve(op, time.Time{})
+	clk.Advance(19 * time.Millisecond)
+	select {
+	case <-op.done:
+		t.Fatal("request completed before its deadline")
+	case <-pin.ctx.Closing():
+		t.Fatal("pin closed before its deadline")
+	default:
+	}
+	clk.Advance(time.Millisecond)
+	await(op, pin, ErrTimeout)
+
+	// a pin closing on its own is no longer tracked
+	op = newTestRequester(time.Hour, clk.Now())
+	pin = serve(op, op.req.Deadline)
+	pin.ctx.Close()
+	<-pin.ctx.Done()
+	for start := time.Now(); pins.Len() > 0; time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("closed pin is still tracked")
+		}
+	}
+}
+
+type testCell struct {
+	Label   *Tag   `amp:"label.Tag"`
+	Owner   *Login // mapped as "Owner.Login"
+	Skipped *Tag   `amp:"-"`
+	Count   int
+}
+
+func TestCellSchema(t *testing.T) {
+	reg := NewRegistry()
+	context := AttrSpec.With("test-cell")
+	schema, err := MakeSchemaForType(reg, context, reflect.TypeOf(testCell{}))
+	if err != nil {
+		t.Fatalf("MakeSchemaForType failed: %v", err)
+	}
+	if len(schema.Fields) != 2 {
+		t.Fatalf("expected 2 mapped fields, got %d", len(schema.Fields))
+	}
+	if schema.Fields[0].ID != context.With("label.Tag").ID || schema.Fields[1].ID != context.With("Owner.Login").ID {
+		t.Fatalf("unexpected field attr specs")
+	}
+	if _, err := reg.MakeValue(schema.Fields[1].ID); err != nil {
+		t.Fatalf("field prototype not registered: %v", err)
+	}
+
+	cellID := tag.Now()
+	src := testCell{
+		Label:   &Tag{Text: "hello"},
+		Owner:   &Login{UserLabel: "cmdr5"},
+		Skipped: &Tag{Text: "not written"},
+	}
+	tx := NewTxMsg(true)
+	if err := WriteCell(tx, cellID, schema, &src); err != nil {
+		t.Fatalf("WriteCell failed: %v", err)
+	}
+	if len(tx.Ops) != 2 {
+		t.Fatalf("expected 2 ops, got %d", len(tx.Ops))
+	}
+
+	var txBuf []byte
+	tx.MarshalToBuffer(&txBuf)
+	tx2, err := ReadTxMsg(&bufReader{buf: txBuf})
+	if err != nil {
+		t.Fatalf("ReadTxMsg failed: %v", err)
+	}
+
+	var dst testCell
+	if err := ReadCell(tx2, cellID, schema, &dst); err != nil {
+		t.Fatalf("ReadCell failed: %v", err)
+	}
+	if dst.Label.GetText() != "hello" || dst.Owner.GetUserLabel() != "cmdr5" || dst.Skipped != nil {
+		t.Fatalf("ReadCell loaded unexpected values: %v", dst)
+	}
+	if err := ReadCell(tx2, tag.Now(), schema, &dst); err != nil {
+		t.Fatalf("ReadCell should ignore missing attrs: %v", err)
+	}
+}
+
+func TestSchemaExport(t *testing.T) {
+	reg := NewRegistry()
+	RegisterBuiltinTypes(reg)
+	reg.RegisterApp(&App{
+		AppSpec:      AppSpec.With("test.schema"),
+		Version:      "v1.2.3",
+		Dependencies: []tag.ID{AppSpec.With("test.other").ID},
+	})
+
+	defs := ExportSchema(reg)
+	if len(defs.Apps) != 1 || defs.Apps[0].Version != "v1.2.3" || defs.Apps[0].Dependencies[0].TagID() != AppSpec.With("test.other").ID {
+		t.Fatalf("unexpected apps: %v", defs.Apps)
+	}
+
+	var tagSchema *AttrSchema
+	for _, attr := range defs.Attrs {
+		if attr.Spec.TagID() == AttrSpec.With("Tag").ID {
+			tagSchema = attr
+		}
+	}
+	if tagSchema == nil || tagSchema.TypeName != "amp.Tag" || tagSchema.GoType != "*amp.Tag" {
+		t.Fatalf("missing or bad amp.Tag schema: %v", tagSchema)
+	}
+	hasTags := false
+	for _, field := range tagSchema.Fields {
+		if field.Name == "Tags" && field.Type == "message" && field.TypeName == "amp.Tag" && field.Repeated {
+			hasTags = true
+		}
+	}
+	if !hasTags {
+		t.Fatalf("amp.Tag schema missing field Tags: %v", tagSchema.Fields)
+	}
+
+	var jsonBuf bytes.Buffer
+	if err := ExportSchemaJSON(reg, &jsonBuf); err != nil {
+		t.Fatalf("ExportSchemaJSON failed: %v", err)
+	}
+	if !bytes.Contains(jsonBuf.Bytes(), []byte(`"TypeName": "amp.LoginCheckpoint"`)) {
+		t.Fatalf("unexpected schema json:\n%s", jsonBuf.String())
+	}
+
+	// A peer registers a spec of its own that maps to a known element type
+	custom := AttrSpec.With("custom.Tag")
+	reg2 := NewRegistry()
+	RegisterBuiltinTypes(reg2)
+	err := reg2.RegisterDefs(&RegisterDefs{
+		Attrs: []*AttrSchema{
+			{Spec: specToTag(custom), TypeName: "amp.Tag"},
+			{Spec: specToTag(AttrSpec.With("unknown")), TypeName: "foo.Unknown"},
+		},
+	})
+	if GetErrCode(err) != ErrCode_AttrNotFound {
+		t.Fatalf("expected ErrCode_AttrNotFound, got %v", err)
+	}
+	val, err := reg2.MakeValue(custom.ID)
+	if err != nil {
+		t.Fatalf("MakeValue failed: %v", err)
+	}
+	if _, isTag := val.(*Tag); !isTag {
+		t.Fatalf("MakeValue returned wrong type: %T", val)
+	}
+}
@@ -0,0 +1,225 @@
+// amp-gen-attrs emits the tag.Value boilerplate (MarshalToStore, TagSpec, New) for every message in a .proto file,
+// plus a Register{Package}Types(reg amp.Registry) function that registers each message type as a prototype.
+//
+// Typical usage is via go:generate alongside a .proto file's generated .pb.go:
+//
+//	//go:generate go run github.com/art-media-platform/amp-sdk-go/cmd/amp-gen-attrs -proto std.proto -spec amp.AttrSpec
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const ampImportPath = "github.com/art-media-platform/amp-sdk-go/amp"
+
+func main() {
+	var (
+		protoFile = flag.String("proto", "", "input .proto file")
+		specExpr  = flag.String("spec", "amp.AttrSpec", "Go expression of the tag.Spec that prefixes each message's TagSpec()")
+		outFile   = flag.String("out", "", "output .go file (default: {proto-name}.attrs.gen.go)")
+		register  = flag.String("register", "", "name of the generated registration func (default: Register{Package}Types)")
+		skip      = flag.String("skip", "", "comma separated list of message names to omit")
+	)
+	flag.Parse()
+
+	if *protoFile == "" {
+		fmt.Fprintln(os.Stderr, "amp-gen-attrs: missing -proto")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := os.ReadFile(*protoFile)
+	if err != nil {
+		fail(err)
+	}
+
+	file, err := ParseProto(src)
+	if err != nil {
+		fail(fmt.Errorf("%s: %v", *protoFile, err))
+	}
+
+	opts := GenOpts{
+		Source:   filepath.Base(*protoFile),
+		SpecExpr: *specExpr,
+		Register: *register,
+	}
+	for _, name := range strings.Split(*skip, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			opts.Skip = append(opts.Skip, name)
+		}
+	}
+
+	out, err := Generate(file, opts)
+	if err != nil {
+		fail(err)
+	}
+
+	dst := *outFile
+	if dst == "" {
+		dst = strings.TrimSuffix(*protoFile, filepath.Ext(*protoFile)) + ".attrs.gen.go"
+	}
+	if err = os.WriteFile(dst, out, 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "amp-gen-attrs:", err)
+	os.Exit(1)
+}
+
+// ProtoFile is the subset of a .proto file needed to emit tag.Value methods.
+type ProtoFile struct {
+	Package   string   // proto package name, e.g. "std"
+	GoPackage string   // Go import path from option go_package
+	GoName    string   // Go package name
+	Messages  []string // Go type name of each message, in declaration order (nested messages are "Outer_Inner")
+}
+
+var (
+	sComments    = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	sProtoTokens = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|[A-Za-z_][\w.]*|[{};=]`)
+	sProtoIdent  = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+)
+
+// ParseProto scans a .proto source for its package, go_package, and (nested) message declarations.
+func ParseProto(src []byte) (*ProtoFile, error) {
+	src = sComments.ReplaceAll(src, nil)
+	tokens := sProtoTokens.FindAllString(string(src), -1)
+
+	file := &ProtoFile{}
+
+	// scope holds the enclosing message name for each open brace ("" for non-message blocks)
+	var scope []string
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch tok {
+		case "package":
+			if len(scope) == 0 && i+1 < len(tokens) {
+				file.Package = tokens[i+1]
+			}
+		case "option":
+			if len(scope) == 0 && i+3 < len(tokens) && tokens[i+1] == "go_package" && tokens[i+2] == "=" {
+				file.GoPackage = strings.Trim(tokens[i+3], `"`)
+			}
+		case "message":
+
+			// "message" is only a declaration if a name and '{' follow -- otherwise it is an identifier such as a field name
+			if i+2 >= len(tokens) || !sProtoIdent.MatchString(tokens[i+1]) || tokens[i+2] != "{" {
+				continue
+			}
+			name := tokens[i+1]
+			for j := len(scope) - 1; j >= 0; j-- {
+				if scope[j] != "" {
+					name = scope[j] + "_" + name
+					break
+				}
+			}
+			file.Messages = append(file.Messages, name)
+			scope = append(scope, name)
+			i += 2
+		case "{":
+			scope = append(scope, "")
+		case "}":
+			if len(scope) == 0 {
+				return nil, fmt.Errorf("unbalanced '}'")
+			}
+			scope = scope[:len(scope)-1]
+		}
+	}
+	if len(scope) != 0 {
+		return nil, fmt.Errorf("unbalanced '{'")
+	}
+
+	// go_package may be of the form "{import-path};{name}"
+	file.GoName = path.Base(file.GoPackage)
+	if idx := strings.IndexByte(file.GoPackage, ';'); idx >= 0 {
+		file.GoName = file.GoPackage[idx+1:]
+		file.GoPackage = file.GoPackage[:idx]
+	}
+	if file.GoPackage == "" {
+		file.GoName = file.Package
+	}
+	if file.GoName == "" {
+		return nil, fmt.Errorf("missing package declaration")
+	}
+	return file, nil
+}
+
+// GenOpts specifies how Generate() emits code for a ProtoFile.
+type GenOpts struct {
+	Source   string   // .proto file name noted in the generated header
+	SpecExpr string   // tag.Spec expression prefixing each TagSpec(), e.g. "amp.AttrSpec"
+	Register string   // name of the registration func; if empty, "Register{Package}Types"
+	Skip     []string // message names to omit
+}
+
+// Generate emits gofmt-ed Go source containing tag.Value methods for each message in file.
+func Generate(file *ProtoFile, opts GenOpts) ([]byte, error) {
+	inAmp := file.GoPackage == ampImportPath
+	ampPrefix := "amp."
+	specExpr := opts.SpecExpr
+	if inAmp {
+		ampPrefix = ""
+		specExpr = strings.TrimPrefix(specExpr, "amp.")
+	}
+
+	register := opts.Register
+	if register == "" {
+		register = "Register" + strings.ToUpper(file.Package[:1]) + file.Package[1:] + "Types"
+	}
+
+	skip := make(map[string]bool, len(opts.Skip))
+	for _, name := range opts.Skip {
+		skip[name] = true
+	}
+
+	var messages []string
+	for _, msg := range file.Messages {
+		if !skip[msg] {
+			messages = append(messages, msg)
+		}
+	}
+
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "// Code generated by amp-gen-attrs. DO NOT EDIT.\n// source: %s\n\n", opts.Source)
+	fmt.Fprintf(b, "package %s\n\n", file.GoName)
+	b.WriteString("import (\n")
+	if !inAmp {
+		fmt.Fprintf(b, "\t%q\n", ampImportPath)
+	}
+	b.WriteString("\t\"github.com/art-media-platform/amp-sdk-go/stdlib/tag\"\n)\n\n")
+
+	fmt.Fprintf(b, "// %s registers each message type in %s as a prototype under %s.\n", register, opts.Source, specExpr)
+	fmt.Fprintf(b, "func %s(reg %sRegistry) {\n", register, ampPrefix)
+	for _, msg := range messages {
+		fmt.Fprintf(b, "\treg.RegisterPrototype(%s, &%s{}, %q)\n", specExpr, msg, msg)
+	}
+	b.WriteString("}\n")
+
+	for _, msg := range messages {
+		fmt.Fprintf(b, `
+func (v *%[1]s) MarshalToStore(in []byte) (out []byte, err error) {
+	return %[2]sMarshalPbToStore(v, in)
+}
+
+func (v *%[1]s) TagSpec() tag.Spec {
+	return %[3]s.With(%[1]q)
+}
+
+func (v *%[1]s) New() tag.Value {
+	return &%[1]s{}
+}
+`, msg, ampPrefix, specExpr)
+	}
+
+	return format.Source(b.Bytes())
+}
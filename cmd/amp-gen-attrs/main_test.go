@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProto(t *testing.T) {
+	src := `
+syntax = "proto3";
+package demo;
+option go_package = "example.com/demo;demopb";
+
+// message Commented { }
+message Outer {
+    enum Mode { Mode_Nil = 0; }
+    message Inner {
+        string Name = 1;
+        string message = 2;
+    }
+    Inner Item = 1;
+    Inner message = 2;
+}
+/*
+message Hidden {}
+*/
+message Other {}
+`
+	file, err := ParseProto([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Package != "demo" || file.GoPackage != "example.com/demo" || file.GoName != "demopb" {
+		t.Fatalf("unexpected package info: %+v", file)
+	}
+	if strings.Join(file.Messages, ",") != "Outer,Outer_Inner,Other" {
+		t.Fatalf("unexpected messages: %v", file.Messages)
+	}
+
+	out, err := Generate(file, GenOpts{
+		Source:   "demo.proto",
+		SpecExpr: "amp.AttrSpec.With(\"demo\")",
+		Skip:     []string{"Other"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen := string(out)
+	for _, want := range []string{
+		"package demopb",
+		"func RegisterDemoTypes(reg amp.Registry) {",
+		`reg.RegisterPrototype(amp.AttrSpec.With("demo"), &Outer_Inner{}, "Outer_Inner")`,
+		`return amp.AttrSpec.With("demo").With("Outer")`,
+	} {
+		if !strings.Contains(gen, want) {
+			t.Errorf("generated code missing %q", want)
+		}
+	}
+	if strings.Contains(gen, "Other") {
+		t.Errorf("skipped message was generated")
+	}
+}
@@ -0,0 +1,69 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
+)
+
+func TestVirtual(t *testing.T) {
+	clk := clock.NewVirtual(time.Time{})
+	start := clk.Now()
+
+	t1 := clk.NewTimer(time.Hour)
+	t2 := clk.NewTimer(time.Minute)
+	t3 := clk.NewTimer(2 * time.Hour)
+	require.Equal(t, 3, clk.Pending())
+	next, ok := clk.NextTimer()
+	require.True(t, ok)
+	require.Equal(t, start.Add(time.Minute), next)
+
+	// timers fire as the clock passes them
+	clk.Advance(90 * time.Minute)
+	require.Equal(t, start.Add(90*time.Minute), <-t2.C())
+	require.Equal(t, start.Add(90*time.Minute), <-t1.C())
+	require.Equal(t, 1, clk.Pending())
+
+	// a stopped timer does not fire and a reset timer fires relative to the present
+	require.True(t, t3.Stop())
+	require.False(t, t3.Stop())
+	require.False(t, t3.Reset(time.Minute))
+	clk.Advance(30 * time.Minute)
+	select {
+	case <-t3.C():
+	default:
+		t.Fatal("reset timer did not fire")
+	}
+
+	// stopping or resetting a fired timer discards its unread value
+	clk.Advance(2 * time.Minute)
+	require.False(t, t3.Reset(time.Minute))
+	select {
+	case <-t3.C():
+		t.Fatal("reset timer delivered a stale value")
+	default:
+	}
+	clk.Advance(time.Minute)
+	require.Equal(t, clk.Now(), <-t3.C())
+	t3.Reset(time.Minute)
+	clk.Advance(time.Minute)
+	require.False(t, t3.Stop())
+	select {
+	case <-t3.C():
+		t.Fatal("stopped timer delivered a stale value")
+	default:
+	}
+
+	// WaitForTimers observes timers created by other goroutines
+	go clk.NewTimer(time.Second)
+	require.True(t, clk.WaitForTimers(1, time.Second))
+	require.False(t, clk.WaitForTimers(2, 10*time.Millisecond))
+
+	// the system clock follows time.Now()
+	timer := clock.System.NewTimer(time.Millisecond)
+	<-timer.C()
+	require.WithinDuration(t, time.Now(), clock.System.Now(), time.Second)
+}
@@ -0,0 +1,184 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Virtual is a Clock whose time only moves when advanced, allowing time-dependent behavior to be tested or simulated deterministically.
+// Timers fire (in order of their due time) as the clock is advanced past them.
+// As with a time.Timer (as of Go 1.23), stopping or resetting a timer discards any value it has fired but that is unread.
+type Virtual struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*virtualTimer // pending timers
+	changed chan struct{}   // closed and replaced when a timer is added
+}
+
+// NewVirtual returns a Virtual clock set to the given time (or to 2000-01-01 UTC if zero).
+func NewVirtual(start time.Time) *Virtual {
+	if start.IsZero() {
+		start = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return &Virtual{
+		now:     start,
+		changed: make(chan struct{}),
+	}
+}
+
+func (clk *Virtual) Now() time.Time {
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+	return clk.now
+}
+
+func (clk *Virtual) NewTimer(d time.Duration) Timer {
+	timer := &virtualTimer{
+		clock: clk,
+		ch:    make(chan time.Time, 1),
+	}
+	timer.Reset(d)
+	return timer
+}
+
+// Advance moves this clock forward by the given duration, firing timers that come due.
+func (clk *Virtual) Advance(d time.Duration) {
+	clk.AdvanceTo(clk.Now().Add(d))
+}
+
+// AdvanceTo moves this clock forward to the given time, firing timers that come due.
+func (clk *Virtual) AdvanceTo(t time.Time) {
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+
+	if t.After(clk.now) {
+		clk.now = t
+	}
+	sort.SliceStable(clk.timers, func(i, j int) bool {
+		return clk.timers[i].when.Before(clk.timers[j].when)
+	})
+	fired := 0
+	for _, timer := range clk.timers {
+		if timer.when.After(clk.now) {
+			break
+		}
+		timer.fire()
+		fired++
+	}
+	clk.timers = append(clk.timers[:0], clk.timers[fired:]...)
+}
+
+// Pending returns the number of timers that have yet to fire.
+func (clk *Virtual) Pending() int {
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+	return len(clk.timers)
+}
+
+// NextTimer returns when the earliest pending timer is due.
+func (clk *Virtual) NextTimer() (when time.Time, ok bool) {
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+	for _, timer := range clk.timers {
+		if !ok || timer.when.Before(when) {
+			when, ok = timer.when, true
+		}
+	}
+	return when, ok
+}
+
+// WaitForTimers blocks until at least n timers are pending, returning false if the given (real) timeout passes first.
+// Since timers are often created by other goroutines, this allows a test to wait until a timer exists before advancing.
+func (clk *Virtual) WaitForTimers(n int, timeout time.Duration) bool {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		clk.mu.Lock()
+		pending, changed := len(clk.timers), clk.changed
+		clk.mu.Unlock()
+		if pending >= n {
+			return true
+		}
+		select {
+		case <-changed:
+		case <-deadline.C:
+			return false
+		}
+	}
+}
+
+// TimerAdded returns a channel closed when a timer is next added or reset.
+func (clk *Virtual) TimerAdded() <-chan struct{} {
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+	return clk.changed
+}
+
+// add adds a pending timer.  Caller must hold clk.mu.
+func (clk *Virtual) add(timer *virtualTimer) {
+	clk.timers = append(clk.timers, timer)
+	close(clk.changed)
+	clk.changed = make(chan struct{})
+}
+
+// remove removes a pending timer, returning false if it was not pending.  Caller must hold clk.mu.
+func (clk *Virtual) remove(timer *virtualTimer) bool {
+	for i, ti := range clk.timers {
+		if ti == timer {
+			clk.timers = append(clk.timers[:i], clk.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type virtualTimer struct {
+	clock *Virtual
+	when  time.Time
+	ch    chan time.Time
+}
+
+func (timer *virtualTimer) C() <-chan time.Time {
+	return timer.ch
+}
+
+func (timer *virtualTimer) Stop() bool {
+	clk := timer.clock
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+	timer.drain()
+	return clk.remove(timer)
+}
+
+func (timer *virtualTimer) Reset(d time.Duration) bool {
+	clk := timer.clock
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+
+	timer.drain()
+	wasPending := clk.remove(timer)
+	timer.when = clk.now.Add(d)
+	if d <= 0 {
+		timer.fire()
+	} else {
+		clk.add(timer)
+	}
+	return wasPending
+}
+
+// drain discards an unread value so that a stopped or reset timer does not deliver a stale time.  Caller must hold clk.mu.
+func (timer *virtualTimer) drain() {
+	select {
+	case <-timer.ch:
+	default:
+	}
+}
+
+// fire sends the clock's time on the timer's channel unless a prior value is unread (as with a time.Timer).  Caller must hold clk.mu.
+func (timer *virtualTimer) fire() {
+	select {
+	case timer.ch <- timer.clock.now:
+	default:
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"encoding/hex"
 	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/art-media-platform/amp-sdk-go/stdlib/bufs"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
 )
 
 var (
@@ -193,9 +195,10 @@ func FromTime(t time.Time, addEntropy bool) ID {
 	}
 
 	if addEntropy {
-		gTagSeed = 377377733*ns_f64 ^ gTagSeed
+		// the LCG step keeps IDs unique even when the time is unchanged (e.g. with a stopped clock.Virtual)
+		gTagSeed = (377377733*ns_f64^gTagSeed)*6364136223846793005 + 1442695040888963407
 		tag[1] ^= gTagSeed & EntropyMask
-		tag[2] ^= gTagSeed * ns_f64
+		tag[2] ^= gTagSeed
 	}
 
 	return tag
@@ -218,8 +221,34 @@ func Join(prefixTags, suffixTags string) string {
 }
 
 // Returns the current time as a tag.ID, statistically guaranteed to be unique even when called in rapid succession.
+// The time is given by the Clock set via SetClock() (clock.System by default).
 func Now() ID {
-	return FromTime(time.Now(), true)
+	return FromTime(gClock.Load().Now(), true)
+}
+
+// NowOn is like Now() but uses the given Clock.
+func NowOn(c clock.Clock) ID {
+	return FromTime(c.Now(), true)
+}
+
+// SetClock sets the Clock used by Now() (and so by new TxMsg and EditID generation), returning the previous Clock.
+// Typically a test sets a clock.Virtual and restores the previous Clock when done.  If c is nil, clock.System is set.
+func SetClock(c clock.Clock) (prev clock.Clock) {
+	if c == nil {
+		c = clock.System
+	}
+	return gClock.Swap(&clockRef{c}).Clock
+}
+
+// clockRef allows a Clock of any type to be stored in an atomic.Pointer.
+type clockRef struct {
+	clock.Clock
+}
+
+var gClock atomic.Pointer[clockRef]
+
+func init() {
+	gClock.Store(&clockRef{clock.System})
 }
 
 func (id ID) IsNil() bool {
@@ -3,7 +3,9 @@ package tag_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
 	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
 )
 
@@ -99,3 +101,26 @@ func TestNewTag(t *testing.T) {
 		prevIDs[i&63] = now
 	}
 }
+
+func TestClock(t *testing.T) {
+	start := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	virtual := clock.NewVirtual(start)
+	prev := tag.SetClock(virtual)
+	defer tag.SetClock(prev)
+
+	id := tag.Now()
+	if id.Unix() != start.Unix() {
+		t.Fatalf("tag.Now() did not use the set clock: %v != %v", id.Unix(), start.Unix())
+	}
+	if next := tag.Now(); next == id || next.Unix() != start.Unix() {
+		t.Errorf("tag.Now() on a stopped clock is not unique: %v, %v", id, next)
+	}
+
+	virtual.Advance(3 * time.Hour)
+	if later := tag.Now(); later.Unix()-id.Unix() != 3*3600 || later.CompareTo(id) <= 0 {
+		t.Errorf("tag.Now() did not follow the clock: %v", later.Unix()-id.Unix())
+	}
+	if other := tag.NowOn(clock.NewVirtual(start)); other.Unix() != start.Unix() {
+		t.Errorf("tag.NowOn() did not use the given clock")
+	}
+}
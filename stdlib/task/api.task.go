@@ -26,6 +26,17 @@ func Go(parent Context, label string, fn func(ctx Context)) (Context, error) {
 	})
 }
 
+// WithValue sets a key/value pair in t.Info.Values, returning t for chaining:
+//
+//	child, err := parent.StartChild((&task.Task{OnRun: fn}).WithValue(userKey, user))
+func (t *Task) WithValue(key, val any) *Task {
+	if t.Info.Values == nil {
+		t.Info.Values = make(map[any]any)
+	}
+	t.Info.Values[key] = val
+	return t
+}
+
 type Info struct {
 	TID       int64    // globally unique atomically incremented instance ID -- assigned OnStart()
 	TagID     tag.ID   // optional user-defined tag.ID
@@ -38,6 +49,25 @@ type Info struct {
 	//
 	// This will not enter into effect unless OnRun is given or a child is started.
 	IdleClose time.Duration
+
+	// If non-zero, Close() is automatically called at this time and Err() then returns context.DeadlineExceeded.
+	// A Context's effective deadline is the earliest of Deadline, Timeout, and its parent's deadline, as returned by Context.Deadline().
+	Deadline time.Time
+
+	// If > 0, the Context's deadline is at most this long after it is started -- see Deadline.
+	Timeout time.Duration
+
+	// Source of time for IdleClose, CloseWhenIdle(), PreventIdleClose(), Deadline, and Timeout.
+	// If nil, the parent's Clock is used (or clock.System if there is no parent).
+	Clock Clock
+
+	// If set, the counters, gauges, and histograms of this Context, which roll up into those of its ancestors -- see Metrics.
+	// If nil, this Context's activity is accounted to the nearest ancestor having Metrics.
+	Metrics *Metrics
+
+	// Key/value pairs available via Context.Value() to this Context and its descendants, where a descendant's values take precedence.
+	// See Task.WithValue().
+	Values map[any]any
 }
 
 // Task is a parameter block used to start a new Context and contains hooks for each stage of the Context's lifecycle.
@@ -49,8 +79,47 @@ type Task struct {
 	OnClosing      func()                  // Called immediately after Close() is first called while self & children are still closing
 	OnChildClosing func(child Context)     // Called immediately after the child's OnClosing() is called
 	OnClosed       func()                  // Called after Close() and all children have completed Close() (but immediately before Done() is released)
+
+	// If set, this Context restarts its children as they close -- see Supervision.
+	Supervision *Supervision
+}
+
+// Supervision specifies how a Context restarts its children, in the spirit of an Erlang supervisor.
+//
+// A child is restarted by starting a new Context from the Task it was originally started with, so a Task restarted in this
+// way should not rely on state captured in its hooks.  A child is said to fail when its Err() is other than context.Canceled,
+// such as when it is closed via CloseWithCause(), by its deadline, or due to a panic (see PanicError).
+// Children are not restarted once their parent is draining or closing.
+type Supervision struct {
+	Strategy Strategy // which children are restarted when a child closes
+	Restart  Restart  // which closed children are restarted
+
+	// If > 0, the parent closes with ErrMaxRestarts once this many restarts occur within Period.
+	MaxRestarts int
+	Period      time.Duration
+
+	// Successive restarts within Period are delayed by MinBackoff, doubling each time up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
 }
 
+// Strategy specifies which children of a supervising Context are restarted when a child is to be restarted.
+type Strategy int32
+
+const (
+	OneForOne Strategy = iota // only the closed child is restarted
+	OneForAll                 // the remaining children are closed and all are restarted
+)
+
+// Restart specifies which closed children of a supervising Context are restarted.
+type Restart int32
+
+const (
+	RestartNever     Restart = iota // children are never restarted
+	RestartOnFailure                // children are restarted if they fail
+	RestartAlways                   // children are restarted however they close
+)
+
 // Context is an expanded form of a context.Context offering, featuring:
 //   - integrated logging, removing guesswork of which Context logged what
 //   - "child" Contexts such that Close() will cause a Context's children to close
@@ -92,6 +161,11 @@ type Context interface {
 	// After all children are done closing, OnClosing(), then OnClosed() are executed.
 	Close() error
 
+	// Close with a reason: once closed, Err() and context.Cause() of this Context and its closed descendants return an error
+	// that wraps cause and also satisfies errors.Is(err, context.Canceled).
+	// If cause is nil, this is equivalent to Close().  Only the first Close() or CloseWithCause() takes effect.
+	CloseWithCause(cause error)
+
 	// Inserts a pending Close() on this Context once it is idle after the given delay.
 	// Subsequent calls will update the delay but the previously pending delay must run out first.
 	// If at the end of the period Task.OnRun() is complete, there are no children, PreventIdleClose() is not in effect, then Close() is called.
@@ -103,6 +177,10 @@ type Context interface {
 	// Returns false if this Context has already been closed.
 	PreventIdleClose(delay time.Duration) bool
 
+	// Signals when this Context should finish its in-flight work and take on no more:
+	// when Shutdown() of this Context or an ancestor begins or when this Context begins closing.
+	Draining() <-chan struct{}
+
 	// Signals when Close() has been called.
 	// First, Children get Close(),  then OnClosing, then OnClosed are executing
 	Closing() <-chan struct{}
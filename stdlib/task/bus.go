@@ -0,0 +1,237 @@
+package task
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
+)
+
+// Bus is an in-process publish/subscribe hub whose topics are keyed by tag.ID, allowing Contexts (such as the apps of a host)
+// to notify each other without sharing channels.  A Bus is typically created by a host and shared with its apps.
+//
+// Messages are typed per topic via Topic[T], and each subscription is owned by a Context and removed when it closes.
+type Bus struct {
+	mu     sync.Mutex
+	topics map[tag.ID]any // *topicSubs[T]
+}
+
+// NewBus returns an empty Bus.
+func NewBus() *Bus {
+	return &Bus{
+		topics: make(map[tag.ID]any),
+	}
+}
+
+// Topic is a handle to the topic of a Bus having the given ID and message type.
+type Topic[T any] struct {
+	ID   tag.ID
+	subs *topicSubs[T]
+}
+
+type topicSubs[T any] struct {
+	mu   sync.RWMutex
+	subs []*Subscription[T]
+}
+
+// NewTopic returns the topic of the given Bus having the given ID, where all handles to a topic must use the same message type.
+// Panics if the topic is already in use with another message type.
+func NewTopic[T any](bus *Bus, topicID tag.ID) Topic[T] {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	entry, exists := bus.topics[topicID]
+	if !exists {
+		entry = &topicSubs[T]{}
+		bus.topics[topicID] = entry
+	}
+	subs, ok := entry.(*topicSubs[T])
+	if !ok {
+		panic(fmt.Sprintf("task: topic %v is in use with another message type (%T)", topicID, entry))
+	}
+	return Topic[T]{
+		ID:   topicID,
+		subs: subs,
+	}
+}
+
+// DeliveryPolicy specifies what Publish() does when a subscriber's buffer is full.
+type DeliveryPolicy int32
+
+const (
+	DropNewest DeliveryPolicy = iota // the published message is dropped for this subscriber
+	DropOldest                       // the subscriber's oldest pending message is dropped to make room
+	Block                            // Publish() blocks until there is room, the subscription closes, or the publishing Context closes
+)
+
+// SubscribeOpts specifies a Subscription.
+type SubscribeOpts struct {
+	Buffer int            // max pending messages (at least 1)
+	Policy DeliveryPolicy // what happens when the buffer is full
+}
+
+// Subscription delivers the messages published to a topic until it or its owning Context closes.
+type Subscription[T any] struct {
+	topic   *topicSubs[T]
+	policy  DeliveryPolicy
+	ch      chan T
+	mu      sync.RWMutex  // held for reading while sending to ch and for writing while closing ch
+	closed  bool          // set once ch is closed
+	done    chan struct{} // closed when closing begins, releasing blocked publishers
+	once    sync.Once
+	dropped atomic.Uint64
+}
+
+// Subscribe adds a subscription to this topic owned by the given Context, which is removed when the owner begins closing.
+// ErrClosed is returned if the owner is already closing.
+func (topic Topic[T]) Subscribe(owner Context, opts SubscribeOpts) (*Subscription[T], error) {
+	if opts.Buffer < 1 {
+		opts.Buffer = 1
+	}
+	sub := &Subscription[T]{
+		topic:  topic.subs,
+		policy: opts.Policy,
+		ch:     make(chan T, opts.Buffer),
+		done:   make(chan struct{}),
+	}
+
+	select {
+	case <-owner.Closing():
+		return nil, ErrClosed
+	default:
+	}
+
+	topic.subs.mu.Lock()
+	topic.subs.subs = append(topic.subs.subs, sub)
+	topic.subs.mu.Unlock()
+
+	go func() {
+		select {
+		case <-owner.Closing():
+			sub.Close()
+		case <-sub.done:
+		}
+	}()
+	return sub, nil
+}
+
+// Handle subscribes to this topic and calls fn with each message in a child Context of owner, in the order received.
+// The subscription is removed when the returned Context or owner closes.
+func (topic Topic[T]) Handle(owner Context, label string, opts SubscribeOpts, fn func(ctx Context, msg T)) (Context, error) {
+	var sub *Subscription[T]
+	return owner.StartChild(&Task{
+		Info: Info{
+			Label: label,
+		},
+		OnStart: func(ctx Context) (err error) {
+			sub, err = topic.Subscribe(ctx, opts)
+			return err
+		},
+		OnRun: func(ctx Context) {
+			for msg := range sub.C() {
+				fn(ctx, msg)
+			}
+		},
+	})
+}
+
+// Publish delivers msg to each subscriber of this topic, returning the number of subscribers it was delivered to.
+// If a subscriber's buffer is full, its DeliveryPolicy applies, where a Block subscriber is abandoned if from closes
+// (from may be nil).
+func (topic Topic[T]) Publish(from Context, msg T) (delivered int) {
+	var closing <-chan struct{}
+	if from != nil {
+		closing = from.Closing()
+	}
+
+	topic.subs.mu.RLock()
+	subs := topic.subs.subs
+	topic.subs.mu.RUnlock()
+
+	for _, sub := range subs {
+		if sub.deliver(msg, closing) {
+			delivered++
+		}
+	}
+	return delivered
+}
+
+// Subscribers returns the number of open subscriptions to this topic.
+func (topic Topic[T]) Subscribers() int {
+	topic.subs.mu.RLock()
+	defer topic.subs.mu.RUnlock()
+	return len(topic.subs.subs)
+}
+
+// C returns the channel on which messages are delivered, which is closed once this subscription closes.
+func (sub *Subscription[T]) C() <-chan T {
+	return sub.ch
+}
+
+// Dropped returns the number of messages dropped due to a full buffer.
+func (sub *Subscription[T]) Dropped() uint64 {
+	return sub.dropped.Load()
+}
+
+// Close removes this subscription from its topic and closes C().  Pending messages remain readable.
+func (sub *Subscription[T]) Close() {
+	sub.once.Do(func() {
+		close(sub.done)
+
+		topic := sub.topic
+		topic.mu.Lock()
+		subs := make([]*Subscription[T], 0, len(topic.subs))
+		for _, si := range topic.subs {
+			if si != sub {
+				subs = append(subs, si)
+			}
+		}
+		topic.subs = subs // copy on write since Publish() iterates without holding topic.mu
+		topic.mu.Unlock()
+
+		sub.mu.Lock()
+		sub.closed = true
+		close(sub.ch)
+		sub.mu.Unlock()
+	})
+}
+
+func (sub *Subscription[T]) deliver(msg T, closing <-chan struct{}) bool {
+	sub.mu.RLock()
+	defer sub.mu.RUnlock()
+	if sub.closed {
+		return false
+	}
+
+	select {
+	case sub.ch <- msg:
+		return true
+	default:
+	}
+
+	switch sub.policy {
+	case DropOldest:
+		for {
+			select {
+			case <-sub.ch:
+				sub.dropped.Add(1)
+			default:
+			}
+			select {
+			case sub.ch <- msg:
+				return true
+			default:
+			}
+		}
+	case Block:
+		select {
+		case sub.ch <- msg:
+			return true
+		case <-sub.done:
+		case <-closing:
+		}
+	}
+	sub.dropped.Add(1)
+	return false
+}
@@ -0,0 +1,12 @@
+package task
+
+import (
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
+)
+
+// Clock is the source of time for a Context's idle-close, deadline, and timeout timing -- see Info.Clock.
+// A test may substitute a clock.Virtual (see tasktest.FakeClock) to exercise timing deterministically.
+type Clock = clock.Clock
+
+// Timer is a time.Timer created by a Clock.
+type Timer = clock.Timer
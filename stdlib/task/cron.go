@@ -0,0 +1,178 @@
+package task
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a Schedule given by a standard 5-field cron expression -- see ParseCron().
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64 // bitmasks of allowed values
+
+	// If both day-of-month and day-of-week are restricted, a day matching either runs (as in standard cron).
+	domOrDow bool
+
+	// Location in which the expression is evaluated (time.Local if nil).
+	Location *time.Location
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronMonths = []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	cronDays   = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+// ParseCron parses a cron expression of the form "minute hour day-of-month month day-of-week", where each field is
+// "*" or a comma-separated list of values or ranges ("a-b"), each optionally stepped ("*/n" or "a-b/n").
+// Months and days of the week may be given by their 3-letter English names, and Sunday is either 0 or 7.
+// The macros @yearly, @annually, @monthly, @weekly, @daily, @midnight, and @hourly are also accepted.
+func ParseCron(expr string) (*CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("task: cron expression %q: expected 5 fields", expr)
+	}
+
+	cron := &CronSchedule{}
+	var err error
+	parsers := []struct {
+		mask     *uint64
+		min, max int
+		names    []string
+	}{
+		{&cron.minute, 0, 59, nil},
+		{&cron.hour, 0, 23, nil},
+		{&cron.dom, 1, 31, nil},
+		{&cron.month, 1, 12, cronMonths},
+		{&cron.dow, 0, 7, cronDays},
+	}
+	for i, p := range parsers {
+		if *p.mask, err = parseCronField(fields[i], p.min, p.max, p.names); err != nil {
+			return nil, fmt.Errorf("task: cron expression %q: %w", expr, err)
+		}
+	}
+	if cron.dow&(1<<7) != 0 { // 7 is also Sunday
+		cron.dow = (cron.dow | 1) &^ (1 << 7)
+	}
+	cron.domOrDow = !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*")
+	return cron, nil
+}
+
+func parseCronField(field string, min, max int, names []string) (uint64, error) {
+	parseValue := func(str string) (int, error) {
+		for i, name := range names {
+			if name != "" && strings.EqualFold(str, name) {
+				return i, nil
+			}
+		}
+		val, err := strconv.Atoi(str)
+		if err != nil || val < min || val > max {
+			return 0, fmt.Errorf("bad value %q", str)
+		}
+		return val, nil
+	}
+
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		rangeStr, stepStr, stepped := strings.Cut(part, "/")
+		step := 1
+		if stepped {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step < 1 {
+				return 0, fmt.Errorf("bad step %q", part)
+			}
+		}
+
+		lo, hi := min, max
+		if rangeStr != "*" {
+			loStr, hiStr, isRange := strings.Cut(rangeStr, "-")
+			var err error
+			if lo, err = parseValue(loStr); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = parseValue(hiStr); err != nil {
+					return 0, err
+				}
+			} else if stepped {
+				hi = max // "a/n" runs from a to the end of the range
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("bad range %q", part)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+// Next returns the first time after the given time matching this schedule, or the zero time if there is none within 5 years.
+func (cron *CronSchedule) Next(after time.Time) time.Time {
+	loc := cron.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	t := after.In(loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	nextHour := func(t time.Time) time.Time {
+		next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		if !next.After(t) { // repeated hour at a DST transition
+			next = t.Truncate(time.Minute).Add(time.Hour - time.Duration(t.Minute())*time.Minute)
+		}
+		return next
+	}
+
+	for t.Before(limit) {
+		if cron.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !cron.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if cron.hour&(1<<uint(t.Hour())) == 0 {
+			t = nextHour(t)
+			continue
+		}
+		if cron.minute&(1<<uint(t.Minute())) == 0 {
+			// skip directly to the next allowed minute of this hour, if any
+			if next := cron.minute >> uint(t.Minute()); next != 0 {
+				t = t.Add(time.Duration(bits.TrailingZeros64(next)) * time.Minute)
+			} else {
+				t = nextHour(t)
+			}
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (cron *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := cron.dom&(1<<uint(t.Day())) != 0
+	dowMatch := cron.dow&(1<<uint(t.Weekday())) != 0
+	if cron.domOrDow {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
@@ -0,0 +1,63 @@
+package task
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// DebugHandler returns an http.Handler serving the Context tree rooted at root as JSON, for diagnostics and dashboards:
+//
+//	GET ?depth=N  -- the Snapshot of root and its descendants up to N levels below (all if omitted)
+//	GET ?watch=1  -- a stream of newline-delimited JSON: the Snapshot of root followed by each Event (see Watch) until root closes
+//
+// Since a snapshot exposes labels and values of all Contexts, it should only be served on a private or authenticated endpoint.
+func DebugHandler(root Context) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		maxDepth := -1
+		if str := query.Get("depth"); str != "" {
+			depth, err := strconv.Atoi(str)
+			if err != nil {
+				http.Error(w, "bad depth", http.StatusBadRequest)
+				return
+			}
+			maxDepth = depth
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+
+		watch, _ := strconv.ParseBool(query.Get("watch"))
+		if !watch {
+			enc.SetIndent("", "  ")
+			enc.Encode(TakeSnapshot(root, maxDepth))
+			return
+		}
+
+		// subscribe before the initial snapshot so that no event is missed
+		feed, stop := Watch(root, 256)
+		defer stop()
+
+		flusher, _ := w.(http.Flusher)
+		if err := enc.Encode(TakeSnapshot(root, maxDepth)); err != nil {
+			return
+		}
+		for {
+			if flusher != nil {
+				flusher.Flush()
+			}
+			select {
+			case ev := <-feed:
+				if err := enc.Encode(ev); err != nil {
+					return
+				}
+			case <-root.Done():
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package task
+
+import (
+	"bufio"
+	"expvar"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WritePrometheus writes the Metrics of root and each of its descendants having Metrics in the Prometheus text exposition format,
+// where each sample is labeled with its Context's label and TID:
+//
+//	children{ctx="session",tid="12"} 3
+//
+// Metric names are sanitized to the characters Prometheus allows and prefixed with namePrefix (e.g. "amp_").
+func WritePrometheus(out io.Writer, root Context, namePrefix string) error {
+	type sample struct {
+		labels string
+		series any
+	}
+	families := make(map[string][]sample)
+
+	walkMetrics(root, func(c Context, m *Metrics) {
+		info := c.Info()
+		labels := fmt.Sprintf(`ctx="%s",tid="%d"`, promLabelEscaper.Replace(c.Log().GetLogLabel()), info.TID)
+		m.forEach(func(name string, series any) {
+			name = promName(namePrefix + name)
+			families[name] = append(families[name], sample{labels, series})
+		})
+	})
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := bufio.NewWriter(out)
+	for _, name := range names {
+		samples := families[name]
+		kind := fmt.Sprintf("%T", samples[0].series)
+		switch samples[0].series.(type) {
+		case *Counter:
+			fmt.Fprintf(w, "# TYPE %s counter\n", name)
+		case *Gauge:
+			fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+		case *Histogram:
+			fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+		}
+		for _, s := range samples {
+			if fmt.Sprintf("%T", s.series) != kind {
+				continue // a family's samples must all be of the same type
+			}
+			switch series := s.series.(type) {
+			case *Counter:
+				fmt.Fprintf(w, "%s{%s} %s\n", name, s.labels, promFloat(series.Value()))
+			case *Gauge:
+				fmt.Fprintf(w, "%s{%s} %s\n", name, s.labels, promFloat(series.Value()))
+			case *Histogram:
+				bounds, cumulative := series.Buckets()
+				count, sum := series.Totals()
+				for i, bound := range bounds {
+					fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", name, s.labels, promFloat(bound), cumulative[i])
+				}
+				fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, s.labels, count)
+				fmt.Fprintf(w, "%s_sum{%s} %s\n", name, s.labels, promFloat(sum))
+				fmt.Fprintf(w, "%s_count{%s} %d\n", name, s.labels, count)
+			}
+		}
+	}
+	return w.Flush()
+}
+
+// ExpvarFunc returns an expvar.Func reporting the Metrics of root and each of its descendants having Metrics, keyed by "label#TID":
+//
+//	expvar.Publish("tasks", task.ExpvarFunc(root))
+func ExpvarFunc(root Context) expvar.Func {
+	return func() any {
+		report := make(map[string]map[string]float64)
+		walkMetrics(root, func(c Context, m *Metrics) {
+			report[fmt.Sprintf("%s#%d", c.Log().GetLogLabel(), c.Info().TID)] = m.Values()
+		})
+		return report
+	}
+}
+
+// walkMetrics calls fn for root and each of its descendants having their own Metrics.
+func walkMetrics(c Context, fn func(c Context, m *Metrics)) {
+	if m := c.Info().Metrics; m != nil {
+		fn(c, m)
+	}
+	var subBuf [20]Context
+	for _, child := range c.GetChildren(subBuf[:0]) {
+		walkMetrics(child, fn)
+	}
+}
+
+// formatMetrics returns the Metrics of a Context as "name=value ..." for PrintContextTreeWithMetrics().
+func formatMetrics(m *Metrics) string {
+	values := m.Values()
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf strings.Builder
+	for _, name := range names {
+		buf.WriteByte(' ')
+		buf.WriteString(name)
+		buf.WriteByte('=')
+		buf.WriteString(strconv.FormatFloat(values[name], 'g', 6, 64))
+	}
+	return buf.String()
+}
+
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func promName(name string) string {
+	var buf strings.Builder
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == ':', r >= '0' && r <= '9' && i > 0:
+			buf.WriteRune(r)
+		default:
+			buf.WriteByte('_')
+		}
+	}
+	return buf.String()
+}
+
+func promFloat(v float64) string {
+	switch {
+	case math.IsInf(v, +1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,148 @@
+package task
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
+)
+
+// Semaphore limits the number of holders of a resource, such as concurrent downloads, where waiting respects Context closing.
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore returns a Semaphore allowing up to n holders at once.
+func NewSemaphore(n int) *Semaphore {
+	if n < 1 {
+		n = 1
+	}
+	return &Semaphore{
+		slots: make(chan struct{}, n),
+	}
+}
+
+// Acquire blocks until a slot is available, returning ErrClosed if ctx begins closing first.
+// Each successful Acquire() must be followed by a Release().
+func (sem *Semaphore) Acquire(ctx Context) error {
+	select {
+	case sem.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	select {
+	case sem.slots <- struct{}{}:
+		return nil
+	case <-ctx.Closing():
+		return ErrClosed
+	}
+}
+
+// TryAcquire acquires a slot if one is available without blocking.
+func (sem *Semaphore) TryAcquire() bool {
+	select {
+	case sem.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release releases a slot acquired by Acquire() or TryAcquire().
+func (sem *Semaphore) Release() {
+	select {
+	case <-sem.slots:
+	default:
+		panic("task: Semaphore released more than acquired")
+	}
+}
+
+// RateLimiter is a token bucket allowing events at a steady rate with bursts, where waiting respects Context closing.
+type RateLimiter struct {
+	mu     sync.Mutex
+	clock  Clock
+	rate   float64 // tokens accrued per nanosecond (0 if none accrue)
+	burst  float64 // max tokens
+	tokens float64 // available tokens as of last (negative when reserved by waiters)
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing perSecond events per second on average and up to burst events at once.
+// If perSecond <= 0, only the initial burst is allowed, and if perSecond is +Inf, all events are allowed.
+// Tokens accrue as measured by clk (or clock.System if nil).
+func NewRateLimiter(perSecond float64, burst int, clk Clock) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	if clk == nil {
+		clk = clock.System
+	}
+	rl := &RateLimiter{
+		clock:  clk,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clk.Now(),
+	}
+	if perSecond > 0 {
+		rl.rate = perSecond / float64(time.Second)
+	}
+	return rl
+}
+
+// advance accrues tokens up to now.  Caller must hold rl.mu.
+func (rl *RateLimiter) advance(now time.Time) {
+	if math.IsInf(rl.rate, 1) {
+		rl.tokens = rl.burst
+		return
+	}
+	if elapsed := now.Sub(rl.last); elapsed > 0 {
+		rl.tokens += float64(elapsed) * rl.rate
+		if rl.tokens > rl.burst {
+			rl.tokens = rl.burst
+		}
+		rl.last = now
+	}
+}
+
+// Allow takes a token if one is available without blocking.
+func (rl *RateLimiter) Allow() bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.advance(rl.clock.Now())
+	if rl.tokens < 1 {
+		return false
+	}
+	rl.tokens--
+	return true
+}
+
+// Wait blocks until a token is available and takes it, returning ErrClosed (and forfeiting nothing) if ctx begins closing first.
+func (rl *RateLimiter) Wait(ctx Context) error {
+	rl.mu.Lock()
+	rl.advance(rl.clock.Now())
+	rl.tokens--
+	tokens := rl.tokens
+	rl.mu.Unlock()
+
+	if tokens >= 0 {
+		return nil
+	}
+
+	wait := -tokens / rl.rate    // +Inf if no tokens accrue
+	var expired <-chan time.Time // nil (never) if the wait is unbounded
+	if wait < float64(math.MaxInt64) {
+		timer := rl.clock.NewTimer(time.Duration(wait))
+		defer timer.Stop()
+		expired = timer.C()
+	}
+	select {
+	case <-expired:
+		return nil
+	case <-ctx.Closing():
+		rl.mu.Lock()
+		rl.tokens = min(rl.tokens+1, rl.burst) // return the reserved token
+		rl.mu.Unlock()
+		return ErrClosed
+	}
+}
@@ -0,0 +1,331 @@
+package task
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Names of the metrics maintained for each Context having Metrics -- see Info.Metrics.
+const (
+	MetricChildren   = "children"    // gauge: open child Contexts, which an ancestor sums over its descendants
+	MetricRunSeconds = "run_seconds" // counter: time spent in Task.OnRun()
+)
+
+// DefaultBuckets are the upper bounds of a Histogram's buckets if none are given.
+var DefaultBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Metrics holds the named counters, gauges, and histograms of a Context, each of which rolls up into the same-named
+// series of the nearest ancestor also having Metrics, so that the Metrics of an app or session accounts for all its pins.
+//
+// A Metrics is attached to a Context via Info.Metrics and should be attached to only one Context at a time.
+// Values recorded before a Metrics is attached (including histogram observations) roll up once it is attached.
+// Contexts without Metrics report to their nearest ancestor's -- see MetricsOf().
+// A nil *Metrics (and the nil series it returns) may be used freely and records nothing.
+type Metrics struct {
+	mu     sync.Mutex
+	parent *Metrics
+	series map[string]any // *Counter, *Gauge, or *Histogram
+}
+
+// NewMetrics returns an empty Metrics to be attached to a Context via Info.Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		series: make(map[string]any),
+	}
+}
+
+// MetricsOf returns the Metrics of the given Context or else of its nearest ancestor having Metrics, or nil if there are none.
+func MetricsOf(c Context) *Metrics {
+	if p, ok := c.(*ctx); ok {
+		return p.metrics
+	}
+	return nil
+}
+
+// Counter returns the named counter, creating it if needed.  Panics if name is in use by another kind of series.
+func (m *Metrics) Counter(name string) *Counter {
+	if m == nil {
+		return nil
+	}
+	return getSeries(m, name, func(parent *Counter) *Counter {
+		c := &Counter{}
+		c.parent.Store(parent)
+		return c
+	}, (*Metrics).Counter)
+}
+
+// Gauge returns the named gauge, creating it if needed.  Panics if name is in use by another kind of series.
+func (m *Metrics) Gauge(name string) *Gauge {
+	if m == nil {
+		return nil
+	}
+	return getSeries(m, name, func(parent *Gauge) *Gauge {
+		g := &Gauge{}
+		g.parent.Store(parent)
+		return g
+	}, (*Metrics).Gauge)
+}
+
+// Histogram returns the named histogram, creating it with the given bucket upper bounds (or DefaultBuckets) if needed.
+// Panics if name is in use by another kind of series.
+func (m *Metrics) Histogram(name string, buckets []float64) *Histogram {
+	if m == nil {
+		return nil
+	}
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	return getSeries(m, name, func(parent *Histogram) *Histogram {
+		return &Histogram{
+			parent:  parent,
+			buckets: buckets,
+			counts:  make([]uint64, len(buckets)),
+		}
+	}, func(parent *Metrics, name string) *Histogram {
+		return parent.Histogram(name, buckets)
+	})
+}
+
+// getSeries returns the named series of m, creating it (and its counterparts in m's ancestors) if needed.
+func getSeries[S any](m *Metrics, name string, newSeries func(parent S) S, ofParent func(*Metrics, string) S) S {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, exists := m.series[name]; exists {
+		series, ok := existing.(S)
+		if !ok {
+			panic(fmt.Sprintf("task: metric %q is already a %T", name, existing))
+		}
+		return series
+	}
+
+	var parent S
+	if m.parent != nil {
+		parent = ofParent(m.parent, name)
+	}
+	series := newSeries(parent)
+	if m.series == nil {
+		m.series = make(map[string]any)
+	}
+	m.series[name] = series
+	return series
+}
+
+// attach links m to the Metrics of its new parent, rolling up existing values and observations.
+// Values recorded to m while it is being attached are rolled up, but may be counted twice by the parent.
+func (m *Metrics) attach(parent *Metrics) {
+	m.mu.Lock()
+	if m.parent == parent || parent == m {
+		m.mu.Unlock()
+		return
+	}
+	m.parent = parent
+	series := make(map[string]any, len(m.series))
+	for name, s := range m.series {
+		series[name] = s
+	}
+	m.mu.Unlock()
+
+	for name, s := range series {
+		switch s := s.(type) {
+		case *Counter:
+			to := parent.Counter(name)
+			s.parent.Store(to)
+			to.Add(s.Value())
+		case *Gauge:
+			to := parent.Gauge(name)
+			s.parent.Store(to)
+			to.Add(s.Value())
+		case *Histogram:
+			to := parent.Histogram(name, s.buckets)
+			s.mu.Lock()
+			s.parent = to
+			counts := append([]uint64(nil), s.counts...)
+			count, sum := s.count, s.sum
+			s.mu.Unlock()
+			to.merge(s.buckets, counts, count, sum)
+		}
+	}
+}
+
+// zeroGauges sets each gauge to 0, removing its contribution from ancestors -- called when its Context closes.
+func (m *Metrics) zeroGauges() {
+	m.forEach(func(name string, series any) {
+		if gauge, ok := series.(*Gauge); ok {
+			gauge.Set(0)
+		}
+	})
+}
+
+// forEach calls fn for each series in order of name.
+func (m *Metrics) forEach(fn func(name string, series any)) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	names := make([]string, 0, len(m.series))
+	for name := range m.series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	series := make([]any, len(names))
+	for i, name := range names {
+		series[i] = m.series[name]
+	}
+	m.mu.Unlock()
+
+	for i, name := range names {
+		fn(name, series[i])
+	}
+}
+
+// Values returns the current value of each series, where a histogram is reported as name_count and name_sum.
+func (m *Metrics) Values() map[string]float64 {
+	if m == nil {
+		return nil
+	}
+	values := make(map[string]float64)
+	m.forEach(func(name string, series any) {
+		switch s := series.(type) {
+		case *Counter:
+			values[name] = s.Value()
+		case *Gauge:
+			values[name] = s.Value()
+		case *Histogram:
+			count, sum := s.Totals()
+			values[name+"_count"] = float64(count)
+			values[name+"_sum"] = sum
+		}
+	})
+	return values
+}
+
+// Counter is a monotonically increasing value that rolls up into its Context's ancestors.
+type Counter struct {
+	bits   atomic.Uint64 // float64 bits
+	parent atomic.Pointer[Counter]
+}
+
+// Add adds v (which should be non-negative) to this counter and its ancestors.
+func (c *Counter) Add(v float64) {
+	for ; c != nil; c = c.parent.Load() {
+		addFloat(&c.bits, v)
+	}
+}
+
+func (c *Counter) Value() float64 {
+	if c == nil {
+		return 0
+	}
+	return math.Float64frombits(c.bits.Load())
+}
+
+// Gauge is a value that goes up and down, where the value of an ancestor's gauge is the sum of its descendants'.
+type Gauge struct {
+	bits   atomic.Uint64 // float64 bits
+	parent atomic.Pointer[Gauge]
+}
+
+// Add adds delta to this gauge and its ancestors.
+func (g *Gauge) Add(delta float64) {
+	for ; g != nil; g = g.parent.Load() {
+		addFloat(&g.bits, delta)
+	}
+}
+
+// Set sets this gauge, adjusting its ancestors by the change.
+func (g *Gauge) Set(v float64) {
+	if g == nil {
+		return
+	}
+	prev := math.Float64frombits(g.bits.Swap(math.Float64bits(v)))
+	g.parent.Load().Add(v - prev)
+}
+
+func (g *Gauge) Value() float64 {
+	if g == nil {
+		return 0
+	}
+	return math.Float64frombits(g.bits.Load())
+}
+
+// Histogram counts observations (such as latencies or sizes) in buckets and rolls up into its Context's ancestors.
+type Histogram struct {
+	mu      sync.Mutex
+	parent  *Histogram
+	buckets []float64 // upper bounds, ascending
+	counts  []uint64  // observations per bucket (not cumulative)
+	count   uint64
+	sum     float64
+}
+
+// Observe records v in this histogram and its ancestors.
+func (h *Histogram) Observe(v float64) {
+	for h != nil {
+		h.mu.Lock()
+		if i := sort.SearchFloat64s(h.buckets, v); i < len(h.counts) {
+			h.counts[i]++
+		}
+		h.count++
+		h.sum += v
+		parent := h.parent
+		h.mu.Unlock()
+		h = parent
+	}
+}
+
+// merge adds the given observations, bucketed by the given upper bounds, to this histogram and its ancestors.
+// A bucket is added to the first bucket of h whose upper bound is at or above its own.
+func (h *Histogram) merge(bounds []float64, counts []uint64, count uint64, sum float64) {
+	for h != nil {
+		h.mu.Lock()
+		for i, n := range counts {
+			if j := sort.SearchFloat64s(h.buckets, bounds[i]); j < len(h.counts) {
+				h.counts[j] += n
+			}
+		}
+		h.count += count
+		h.sum += sum
+		parent := h.parent
+		h.mu.Unlock()
+		h = parent
+	}
+}
+
+// Totals returns the number and sum of observations.
+func (h *Histogram) Totals() (count uint64, sum float64) {
+	if h == nil {
+		return 0, 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count, h.sum
+}
+
+// Buckets returns the upper bound of each bucket and the cumulative number of observations at or below it.
+func (h *Histogram) Buckets() (bounds []float64, cumulative []uint64) {
+	if h == nil {
+		return nil, nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	cumulative = make([]uint64, len(h.counts))
+	total := uint64(0)
+	for i, n := range h.counts {
+		total += n
+		cumulative[i] = total
+	}
+	return h.buckets, cumulative
+}
+
+func addFloat(bits *atomic.Uint64, delta float64) {
+	for {
+		old := bits.Load()
+		if bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
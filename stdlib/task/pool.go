@@ -0,0 +1,293 @@
+package task
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PoolOpts specifies a worker Pool.
+type PoolOpts struct {
+	Label     string // label of the pool's Context
+	Size      int    // max number of jobs running at once
+	MaxQueued int    // max number of jobs waiting to run before Submit() blocks (0 for no limit)
+}
+
+// DefaultPoolOpts is a suggested set of options.
+func DefaultPoolOpts() PoolOpts {
+	return PoolOpts{
+		Label:     "pool",
+		Size:      8,
+		MaxQueued: 1024,
+	}
+}
+
+// Pool runs submitted jobs with bounded concurrency, where each running job is a child Context of the pool's Context
+// and so appears in the Context tree.  Queued jobs consume no goroutine until they run.
+//
+// The pool closes when its parent closes or Close() is called, whereupon running jobs are closed and queued jobs fail with ErrClosed.
+type Pool struct {
+	ctx  Context
+	opts PoolOpts
+
+	mu      sync.Mutex
+	cond    *sync.Cond // signaled when the queue shrinks or the pool is closing
+	queue   []*Job     // jobs waiting to run
+	running int        // number of jobs started and not yet closed
+	closing bool
+}
+
+// Job is a function submitted to a Pool.
+type Job struct {
+	pool  *Pool
+	label string
+	fn    func(ctx Context)
+
+	ctx       Context     // set once running
+	completed atomic.Bool // set if fn returned before the job's Context began closing
+	canceled  bool
+	err       error
+	done      chan struct{}
+}
+
+// StartPool starts a Pool as a child of the given parent running up to size jobs at once -- see DefaultPoolOpts()
+func StartPool(parent Context, size int) (*Pool, error) {
+	opts := DefaultPoolOpts()
+	opts.Size = size
+	return StartPoolWithOpts(parent, opts)
+}
+
+// StartPoolWithOpts starts a Pool as a child of the given parent.
+func StartPoolWithOpts(parent Context, opts PoolOpts) (*Pool, error) {
+	if opts.Size <= 0 {
+		opts.Size = DefaultPoolOpts().Size
+	}
+	pool := &Pool{
+		opts: opts,
+	}
+	pool.cond = sync.NewCond(&pool.mu)
+
+	var err error
+	pool.ctx, err = parent.StartChild(&Task{
+		Info: Info{
+			Label: opts.Label,
+		},
+		OnClosing: pool.onClosing,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// Context returns the pool's Context, the parent of each running job.
+func (pool *Pool) Context() Context {
+	return pool.ctx
+}
+
+// Close closes the pool's Context -- see Pool.
+func (pool *Pool) Close() error {
+	return pool.ctx.Close()
+}
+
+// Len returns the number of running and queued jobs.
+func (pool *Pool) Len() (running, queued int) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	return pool.running, len(pool.queue)
+}
+
+// Submit queues the given function to run as a child Context of the pool, blocking while MaxQueued jobs are waiting to run.
+// ErrClosed is returned if the pool is closing.
+func (pool *Pool) Submit(label string, fn func(ctx Context)) (*Job, error) {
+	return pool.submit(label, fn, true)
+}
+
+// TrySubmit is like Submit() but returns ErrPoolFull rather than blocking.
+func (pool *Pool) TrySubmit(label string, fn func(ctx Context)) (*Job, error) {
+	return pool.submit(label, fn, false)
+}
+
+func (pool *Pool) submit(label string, fn func(ctx Context), block bool) (*Job, error) {
+	job := &Job{
+		pool:  pool,
+		label: label,
+		fn:    fn,
+		done:  make(chan struct{}),
+	}
+
+	pool.mu.Lock()
+	for !pool.isClosing() && pool.opts.MaxQueued > 0 && len(pool.queue) >= pool.opts.MaxQueued {
+		if !block {
+			pool.mu.Unlock()
+			return nil, ErrPoolFull
+		}
+		pool.cond.Wait()
+	}
+	if pool.isClosing() {
+		pool.mu.Unlock()
+		return nil, ErrClosed
+	}
+	pool.queue = append(pool.queue, job)
+	ready := pool.dequeueReady()
+	pool.mu.Unlock()
+
+	pool.startJobs(ready)
+	return job, nil
+}
+
+// isClosing returns true once the pool's Context has begun closing, even if onClosing() has yet to run.  Caller must hold pool.mu.
+func (pool *Pool) isClosing() bool {
+	if !pool.closing {
+		select {
+		case <-pool.ctx.Closing():
+			pool.closing = true
+		default:
+		}
+	}
+	return pool.closing
+}
+
+// dequeueReady pops the queued jobs that may start now.  Caller must hold pool.mu.
+func (pool *Pool) dequeueReady() []*Job {
+	var ready []*Job
+	for pool.running < pool.opts.Size && len(pool.queue) > 0 {
+		ready = append(ready, pool.queue[0])
+		pool.queue[0] = nil
+		pool.queue = pool.queue[1:]
+		pool.running++
+	}
+	if len(ready) > 0 {
+		pool.cond.Broadcast()
+	}
+	return ready
+}
+
+func (pool *Pool) startJobs(ready []*Job) {
+	for _, job := range ready {
+		jobCtx, err := pool.ctx.StartChild(&Task{
+			Info: Info{
+				Label:     job.label,
+				IdleClose: time.Nanosecond,
+			},
+			OnRun: job.run,
+		})
+		if err != nil { // the pool is closing
+			pool.mu.Lock()
+			pool.running--
+			pool.mu.Unlock()
+			job.finish(ErrClosed)
+			continue
+		}
+
+		pool.mu.Lock()
+		job.ctx = jobCtx
+		canceled := job.canceled
+		pool.mu.Unlock()
+		if canceled {
+			jobCtx.Close()
+		}
+
+		go func() {
+			<-jobCtx.Done()
+			pool.jobClosed(job)
+		}()
+	}
+}
+
+// jobClosed is called once a running job's Context has closed, starting the next queued jobs.
+func (pool *Pool) jobClosed(job *Job) {
+	pool.mu.Lock()
+	pool.running--
+	err := job.ctx.Err()
+	switch {
+	case job.completed.Load():
+		err = nil
+	case job.canceled:
+		err = context.Canceled
+	case err == nil || err == context.Canceled:
+		err = ErrClosed // cut off by the pool closing
+	}
+	var ready []*Job
+	if !pool.closing {
+		ready = pool.dequeueReady()
+	}
+	pool.mu.Unlock()
+
+	job.finish(err)
+	pool.startJobs(ready)
+}
+
+func (pool *Pool) onClosing() {
+	pool.mu.Lock()
+	queued := pool.queue
+	pool.queue = nil
+	pool.closing = true
+	pool.cond.Broadcast()
+	pool.mu.Unlock()
+
+	for _, job := range queued {
+		job.finish(ErrClosed)
+	}
+}
+
+// Cancel removes the job if it is queued or closes its Context if it is running.
+// Err() then returns context.Canceled unless the job had already completed.
+func (job *Job) Cancel() {
+	pool := job.pool
+
+	pool.mu.Lock()
+	if job.canceled {
+		pool.mu.Unlock()
+		return
+	}
+	job.canceled = true
+	jobCtx := job.ctx
+	dequeued := false
+	for i, queued := range pool.queue {
+		if queued == job {
+			pool.queue = append(pool.queue[:i], pool.queue[i+1:]...)
+			pool.cond.Broadcast()
+			dequeued = true
+			break
+		}
+	}
+	pool.mu.Unlock()
+
+	if dequeued {
+		job.finish(context.Canceled)
+	} else if jobCtx != nil {
+		jobCtx.Close()
+	}
+}
+
+// Done is signaled once the job has completed, been canceled, or failed to run.
+func (job *Job) Done() <-chan struct{} {
+	return job.done
+}
+
+// Err returns nil if the job ran to completion, context.Canceled if canceled, ErrClosed if the pool closed before it ran or
+// while it was running, or the error its Context closed with (e.g. a PanicError).  Err returns nil until Done() is signaled.
+func (job *Job) Err() error {
+	select {
+	case <-job.done:
+		return job.err
+	default:
+		return nil
+	}
+}
+
+func (job *Job) run(ctx Context) {
+	job.fn(ctx)
+	select {
+	case <-ctx.Closing():
+	default:
+		job.completed.Store(true)
+	}
+}
+
+func (job *Job) finish(err error) {
+	job.err = err
+	close(job.done)
+}
@@ -0,0 +1,161 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Schedule determines when a scheduled task runs -- see StartScheduled().
+type Schedule interface {
+
+	// Next returns the first run time after the given time, or the zero time if there are no more runs.
+	Next(after time.Time) time.Time
+}
+
+// Every returns a Schedule running at a fixed interval, starting one interval from when the scheduled task starts.
+func Every(interval time.Duration) Schedule {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return everySchedule(interval)
+}
+
+type everySchedule time.Duration
+
+func (every everySchedule) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(every))
+}
+
+// At returns a Schedule running once at the given time, or immediately if the time has passed when the scheduled task starts.
+func At(when time.Time) Schedule {
+	return atSchedule{when}
+}
+
+type atSchedule struct {
+	when time.Time
+}
+
+func (at atSchedule) Next(after time.Time) time.Time {
+	if at.when.After(after) {
+		return at.when
+	}
+	return time.Time{}
+}
+
+// MissedRuns specifies what a scheduled task does when one or more run times pass without a run,
+// such as when the host was suspended or a run (or OverlapWait) took longer than the interval.
+type MissedRuns int32
+
+const (
+	MissedSkip    MissedRuns = iota // missed runs are skipped and the schedule resumes from the present
+	MissedRunOnce                   // a single run occurs immediately in place of all the missed runs
+)
+
+// Overlap specifies what a scheduled task does when a run is due while the previous run is still running.
+type Overlap int32
+
+const (
+	OverlapSkip  Overlap = iota // the run is skipped
+	OverlapWait                 // the run starts once the previous run completes
+	OverlapAllow                // the run starts regardless
+)
+
+// ScheduleOpts specifies a scheduled task -- see StartScheduled().
+type ScheduleOpts struct {
+	Label    string
+	Schedule Schedule      // Every(), At(), ParseCron(), or a custom Schedule
+	Jitter   time.Duration // if > 0, each run is delayed by a random duration in [0, Jitter) to spread load
+	Missed   MissedRuns
+	Overlap  Overlap
+}
+
+// StartScheduled starts a child Context of parent that runs fn in a new child Context each time the schedule comes due.
+// The scheduled Context closes once the schedule has no more runs (or it begins draining) and the last run is complete,
+// or when it or its parent is closed, whereupon any run in progress is closed.
+func StartScheduled(parent Context, opts ScheduleOpts, fn func(ctx Context)) (Context, error) {
+	if opts.Schedule == nil {
+		return nil, errors.New("task: no Schedule given")
+	}
+	return parent.StartChild(&Task{
+		Info: Info{
+			Label:     opts.Label,
+			IdleClose: time.Nanosecond,
+		},
+		OnRun: func(ctx Context) {
+			runSchedule(ctx, opts, fn)
+		},
+	})
+}
+
+func runSchedule(ctx Context, opts ScheduleOpts, fn func(ctx Context)) {
+	var (
+		timer Timer
+		prev  Context // the most recent run
+	)
+	runCount := 0
+	clock := ctx.Info().Clock
+
+	due := opts.Schedule.Next(clock.Now())
+	if at, isAt := opts.Schedule.(atSchedule); isAt && due.IsZero() {
+		due = at.when // a one-shot in the past runs immediately
+	}
+
+	for !due.IsZero() {
+		delay := due.Sub(clock.Now())
+		if opts.Jitter > 0 {
+			delay += rand.N(opts.Jitter)
+		}
+		if delay > 0 {
+			if timer == nil {
+				timer = clock.NewTimer(delay)
+				defer timer.Stop()
+			} else {
+				timer.Reset(delay)
+			}
+			select {
+			case <-timer.C():
+			case <-ctx.Draining():
+				return
+			}
+		}
+
+		run := true
+		if prev != nil {
+			select {
+			case <-prev.Done():
+			default:
+				switch opts.Overlap {
+				case OverlapSkip:
+					run = false
+				case OverlapWait:
+					select {
+					case <-prev.Done():
+					case <-ctx.Closing():
+						return
+					}
+				}
+			}
+		}
+		if run {
+			runCount++
+			var err error
+			if prev, err = ctx.Go(fmt.Sprintf("%s#%d", ctx.Log().GetLogLabel(), runCount), fn); err != nil {
+				return // closing
+			}
+		}
+
+		// determine the next run, handling run times that have already passed
+		now := clock.Now()
+		next := opts.Schedule.Next(due)
+		if !next.IsZero() && !next.After(now) {
+			if opts.Missed == MissedRunOnce {
+				next = now
+			} else {
+				next = opts.Schedule.Next(now)
+			}
+		}
+		due = next
+	}
+}
@@ -0,0 +1,92 @@
+package task
+
+import (
+	"time"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/log"
+)
+
+// Shutdown politely stops the given Context and blocks until it is done:
+//  1. Draining() is signaled for c and its descendants, which should finish their in-flight work and close,
+//  2. Shutdown waits until c has no children or the timeout passes, and then
+//  3. c is closed, where children that overran the timeout are force-closed with ErrDrainTimeout.
+//
+// If any children overran, ErrDrainTimeout is returned along with a Snapshot of each, taken as the timeout passed.
+// The timeout is measured by c's Info.Clock.
+func Shutdown(c Context, timeout time.Duration) (overran []Snapshot, err error) {
+	if p, ok := c.(*ctx); ok {
+		p.drain()
+	}
+
+	clk := c.Info().Clock
+	if clk == nil {
+		clk = clock.System
+	}
+	timer := clk.NewTimer(timeout)
+	defer timer.Stop()
+
+	var subBuf [20]Context
+	for expired := false; !expired; {
+		children := c.GetChildren(subBuf[:0])
+		if len(children) == 0 {
+			break
+		}
+		for _, child := range children {
+			select {
+			case <-child.Done():
+				continue
+			case <-timer.C():
+				expired = true
+			case <-c.Closing():
+				expired = true // closed by other means
+			}
+			break
+		}
+	}
+
+	for _, child := range c.GetChildren(subBuf[:0]) {
+		select {
+		case <-child.Done():
+		default:
+			overran = append(overran, takeSnapshot(child, 0))
+		}
+	}
+
+	if len(overran) > 0 {
+		c.CloseWithCause(ErrDrainTimeout)
+		err = ErrDrainTimeout
+	} else {
+		c.Close()
+	}
+	<-c.Done()
+	return overran, err
+}
+
+// ShutdownOnInterrupt blocks until the given Context is done, calling Shutdown() upon the first interrupt or termination signal
+// and force-closing c upon repeated signals -- see log.AwaitInterrupt()
+func ShutdownOnInterrupt(c Context, timeout time.Duration) {
+	first, repeated := log.AwaitInterrupt()
+
+	select {
+	case <-first:
+	case <-c.Done():
+		return
+	}
+
+	go func() {
+		overran, err := Shutdown(c, timeout)
+		if err != nil {
+			for _, snap := range overran {
+				c.Log().Warnf("shutdown: %q (TID %d) overran %v", snap.Label, snap.TID, timeout)
+			}
+		}
+	}()
+
+	select {
+	case <-repeated:
+		c.CloseWithCause(ErrDrainTimeout)
+	case <-c.Done():
+	}
+	<-c.Done()
+}
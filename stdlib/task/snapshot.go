@@ -0,0 +1,208 @@
+package task
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Snapshot is a structured and JSON-serializable view of a Context and (optionally) its descendants -- see TakeSnapshot().
+type Snapshot struct {
+	TID         int64              `json:"tid"`
+	ParentTID   int64              `json:"parentTid,omitempty"`
+	Label       string             `json:"label"`
+	TagID       string             `json:"tagId,omitempty"`       // Info.TagID in base32, if set
+	State       string             `json:"state"`                 // "running", "closing", or "closed"
+	Started     time.Time          `json:"started"`               // when the Context was started
+	Age         time.Duration      `json:"age"`                   // time since started (at the time of the snapshot)
+	NumChildren int                `json:"numChildren"`           // number of children, even if Children is not populated
+	IdleClose   time.Duration      `json:"idleClose,omitempty"`   // Info.IdleClose
+	IdlePending bool               `json:"idlePending,omitempty"` // set if CloseWhenIdle() is pending
+	Running     bool               `json:"running"`               // set while Task.OnRun() is executing
+	Err         string             `json:"err,omitempty"`         // the close cause, if closing with an error other than context.Canceled
+	Metrics     map[string]float64 `json:"metrics,omitempty"`     // values of Info.Metrics, if set
+	Children    []Snapshot         `json:"children,omitempty"`
+}
+
+// TakeSnapshot returns a Snapshot of the given Context and its descendants up to maxDepth levels below it (or all if maxDepth < 0).
+func TakeSnapshot(ctx Context, maxDepth int) Snapshot {
+	return takeSnapshot(ctx, maxDepth)
+}
+
+func takeSnapshot(c Context, maxDepth int) Snapshot {
+	var subBuf [20]Context
+	children := c.GetChildren(subBuf[:0])
+
+	info := c.Info()
+	snap := Snapshot{
+		TID:         info.TID,
+		Label:       c.Log().GetLogLabel(),
+		IdleClose:   info.IdleClose,
+		NumChildren: len(children),
+		State:       "running",
+	}
+	if info.TagID.IsSet() {
+		snap.TagID = info.TagID.Base32()
+	}
+	if info.Metrics != nil {
+		snap.Metrics = info.Metrics.Values()
+	}
+
+	if p, ok := c.(*ctx); ok {
+		if p.parent != nil {
+			snap.ParentTID = p.parent.task.Info.TID
+		}
+		switch atomic.LoadInt32(&p.state) {
+		case Closing:
+			snap.State = "closing"
+		case Closed:
+			snap.State = "closed"
+		}
+		snap.Started = p.started
+		snap.Age = p.task.Info.Clock.Now().Sub(p.started)
+		snap.IdlePending = p.idleCloseRetry.Load() > 0
+		snap.Running = p.running.Load()
+
+		p.subsMu.Lock() // closeWithErr() sets err under subsMu
+		if err := p.err; err != nil {
+			snap.Err = err.Error()
+		}
+		p.subsMu.Unlock()
+	} else {
+		select {
+		case <-c.Done():
+			snap.State = "closed"
+		case <-c.Closing():
+			snap.State = "closing"
+		default:
+		}
+	}
+
+	if maxDepth != 0 && len(children) > 0 {
+		snap.Children = make([]Snapshot, len(children))
+		for i, child := range children {
+			snap.Children[i] = takeSnapshot(child, maxDepth-1)
+		}
+	}
+	return snap
+}
+
+// EventKind identifies what an Event reports.
+type EventKind int32
+
+const (
+	EventStarted EventKind = iota + 1 // a Context was started
+	EventClosed                       // a Context is fully closed (Done)
+)
+
+func (kind EventKind) String() string {
+	switch kind {
+	case EventStarted:
+		return "started"
+	case EventClosed:
+		return "closed"
+	}
+	return "unknown"
+}
+
+func (kind EventKind) MarshalText() ([]byte, error) {
+	return []byte(kind.String()), nil
+}
+
+// Event reports a Context starting or closing to a Watch() feed.
+type Event struct {
+	Kind    EventKind `json:"kind"`
+	Time    time.Time `json:"time"`
+	Context Snapshot  `json:"context"`           // the Context's state (without children) at the time of the event
+	Dropped uint64    `json:"dropped,omitempty"` // number of events dropped before this one because the feed's buffer was full
+}
+
+// Watch returns a feed of Events for root and its descendants (or for all Contexts if root is nil), starting with those that follow.
+// root is identified by its Info.TID, so it may be a type embedding a Context (such as an app instance).
+// Events are dropped rather than block the Contexts reporting them, so the feed should be read promptly (see Event.Dropped).
+// The returned stop func ends the feed and closes the channel.
+func Watch(root Context, bufSize int) (feed <-chan Event, stop func()) {
+	if bufSize < 1 {
+		bufSize = 1
+	}
+	w := &watcher{
+		ch: make(chan Event, bufSize),
+	}
+	if root != nil {
+		w.rootTID = root.Info().TID
+		w.scoped = true
+	}
+
+	gWatchers.mu.Lock()
+	gWatchers.list = append(gWatchers.list, w)
+	gWatchers.count.Store(int32(len(gWatchers.list)))
+	gWatchers.mu.Unlock()
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			gWatchers.mu.Lock()
+			defer gWatchers.mu.Unlock()
+			for i, wi := range gWatchers.list {
+				if wi == w {
+					gWatchers.list = append(gWatchers.list[:i], gWatchers.list[i+1:]...)
+					break
+				}
+			}
+			gWatchers.count.Store(int32(len(gWatchers.list)))
+			close(w.ch)
+		})
+	}
+	return w.ch, stop
+}
+
+type watcher struct {
+	rootTID int64 // TID of the watched root if scoped
+	scoped  bool  // false to watch all
+	ch      chan Event
+	dropped uint64
+}
+
+var gWatchers struct {
+	mu    sync.Mutex
+	list  []*watcher
+	count atomic.Int32 // len(list), allowing emitEvent() to return early when there are no watchers
+}
+
+// emitEvent reports the given Context to each watcher whose root is p or one of its ancestors.
+func (p *ctx) emitEvent(kind EventKind) {
+	if gWatchers.count.Load() == 0 {
+		return
+	}
+
+	ev := Event{
+		Kind:    kind,
+		Time:    p.task.Info.Clock.Now(),
+		Context: takeSnapshot(p, 0),
+	}
+
+	gWatchers.mu.Lock()
+	defer gWatchers.mu.Unlock()
+	for _, w := range gWatchers.list {
+		if w.scoped && !p.descendsFrom(w.rootTID) {
+			continue
+		}
+		ev.Dropped = w.dropped
+		select {
+		case w.ch <- ev:
+			w.dropped = 0
+		default:
+			w.dropped++
+		}
+	}
+}
+
+// descendsFrom returns true if p is the Context having the given TID or one of its descendants.
+func (p *ctx) descendsFrom(ancestorTID int64) bool {
+	for c := p; c != nil; c = c.parent {
+		if c.task.Info.TID == ancestorTID {
+			return true
+		}
+	}
+	return false
+}
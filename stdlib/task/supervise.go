@@ -0,0 +1,154 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PanicError is the cause of a Context closed due to a panic in its OnStart() or OnRun() -- see errors.As()
+type PanicError struct {
+	Value any    // value passed to panic()
+	Stack []byte // stack of the panicking goroutine
+}
+
+func (err *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", err.Value)
+}
+
+// recoverCall calls fn, recovering a panic into a PanicError which is logged along with its stack.
+func (p *ctx) recoverCall(hook string, fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			perr := &PanicError{
+				Value: r,
+				Stack: debug.Stack(),
+			}
+			p.log.Errorf("%s: %v\n%s", hook, perr, perr.Stack)
+			err = perr
+		}
+	}()
+	fn()
+	return nil
+}
+
+// supervisor restarts the children of a Context according to its Task.Supervision.
+type supervisor struct {
+	Supervision
+
+	mu       sync.Mutex
+	restarts []time.Time       // recent restart times within Period
+	stopped  map[*ctx]struct{} // children closed by OneForAll, restarted with the child that caused it
+}
+
+func newSupervisor(opts *Supervision) *supervisor {
+	return &supervisor{
+		Supervision: *opts,
+		stopped:     make(map[*ctx]struct{}),
+	}
+}
+
+// childClosed is called once a child of p is fully closed but before p stops waiting on it, restarting children as needed.
+func (sup *supervisor) childClosed(p *ctx, child *ctx) {
+	sup.mu.Lock()
+	defer sup.mu.Unlock()
+
+	if _, stopped := sup.stopped[child]; stopped {
+		delete(sup.stopped, child)
+		return
+	}
+
+	cause := child.err
+	failed := cause != nil && cause != context.Canceled
+	p.subsMu.Lock()
+	draining := p.draining
+	p.subsMu.Unlock()
+
+	switch {
+	case draining,
+		sup.Restart == RestartNever,
+		sup.Restart == RestartOnFailure && !failed,
+		atomic.LoadInt32(&p.state) != Running:
+		return
+	}
+
+	// prune restarts outside the period and enforce MaxRestarts
+	clock := p.task.Info.Clock
+	now := clock.Now()
+	recent := sup.restarts[:0]
+	for _, t := range sup.restarts {
+		if sup.Period <= 0 || now.Sub(t) < sup.Period {
+			recent = append(recent, t)
+		}
+	}
+	sup.restarts = recent
+	if sup.MaxRestarts > 0 && len(recent) >= sup.MaxRestarts {
+		if cause == nil {
+			cause = context.Canceled
+		}
+		go p.CloseWithCause(fmt.Errorf("%w: %s: %w", ErrMaxRestarts, child.task.Info.Label, cause))
+		return
+	}
+	sup.restarts = append(sup.restarts, now)
+
+	var backoff time.Duration
+	if sup.MinBackoff > 0 {
+		backoff = sup.MinBackoff << min(len(recent), 30)
+		if backoff <= 0 || (sup.MaxBackoff > 0 && backoff > sup.MaxBackoff) {
+			backoff = sup.MaxBackoff
+		}
+	}
+
+	// The failed child is restarted first, followed by its siblings in the order they were started.
+	specs := []Task{child.spec}
+	var siblings []*ctx
+
+	p.subsMu.Lock()
+	if atomic.LoadInt32(&p.state) != Running {
+		p.subsMu.Unlock()
+		return
+	}
+	if sup.Strategy == OneForAll {
+		for _, sub := range p.subs {
+			if sib, ok := sub.(*ctx); ok && sib != child {
+				if _, stopped := sup.stopped[sib]; !stopped {
+					sup.stopped[sib] = struct{}{}
+					siblings = append(siblings, sib)
+				}
+			}
+		}
+	}
+	p.busy.Add(1) // held until the restart is complete so that p does not close idle in the meantime
+	p.subsMu.Unlock()
+
+	go func() {
+		defer p.busy.Done()
+
+		for _, sib := range siblings {
+			sib.Close()
+		}
+		for _, sib := range siblings {
+			<-sib.Done()
+			specs = append(specs, sib.spec)
+		}
+
+		if backoff > 0 {
+			timer := clock.NewTimer(backoff)
+			defer timer.Stop()
+			select {
+			case <-timer.C():
+			case <-p.Closing():
+				return
+			}
+		}
+
+		for i := range specs {
+			if _, err := p.StartChild(&specs[i]); err != nil && err != ErrNotStarted {
+				p.log.Warnf("restart of %q failed: %v", specs[i].Info.Label, err)
+			}
+		}
+	}()
+}
@@ -58,11 +58,20 @@ func PrintTreePeriodically(ctx Context, period time.Duration, verboseLevel int32
 // Writes pretty and indented debug state info of a given verbosity level.
 // If out == nil, the text output is instead directed to this context's logger.Info()
 func PrintContextTree(ctx Context, out io.Writer, verboseLevel int32) {
+	printTree(ctx, out, verboseLevel, false)
+}
+
+// PrintContextTreeWithMetrics is PrintContextTree() where each Context having Metrics is followed by their values -- see Info.Metrics.
+func PrintContextTreeWithMetrics(ctx Context, out io.Writer, verboseLevel int32) {
+	printTree(ctx, out, verboseLevel, true)
+}
+
+func printTree(ctx Context, out io.Writer, verboseLevel int32, withMetrics bool) {
 	buf := new(strings.Builder)
 	buf.WriteString("task.Context tree:\n")
 
 	var prefixBuf [256]rune
-	printContextTree(ctx, buf, 0, prefixBuf[:0], true)
+	printContextTree(ctx, buf, 0, prefixBuf[:0], true, withMetrics)
 	outStr := buf.String()
 	if out != nil {
 		out.Write([]byte(outStr))
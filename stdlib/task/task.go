@@ -9,24 +9,33 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
 	"github.com/art-media-platform/amp-sdk-go/stdlib/log"
 )
 
 // ctx implements Context
 type ctx struct {
+	parent         *ctx // nil if no parent
 	log            log.Logger
 	task           Task
+	spec           Task        // the Task this Context was started with, used to restart it -- see Supervision
+	super          *supervisor // non-nil if Task.Supervision is set
+	started        time.Time   // when StartChild() was called
+	running        atomic.Bool // set while OnRun() is executing
+	metrics        *Metrics    // Info.Metrics if given, otherwise the nearest ancestor's (or nil)
 	state          int32
 	idle           bool
 	idleCloseRetry atomic.Int64 // time.Duration
 	idleCloseMin   time.Time
 
-	chClosing chan struct{}  // signals Close() has been called and close execution has begun.
-	chClosed  chan struct{}  // signals Close() has been called and all close execution is done.
-	err       error          // See context.Err() for spec
-	busy      sync.WaitGroup // blocks until all execution is complete
-	subsMu    sync.Mutex     // Locked when .subs is being accessed
-	subs      []Context
+	chDraining chan struct{}  // signals Shutdown() of this or an ancestor has begun or this Context is closing.
+	draining   bool           // set once chDraining is closed (guarded by subsMu)
+	chClosing  chan struct{}  // signals Close() has been called and close execution has begun.
+	chClosed   chan struct{}  // signals Close() has been called and all close execution is done.
+	err        error          // See context.Err() for spec -- set when closing begins (nil denotes context.Canceled)
+	busy       sync.WaitGroup // blocks until all execution is complete
+	subsMu     sync.Mutex     // Locked when .subs is being accessed
+	subs       []Context
 }
 
 // Errors
@@ -34,21 +43,83 @@ var (
 	ErrAlreadyStarted = errors.New("already started")
 	ErrNotStarted     = errors.New("not started")
 	ErrClosed         = errors.New("closed")
+	ErrMaxRestarts    = errors.New("max restarts exceeded")
+	ErrPoolFull       = errors.New("pool queue full")
+	ErrDrainTimeout   = errors.New("drain timeout")
 )
 
 var gInstanceCount = int64(0)
 
 func (p *ctx) Close() error {
+	p.closeWithErr(nil)
+	return nil
+}
+
+func (p *ctx) CloseWithCause(cause error) {
+	if cause != nil && cause != context.Canceled && cause != context.DeadlineExceeded {
+		cause = &closeCause{cause}
+	}
+	p.closeWithErr(cause)
+}
+
+// closeCause wraps the cause given to CloseWithCause() so that it also satisfies errors.Is(err, context.Canceled).
+type closeCause struct {
+	cause error
+}
+
+func (err *closeCause) Error() string {
+	return err.cause.Error()
+}
+
+func (err *closeCause) Unwrap() error {
+	return err.cause
+}
+
+func (err *closeCause) Is(target error) bool {
+	return target == context.Canceled
+}
+
+// closeWithErr initiates Close() where err (if non-nil) is returned by Err() once closed.
+// Only the first close takes effect.
+//
+// subsMu is held so that StartChild() does not add a child once closing has begun (and busy.Wait() may be underway).
+func (p *ctx) closeWithErr(err error) {
+	p.subsMu.Lock()
+	defer p.subsMu.Unlock()
+
 	first := atomic.CompareAndSwapInt32(&p.state, Running, Closing)
 	if first {
+		p.err = err
+		if !p.draining {
+			p.draining = true
+			close(p.chDraining)
+		}
 		close(p.chClosing)
 	}
-	return nil
+}
+
+// drain signals Draining() for p and its descendants.
+func (p *ctx) drain() {
+	p.subsMu.Lock()
+	if p.draining {
+		p.subsMu.Unlock()
+		return
+	}
+	p.draining = true
+	close(p.chDraining)
+	subs := append([]Context(nil), p.subs...)
+	p.subsMu.Unlock()
+
+	for _, sub := range subs {
+		if child, ok := sub.(*ctx); ok {
+			child.drain()
+		}
+	}
 }
 
 func (p *ctx) PreventIdleClose(delay time.Duration) bool {
 	p.subsMu.Lock()
-	p.idleCloseMin = time.Now().Add(delay)
+	p.idleCloseMin = p.task.Info.Clock.Now().Add(delay)
 	p.idle = false
 	p.subsMu.Unlock()
 
@@ -74,10 +145,12 @@ func (p *ctx) CloseWhenIdle(delay time.Duration) {
 	}
 
 	go func() {
-		var timer *time.Timer
+		var timer Timer
 
 		for idleClose := true; idleClose; {
+			p.subsMu.Lock()
 			p.idle = true
+			p.subsMu.Unlock()
 			p.busy.Wait() // wait until there is a chance of catching ctx idle
 
 			retry := false
@@ -90,7 +163,7 @@ func (p *ctx) CloseWhenIdle(delay time.Duration) {
 				idleClose = false
 			} else {
 				if !p.idleCloseMin.IsZero() {
-					minDelay := time.Until(p.idleCloseMin)
+					minDelay := p.idleCloseMin.Sub(p.task.Info.Clock.Now())
 					if minDelay <= 0 {
 						p.idleCloseMin = time.Time{}
 					}
@@ -108,12 +181,12 @@ func (p *ctx) CloseWhenIdle(delay time.Duration) {
 
 			if delay > 0 {
 				if timer == nil {
-					timer = time.NewTimer(delay)
+					timer = p.task.Info.Clock.NewTimer(delay)
 				} else {
 					timer.Reset(delay)
 				}
 				select {
-				case <-timer.C:
+				case <-timer.C():
 				case <-p.Closing():
 					idleClose = false
 				}
@@ -123,18 +196,20 @@ func (p *ctx) CloseWhenIdle(delay time.Duration) {
 			// Note in the case that we're closing, the below has no effect
 			if idleClose {
 				p.subsMu.Lock()
-				if p.idle {
+				isIdle := p.idle
+				p.subsMu.Unlock()
+				if isIdle {
 					p.Close()
 					idleClose = false
 				}
-				p.subsMu.Unlock()
 			}
 		}
 	}()
 }
 
 func (p *ctx) Deadline() (deadline time.Time, ok bool) {
-	return time.Time{}, false
+	deadline = p.task.Info.Deadline
+	return deadline, !deadline.IsZero()
 }
 
 func (p *ctx) Err() error {
@@ -150,6 +225,11 @@ func (p *ctx) Err() error {
 }
 
 func (p *ctx) Value(key interface{}) interface{} {
+	for c := p; c != nil; c = c.parent {
+		if val, exists := c.task.Info.Values[key]; exists {
+			return val
+		}
+	}
 	return nil
 }
 
@@ -161,7 +241,7 @@ func (p *ctx) Log() log.Logger {
 	return p.log
 }
 
-func printContextTree(ctx Context, out *strings.Builder, depth int, prefix []rune, lastChild bool) {
+func printContextTree(ctx Context, out *strings.Builder, depth int, prefix []rune, lastChild, withMetrics bool) {
 	icon := ' '
 	if depth > 0 {
 		icon = '┣'
@@ -171,7 +251,11 @@ func printContextTree(ctx Context, out *strings.Builder, depth int, prefix []run
 	}
 	taskInfo := ctx.Info()
 	prefix = append(prefix, icon, ' ')
-	out.WriteString(fmt.Sprintf("%04d%s%s\n", taskInfo.TID, string(prefix), ctx.Log().GetLogLabel()))
+	out.WriteString(fmt.Sprintf("%04d%s%s", taskInfo.TID, string(prefix), ctx.Log().GetLogLabel()))
+	if withMetrics && taskInfo.Metrics != nil {
+		out.WriteString(formatMetrics(taskInfo.Metrics))
+	}
+	out.WriteByte('\n')
 	icon = '┃'
 	if lastChild {
 		icon = ' '
@@ -181,7 +265,7 @@ func printContextTree(ctx Context, out *strings.Builder, depth int, prefix []run
 	var subBuf [20]Context
 	children := ctx.GetChildren(subBuf[:0])
 	for i, ci := range children {
-		printContextTree(ci, out, depth+1, prefix, i == len(children)-1)
+		printContextTree(ci, out, depth+1, prefix, i == len(children)-1, withMetrics)
 	}
 }
 
@@ -206,22 +290,72 @@ func (p *ctx) StartChild(task *Task) (Context, error) {
 	if info.Label == "" {
 		info.Label = fmt.Sprintf("ctx_%d", task.Info.TID)
 	}
+
+	// the clock is inherited from the parent unless given
+	clk := task.Info.Clock
+	if clk == nil {
+		if p != nil {
+			clk = p.task.Info.Clock
+		} else {
+			clk = clock.System
+		}
+	}
+	now := clk.Now()
+
+	// the effective deadline is the earliest of the task's deadline, its timeout, and the parent's deadline
+	deadline := task.Info.Deadline
+	if timeout := task.Info.Timeout; timeout > 0 {
+		if dl := now.Add(timeout); deadline.IsZero() || dl.Before(deadline) {
+			deadline = dl
+		}
+	}
+	if p != nil {
+		if dl, ok := p.Deadline(); ok && (deadline.IsZero() || dl.Before(deadline)) {
+			deadline = dl
+		}
+	}
+
 	child := &ctx{
-		log:       log.NewLogger(info.Label),
-		state:     Running,
-		task:      *task,
-		chClosing: make(chan struct{}),
-		chClosed:  make(chan struct{}),
+		parent:     p,
+		log:        log.NewLogger(info.Label),
+		state:      Running,
+		task:       *task,
+		spec:       *task,
+		started:    now,
+		chDraining: make(chan struct{}),
+		chClosing:  make(chan struct{}),
+		chClosed:   make(chan struct{}),
+	}
+	child.task.Info.Deadline = deadline
+	child.task.Info.Clock = clk
+
+	// Metrics roll up into the nearest ancestor's
+	if p != nil {
+		child.metrics = p.metrics
+	}
+	if m := task.Info.Metrics; m != nil {
+		if child.metrics != nil {
+			m.attach(child.metrics)
+		}
+		child.metrics = m
+	}
+	if task.Supervision != nil {
+		child.super = newSupervisor(task.Supervision)
 	}
 
 	// If a parent is given, add the child to the parent's list of children.
 	if p != nil {
 		var err error
 		p.subsMu.Lock()
-		if p.state == Running {
+		if atomic.LoadInt32(&p.state) == Running {
 			p.busy.Add(1)
 			p.idle = false
 			p.subs = append(p.subs, child)
+			p.metrics.Gauge(MetricChildren).Add(1)
+			if p.draining { // a child started while draining is also draining
+				child.draining = true
+				close(child.chDraining)
+			}
 		} else {
 			err = ErrNotStarted
 		}
@@ -232,28 +366,46 @@ func (p *ctx) StartChild(task *Task) (Context, error) {
 		}
 	}
 
+	// OnRun is counted as busy before the child can begin closing
+	if child.task.OnRun != nil {
+		child.busy.Add(1)
+	}
+	child.emitEvent(EventStarted)
+
 	go func() {
 
-		// If there is a parent, wait until child.Close() *or* p.Close()
+		// If there is a parent, wait until child.Close() *or* p.Close() *or* the child's deadline
 		// TODO: merge CloseWhenIdle() into this block?
+		var (
+			parentClosing <-chan struct{}
+			deadline      <-chan time.Time
+		)
 		if p != nil {
-			select {
-			case <-p.Closing():
-				child.Close()
-			case <-child.Closing():
-			}
+			parentClosing = p.Closing()
+		}
+		if dl := child.task.Info.Deadline; !dl.IsZero() {
+			timer := clk.NewTimer(dl.Sub(clk.Now()))
+			defer timer.Stop()
+			deadline = timer.C()
+		}
+		select {
+		case <-parentClosing:
+			child.closeWithErr(p.err) // propagate the parent's cause
+		case <-deadline:
+			child.closeWithErr(context.DeadlineExceeded)
+		case <-child.Closing():
 		}
 
 		// Wait for child to begin closing phase
 		<-child.Closing()
 
-		// Fire callback if given
+		// Fire callback if given -- a panic in a close hook is logged since the context is already closing
 		if child.task.OnClosing != nil {
-			child.task.OnClosing()
+			child.recoverCall("OnClosing", child.task.OnClosing)
 		}
 
 		if p != nil && p.task.OnChildClosing != nil {
-			p.task.OnChildClosing(child)
+			p.recoverCall("OnChildClosing", func() { p.task.OnChildClosing(child) })
 		}
 
 		// Once all child's children are closed, proceed with completion.
@@ -286,14 +438,22 @@ func (p *ctx) StartChild(task *Task) (Context, error) {
 		}
 
 		// Move to Closed state now that all all that remains is the OnClosed callback and release of the chClosed chan.
-		child.state = Closed
+		atomic.StoreInt32(&child.state, Closed)
 		if child.task.OnClosed != nil {
-			child.task.OnClosed()
+			child.recoverCall("OnClosed", child.task.OnClosed)
+		}
+		if child.task.Info.Metrics != nil {
+			child.task.Info.Metrics.zeroGauges()
 		}
 		close(child.chClosed)
+		child.emitEvent(EventClosed)
 
 		// With the child now fully closed, the parent is no longer waiting on this child
 		if p != nil {
+			if p.super != nil {
+				p.super.childClosed(p, child)
+			}
+			p.metrics.Gauge(MetricChildren).Add(-1)
 			p.busy.Done()
 		}
 
@@ -303,19 +463,37 @@ func (p *ctx) StartChild(task *Task) (Context, error) {
 	}()
 
 	if child.task.OnStart != nil {
-		err := child.task.OnStart(child)
+		var err error
+		perr := child.recoverCall("OnStart", func() {
+			err = child.task.OnStart(child)
+		})
 		child.task.OnStart = nil
+		if perr != nil {
+			err = perr
+		}
 		if err != nil {
-			child.Close()
+			if child.task.OnRun != nil {
+				child.busy.Done() // OnRun is never called
+			}
+			if perr != nil {
+				child.CloseWithCause(perr)
+			} else {
+				child.Close()
+			}
 			return nil, err
 		}
 	}
 
 	if child.task.OnRun != nil {
-		child.busy.Add(1)
+		child.running.Store(true)
 		go func() {
-			child.task.OnRun(child)
+			start := clk.Now()
+			if err := child.recoverCall("OnRun", func() { child.task.OnRun(child) }); err != nil {
+				child.CloseWithCause(err)
+			}
 			child.task.OnRun = nil
+			child.running.Store(false)
+			child.metrics.Counter(MetricRunSeconds).Add(clk.Now().Sub(start).Seconds())
 			child.busy.Done()
 
 			// If idleclose is set, try to do so
@@ -338,6 +516,10 @@ func (p *ctx) Go(label string, fn func(ctx Context)) (Context, error) {
 	})
 }
 
+func (p *ctx) Draining() <-chan struct{} {
+	return p.chDraining
+}
+
 func (p *ctx) Closing() <-chan struct{} {
 	return p.chClosing
 }
@@ -1,12 +1,22 @@
 package task_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/tag"
 	"github.com/art-media-platform/amp-sdk-go/stdlib/task"
 )
 
@@ -208,3 +218,799 @@ func (a Awaiter) NeverHappenedOrFail(t testing.TB, params ...interface{}) {
 	case <-time.After(duration):
 	}
 }
+
+func TestDeadline(t *testing.T) {
+	deadline := time.Now().Add(20 * time.Millisecond)
+	p, err := task.Start(&task.Task{
+		Info: task.Info{
+			Label:    "deadline",
+			Deadline: deadline,
+		},
+	})
+	require.NoError(t, err)
+
+	dl, ok := p.Deadline()
+	require.True(t, ok)
+	require.Equal(t, deadline, dl)
+
+	select {
+	case <-time.After(2 * time.Second):
+		t.Fatal("deadline did not close the context")
+	case <-p.Done():
+	}
+	require.ErrorIs(t, p.Err(), context.DeadlineExceeded)
+
+	// a context closed before its deadline reports context.Canceled
+	p, _ = task.Start(&task.Task{
+		Info: task.Info{
+			Deadline: time.Now().Add(time.Hour),
+		},
+	})
+	p.Close()
+	<-p.Done()
+	require.ErrorIs(t, p.Err(), context.Canceled)
+}
+
+func TestTimeoutValuesCause(t *testing.T) {
+	type key string
+
+	// timeouts and deadlines are inherited
+	root, err := task.Start((&task.Task{
+		Info: task.Info{
+			Label:   "root",
+			Timeout: 50 * time.Millisecond,
+		},
+	}).WithValue(key("user"), "alice").WithValue(key("scope"), "root"))
+	require.NoError(t, err)
+
+	child, err := root.StartChild((&task.Task{
+		Info: task.Info{
+			Label:   "child",
+			Timeout: time.Hour,
+		},
+	}).WithValue(key("scope"), "child"))
+	require.NoError(t, err)
+
+	rootDeadline, ok := root.Deadline()
+	require.True(t, ok)
+	childDeadline, ok := child.Deadline()
+	require.True(t, ok)
+	require.Equal(t, rootDeadline, childDeadline)
+
+	require.Equal(t, "alice", child.Value(key("user")))
+	require.Equal(t, "child", child.Value(key("scope")))
+	require.Equal(t, "root", root.Value(key("scope")))
+	require.Nil(t, child.Value(key("missing")))
+
+	select {
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout did not close the context")
+	case <-child.Done():
+	}
+	<-root.Done()
+	require.ErrorIs(t, root.Err(), context.DeadlineExceeded)
+	require.ErrorIs(t, child.Err(), context.DeadlineExceeded)
+
+	// a cause propagates to children and satisfies context.Canceled
+	errQuota := errors.New("quota exceeded")
+	root, _ = task.Start(&task.Task{})
+	child, _ = root.StartChild(&task.Task{})
+	root.CloseWithCause(errQuota)
+	<-child.Done()
+	<-root.Done()
+	for _, ctx := range []task.Context{root, child} {
+		require.ErrorIs(t, ctx.Err(), errQuota)
+		require.ErrorIs(t, ctx.Err(), context.Canceled)
+		require.ErrorIs(t, context.Cause(ctx), errQuota)
+		require.Equal(t, errQuota.Error(), ctx.Err().Error())
+	}
+
+	// only the first close takes effect
+	root, _ = task.Start(&task.Task{})
+	root.Close()
+	root.CloseWithCause(errQuota)
+	<-root.Done()
+	require.Equal(t, context.Canceled, root.Err())
+}
+
+func TestPanicSupervision(t *testing.T) {
+	root, err := task.Start(&task.Task{})
+	require.NoError(t, err)
+	defer root.Close()
+
+	// a panic closes only the panicking context, with a PanicError as its cause
+	child, err := root.Go("panics", func(ctx task.Context) {
+		panic("boom")
+	})
+	require.NoError(t, err)
+	<-child.Done()
+	var perr *task.PanicError
+	require.ErrorAs(t, child.Err(), &perr)
+	require.Equal(t, "boom", perr.Value)
+	require.NotEmpty(t, perr.Stack)
+	requireDone(t, root.Closing(), false)
+
+	_, err = root.StartChild(&task.Task{
+		OnStart: func(ctx task.Context) error { panic("boom") },
+	})
+	require.ErrorAs(t, err, &perr)
+
+	// OneForOne restarts a failed child with backoff until it succeeds
+	var runs atomic.Int32
+	sup, _ := root.StartChild(&task.Task{
+		Supervision: &task.Supervision{
+			Strategy:   task.OneForOne,
+			Restart:    task.RestartOnFailure,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 10 * time.Millisecond,
+		},
+	})
+	sup.StartChild(&task.Task{
+		OnRun: func(ctx task.Context) {
+			if runs.Add(1) < 3 {
+				panic("flaky")
+			}
+			<-ctx.Closing()
+		},
+	})
+	require.Eventually(t, func() bool { return runs.Load() == 3 }, 2*time.Second, time.Millisecond)
+	require.Len(t, sup.GetChildren(nil), 1)
+	sup.Close()
+	<-sup.Done()
+	require.Equal(t, int32(3), runs.Load())
+
+	// OneForAll restarts the siblings of a failed child
+	var starts [2]atomic.Int32
+	sup, _ = root.StartChild(&task.Task{
+		Supervision: &task.Supervision{
+			Strategy: task.OneForAll,
+			Restart:  task.RestartOnFailure,
+		},
+	})
+	for i := range starts {
+		sup.StartChild(&task.Task{
+			OnRun: func(ctx task.Context) {
+				if starts[i].Add(1) == 1 && i == 0 {
+					ctx.CloseWithCause(errors.New("failed"))
+				}
+				<-ctx.Closing()
+			},
+		})
+	}
+	require.Eventually(t, func() bool { return starts[0].Load() == 2 && starts[1].Load() == 2 }, 2*time.Second, time.Millisecond)
+	require.Len(t, sup.GetChildren(nil), 2)
+	sup.Close()
+	<-sup.Done()
+
+	// exceeding MaxRestarts closes the supervisor
+	runs.Store(0)
+	sup, _ = root.StartChild(&task.Task{
+		Supervision: &task.Supervision{
+			Restart:     task.RestartAlways,
+			MaxRestarts: 3,
+			Period:      time.Minute,
+		},
+	})
+	sup.Go("crashes", func(ctx task.Context) {
+		runs.Add(1)
+		panic("always")
+	})
+	select {
+	case <-time.After(2 * time.Second):
+		t.Fatal("supervisor did not close")
+	case <-sup.Done():
+	}
+	require.ErrorIs(t, sup.Err(), task.ErrMaxRestarts)
+	require.ErrorAs(t, sup.Err(), &perr)
+	require.Equal(t, int32(4), runs.Load())
+	requireDone(t, root.Closing(), false)
+}
+
+func TestSnapshotWatch(t *testing.T) {
+	root, err := task.Start(&task.Task{
+		Info: task.Info{
+			Label: "root",
+			TagID: tag.ID{1, 2, 3},
+		},
+	})
+	require.NoError(t, err)
+	defer root.Close()
+
+	feed, stop := task.Watch(root, 16)
+	defer stop()
+
+	session, _ := root.StartChild(&task.Task{Info: task.Info{Label: "session"}})
+	release := make(chan struct{})
+	pin, _ := session.Go("pin", func(ctx task.Context) {
+		<-release
+	})
+
+	snap := task.TakeSnapshot(root, -1)
+	require.Equal(t, "root", snap.Label)
+	require.Equal(t, tag.ID{1, 2, 3}.Base32(), snap.TagID)
+	require.Equal(t, "running", snap.State)
+	require.Equal(t, 1, snap.NumChildren)
+	require.Len(t, snap.Children, 1)
+	sessionSnap := snap.Children[0]
+	require.Equal(t, snap.TID, sessionSnap.ParentTID)
+	require.Len(t, sessionSnap.Children, 1)
+	pinSnap := sessionSnap.Children[0]
+	require.Equal(t, "pin", pinSnap.Label)
+	require.True(t, pinSnap.Running)
+	require.Equal(t, time.Nanosecond, pinSnap.IdleClose)
+
+	shallow := task.TakeSnapshot(root, 0)
+	require.Empty(t, shallow.Children)
+	require.Equal(t, 1, shallow.NumChildren)
+
+	close(release)
+	<-pin.Done()
+
+	nextEvent := func() task.Event {
+		t.Helper()
+		select {
+		case ev := <-feed:
+			return ev
+		case <-time.After(2 * time.Second):
+			t.Fatal("no event")
+			return task.Event{}
+		}
+	}
+	for _, expect := range []struct {
+		kind  task.EventKind
+		label string
+	}{
+		{task.EventStarted, "session"},
+		{task.EventStarted, "pin"},
+		{task.EventClosed, "pin"},
+	} {
+		ev := nextEvent()
+		require.Equal(t, expect.kind, ev.Kind)
+		require.Equal(t, expect.label, ev.Context.Label)
+	}
+
+	// contexts outside the watched tree are not reported
+	other, _ := task.Start(&task.Task{})
+	other.Close()
+	<-other.Done()
+	requireNoEvent := func() {
+		select {
+		case ev := <-feed:
+			t.Fatalf("unexpected event: %v %v", ev.Kind, ev.Context.Label)
+		default:
+		}
+	}
+	requireNoEvent()
+
+	// a root embedding a Context is watched as the Context it embeds
+	wrapped := struct{ task.Context }{session}
+	sessionFeed, stopSession := task.Watch(wrapped, 16)
+	defer stopSession()
+	other, _ = task.Start(&task.Task{})
+	other.Close()
+	<-other.Done()
+	child, _ := session.StartChild(&task.Task{Info: task.Info{Label: "child"}})
+	select {
+	case ev := <-sessionFeed:
+		require.Equal(t, task.EventStarted, ev.Kind)
+		require.Equal(t, "child", ev.Context.Label)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event")
+	}
+	child.Close()
+	<-child.Done()
+	require.Equal(t, task.EventStarted, nextEvent().Kind)
+	require.Equal(t, task.EventClosed, nextEvent().Kind)
+
+	// debug handler
+	srv := httptest.NewServer(task.DebugHandler(root))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "?depth=1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var served task.Snapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&served))
+	require.Equal(t, snap.TID, served.TID)
+	require.Len(t, served.Children, 1)
+	require.Empty(t, served.Children[0].Children)
+}
+
+func TestPoolLimits(t *testing.T) {
+	root, err := task.Start(&task.Task{})
+	require.NoError(t, err)
+	defer root.Close()
+
+	pool, err := task.StartPoolWithOpts(root, task.PoolOpts{
+		Label:     "assets",
+		Size:      2,
+		MaxQueued: 3,
+	})
+	require.NoError(t, err)
+
+	// jobs run at most Size at once as children of the pool
+	var active, peak atomic.Int32
+	release := make(chan struct{})
+	work := func(ctx task.Context) {
+		n := active.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		select {
+		case <-release:
+		case <-ctx.Closing():
+		}
+		active.Add(-1)
+	}
+	var jobs []*task.Job
+	for i := 0; i < 5; i++ {
+		job, err := pool.TrySubmit(fmt.Sprintf("job%d", i), work)
+		require.NoError(t, err)
+		jobs = append(jobs, job)
+	}
+	_, err = pool.TrySubmit("overflow", work)
+	require.ErrorIs(t, err, task.ErrPoolFull)
+
+	require.Eventually(t, func() bool { return active.Load() == 2 }, 2*time.Second, time.Millisecond)
+	running, queued := pool.Len()
+	require.Equal(t, 2, running)
+	require.Equal(t, 3, queued)
+	require.Len(t, pool.Context().GetChildren(nil), 2)
+
+	// canceling a queued and a running job
+	jobs[4].Cancel()
+	<-jobs[4].Done()
+	require.ErrorIs(t, jobs[4].Err(), context.Canceled)
+	jobs[0].Cancel()
+	<-jobs[0].Done()
+	require.ErrorIs(t, jobs[0].Err(), context.Canceled)
+
+	close(release)
+	for _, job := range jobs[1:4] {
+		<-job.Done()
+		require.NoError(t, job.Err())
+	}
+	require.Equal(t, int32(2), peak.Load())
+
+	// closing the pool fails queued jobs
+	block := make(chan struct{})
+	blocker, _ := pool.Submit("blocker", func(ctx task.Context) { <-ctx.Closing(); close(block) })
+	pool.Submit("blocker", func(ctx task.Context) { <-ctx.Closing() })
+	queuedJob, _ := pool.Submit("queued", work)
+	pool.Close()
+	<-queuedJob.Done()
+	require.ErrorIs(t, queuedJob.Err(), task.ErrClosed)
+	<-block
+	<-blocker.Done()
+	require.ErrorIs(t, blocker.Err(), task.ErrClosed) // cut off while running
+	_, err = pool.Submit("late", work)
+	require.ErrorIs(t, err, task.ErrClosed)
+
+	// semaphore
+	sem := task.NewSemaphore(1)
+	require.NoError(t, sem.Acquire(root))
+	require.False(t, sem.TryAcquire())
+	waiter, _ := root.StartChild(&task.Task{})
+	go waiter.Close()
+	require.ErrorIs(t, sem.Acquire(waiter), task.ErrClosed)
+	sem.Release()
+	require.True(t, sem.TryAcquire())
+
+	// rate limiter
+	clk := clock.NewVirtual(time.Time{})
+	rl := task.NewRateLimiter(100, 2, clk)
+	require.True(t, rl.Allow())
+	require.True(t, rl.Allow())
+	require.False(t, rl.Allow())
+	waited := make(chan error, 1)
+	go func() { waited <- rl.Wait(root) }()
+	require.True(t, clk.WaitForTimers(1, 2*time.Second))
+	clk.Advance(9 * time.Millisecond)
+	require.Empty(t, waited, "Wait should block until a token accrues")
+	clk.Advance(time.Millisecond)
+	require.NoError(t, <-waited)
+	clk.Advance(10 * time.Millisecond)
+	require.True(t, rl.Allow())
+	require.False(t, rl.Allow())
+
+	slow := task.NewRateLimiter(0.1, 1, clk)
+	require.True(t, slow.Allow())
+	waiter, _ = root.StartChild(&task.Task{})
+	go func() { waited <- slow.Wait(waiter) }()
+	require.True(t, clk.WaitForTimers(1, 2*time.Second))
+	waiter.Close()
+	require.ErrorIs(t, <-waited, task.ErrClosed)
+	clk.Advance(10 * time.Second)
+	require.True(t, slow.Allow(), "a closed waiter should return its reserved token")
+
+	// a non-positive rate allows only the initial burst, and an infinite rate allows everything
+	for _, perSecond := range []float64{0, -1, math.NaN()} {
+		never := task.NewRateLimiter(perSecond, 2, clk)
+		require.NoError(t, never.Wait(root))
+		require.True(t, never.Allow())
+		require.False(t, never.Allow())
+		clk.Advance(time.Hour)
+		require.False(t, never.Allow())
+		waiter, _ = root.StartChild(&task.Task{})
+		go waiter.Close()
+		require.ErrorIs(t, never.Wait(waiter), task.ErrClosed)
+	}
+	unlimited := task.NewRateLimiter(math.Inf(1), 1, clk)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, unlimited.Wait(root))
+		require.True(t, unlimited.Allow())
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	cron, err := task.ParseCron("*/15 9-17 * * mon-fri")
+	require.NoError(t, err)
+	cron.Location = time.UTC
+	at := func(s string) time.Time {
+		tm, err := time.Parse(time.DateTime, s)
+		require.NoError(t, err)
+		return tm
+	}
+	for _, tc := range []struct{ after, next string }{
+		{"2026-10-16 09:00:00", "2026-10-16 09:15:00"}, // Friday
+		{"2026-10-16 09:14:59", "2026-10-16 09:15:00"},
+		{"2026-10-16 17:45:00", "2026-10-19 09:00:00"}, // to Monday
+		{"2026-12-31 23:59:00", "2027-01-01 09:00:00"},
+	} {
+		require.Equal(t, at(tc.next), cron.Next(at(tc.after)), tc.after)
+	}
+
+	cron, err = task.ParseCron("0 0 13 * fri") // the 13th or any Friday
+	require.NoError(t, err)
+	cron.Location = time.UTC
+	require.Equal(t, at("2026-10-16 00:00:00"), cron.Next(at("2026-10-14 12:00:00")))
+	require.Equal(t, at("2026-11-13 00:00:00"), cron.Next(at("2026-11-07 00:00:00")))
+
+	cron, err = task.ParseCron("@monthly")
+	require.NoError(t, err)
+	cron.Location = time.UTC
+	require.Equal(t, at("2026-11-01 00:00:00"), cron.Next(at("2026-10-18 08:00:00")))
+
+	for _, bad := range []string{"", "* * * *", "60 * * * *", "* * * * mon-sun/0", "5-1 * * * *", "* * * foo *"} {
+		_, err = task.ParseCron(bad)
+		require.Error(t, err, bad)
+	}
+
+	clk := clock.NewVirtual(time.Time{})
+	root, err := task.Start(&task.Task{Info: task.Info{Clock: clk}})
+	require.NoError(t, err)
+	defer root.Close()
+
+	// advance moves the clock once the schedule (or a run's idle close) has a pending timer
+	advance := func() {
+		t.Helper()
+		require.True(t, clk.WaitForTimers(1, 2*time.Second))
+		clk.Advance(5 * time.Millisecond)
+	}
+
+	// runs appear as children and overlapping runs are skipped
+	var runs, active atomic.Int32
+	release := make(chan struct{})
+	sched, err := task.StartScheduled(root, task.ScheduleOpts{
+		Label:    "sync",
+		Schedule: task.Every(5 * time.Millisecond),
+		Overlap:  task.OverlapSkip,
+	}, func(ctx task.Context) {
+		runs.Add(1)
+		active.Add(1)
+		defer active.Add(-1)
+		select {
+		case <-release:
+		case <-ctx.Closing():
+		}
+	})
+	require.NoError(t, err)
+	advance()
+	require.Eventually(t, func() bool { return runs.Load() == 1 }, 2*time.Second, time.Millisecond)
+	for i := 0; i < 6; i++ {
+		advance() // each occurrence is skipped while run #1 is active
+	}
+	require.True(t, clk.WaitForTimers(1, 2*time.Second))
+	require.Equal(t, int32(1), runs.Load())
+	require.Len(t, sched.GetChildren(nil), 1)
+	require.Equal(t, "sync#1", sched.GetChildren(nil)[0].Log().GetLogLabel())
+
+	close(release)
+	require.Eventually(t, func() bool { return active.Load() == 0 }, 2*time.Second, time.Millisecond)
+	for start := time.Now(); runs.Load() < 3; {
+		require.Less(t, time.Since(start), 2*time.Second, "scheduled runs did not resume")
+		advance()
+	}
+	sched.Close()
+	<-sched.Done()
+	require.Equal(t, int32(0), active.Load())
+
+	// a one-shot runs once and then closes
+	var shots atomic.Int32
+	oneShot, err := task.StartScheduled(root, task.ScheduleOpts{
+		Schedule: task.At(clk.Now().Add(10 * time.Millisecond)),
+		Jitter:   time.Millisecond,
+	}, func(ctx task.Context) {
+		shots.Add(1)
+	})
+	require.NoError(t, err)
+	for !isDone(t, oneShot.Done()) {
+		added := clk.TimerAdded()
+		if clk.Pending() > 0 {
+			clk.Advance(5 * time.Millisecond) // the run and the one-shot's idle close are on the virtual clock
+			continue
+		}
+		select {
+		case <-added:
+		case <-oneShot.Done():
+		case <-time.After(2 * time.Second):
+			t.Fatal("one-shot did not close")
+		}
+	}
+	require.Equal(t, int32(1), shots.Load())
+
+	// closing the parent stops a pending schedule
+	daily, _ := task.ParseCron("@daily")
+	child, _ := root.StartChild(&task.Task{})
+	pending, err := task.StartScheduled(child, task.ScheduleOpts{Schedule: daily}, func(ctx task.Context) {})
+	require.NoError(t, err)
+	child.Close()
+	select {
+	case <-time.After(2 * time.Second):
+		t.Fatal("schedule did not stop")
+	case <-pending.Done():
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	root, err := task.Start(&task.Task{Info: task.Info{Label: "host"}})
+	require.NoError(t, err)
+
+	// a polite child finishes its in-flight work once draining
+	var finished atomic.Bool
+	polite, _ := root.Go("polite", func(ctx task.Context) {
+		<-ctx.Draining()
+		time.Sleep(5 * time.Millisecond)
+		finished.Store(true)
+	})
+	session, _ := root.StartChild(&task.Task{Info: task.Info{Label: "session"}})
+	pin, _ := session.Go("pin", func(ctx task.Context) {
+		<-ctx.Draining()
+	})
+	go func() {
+		<-pin.Done()
+		session.Close()
+	}()
+
+	overran, err := task.Shutdown(root, 2*time.Second)
+	require.NoError(t, err)
+	require.Empty(t, overran)
+	require.True(t, finished.Load())
+	requireDone(t, polite.Done(), true)
+	require.ErrorIs(t, root.Err(), context.Canceled)
+
+	// stragglers are force-closed and reported once the timeout passes on the root's clock
+	clk := clock.NewVirtual(time.Time{})
+	root, _ = task.Start(&task.Task{Info: task.Info{Clock: clk}})
+	straggler, _ := root.StartChild(&task.Task{
+		Info: task.Info{Label: "straggler"},
+		OnRun: func(ctx task.Context) {
+			<-ctx.Closing()
+		},
+	})
+	done := make(chan struct{})
+	go func() {
+		overran, err = task.Shutdown(root, 20*time.Millisecond)
+		close(done)
+	}()
+	require.True(t, clk.WaitForTimers(1, 2*time.Second))
+	clk.Advance(19 * time.Millisecond)
+	requireDone(t, done, false)
+	clk.Advance(time.Millisecond)
+	<-done
+	require.ErrorIs(t, err, task.ErrDrainTimeout)
+	require.Len(t, overran, 1)
+	require.Equal(t, "straggler", overran[0].Label)
+	require.True(t, overran[0].Running)
+	require.ErrorIs(t, straggler.Err(), task.ErrDrainTimeout)
+
+	// a child started while draining is draining, and Close() also signals Draining()
+	root, _ = task.Start(&task.Task{})
+	blocker, _ := root.Go("blocker", func(ctx task.Context) { <-ctx.Closing() })
+	go task.Shutdown(root, time.Second)
+	<-blocker.Draining()
+	late, err := root.StartChild(&task.Task{})
+	if err == nil {
+		requireDone(t, late.Draining(), true)
+		late.Close()
+	}
+	blocker.Close()
+	<-root.Done()
+
+	other, _ := task.Start(&task.Task{})
+	requireDone(t, other.Draining(), false)
+	other.Close()
+	requireDone(t, other.Draining(), true)
+}
+
+func TestMetrics(t *testing.T) {
+	app, err := task.Start(&task.Task{
+		Info: task.Info{
+			Label:   "app",
+			Metrics: task.NewMetrics(),
+		},
+	})
+	require.NoError(t, err)
+	defer app.Close()
+
+	sessionMetrics := task.NewMetrics()
+	session, _ := app.StartChild(&task.Task{
+		Info: task.Info{
+			Label:   "session",
+			Metrics: sessionMetrics,
+		},
+	})
+
+	// a pin without Metrics reports to its session's, which rolls up into the app's
+	release := make(chan struct{})
+	pin, _ := session.Go("pin", func(ctx task.Context) {
+		m := task.MetricsOf(ctx)
+		m.Counter("tx_sent").Add(3)
+		m.Counter("tx_bytes").Add(1024)
+		m.Histogram("tx_latency", []float64{0.01, 0.1}).Observe(0.05)
+		m.Gauge("pending").Set(2)
+		<-release
+	})
+	require.Same(t, sessionMetrics, task.MetricsOf(pin))
+
+	appMetrics := task.MetricsOf(app)
+	require.Eventually(t, func() bool { return appMetrics.Counter("tx_sent").Value() == 3 }, 2*time.Second, time.Millisecond)
+	require.Equal(t, float64(1), sessionMetrics.Gauge(task.MetricChildren).Value())
+	require.Equal(t, float64(2), appMetrics.Gauge(task.MetricChildren).Value()) // the session and its pin
+	require.Equal(t, float64(1024), appMetrics.Counter("tx_bytes").Value())
+	count, sum := appMetrics.Histogram("tx_latency", nil).Totals()
+	require.Equal(t, uint64(1), count)
+	require.Equal(t, 0.05, sum)
+	require.Equal(t, float64(2), appMetrics.Gauge("pending").Value())
+
+	// exporters
+	var prom strings.Builder
+	require.NoError(t, task.WritePrometheus(&prom, app, "amp_"))
+	text := prom.String()
+	require.Contains(t, text, "# TYPE amp_tx_sent counter\n")
+	require.Contains(t, text, fmt.Sprintf("amp_tx_sent{ctx=\"session\",tid=\"%d\"} 3\n", session.Info().TID))
+	require.Contains(t, text, fmt.Sprintf("amp_tx_latency_bucket{ctx=\"app\",tid=\"%d\",le=\"0.1\"} 1\n", app.Info().TID))
+	require.Contains(t, text, "# TYPE amp_children gauge\n")
+
+	var vars map[string]map[string]float64
+	require.NoError(t, json.Unmarshal([]byte(task.ExpvarFunc(app).String()), &vars))
+	require.Equal(t, float64(3), vars[fmt.Sprintf("session#%d", session.Info().TID)]["tx_sent"])
+
+	var tree strings.Builder
+	task.PrintContextTree(app, &tree, 0)
+	require.NotContains(t, tree.String(), "children=")
+	tree.Reset()
+	task.PrintContextTreeWithMetrics(app, &tree, 0)
+	require.Contains(t, tree.String(), "session children=1")
+	require.Equal(t, float64(3), task.TakeSnapshot(app, 0).Metrics["tx_sent"])
+
+	// run time accrues and gauges are released as contexts close
+	close(release)
+	<-pin.Done()
+	require.Greater(t, appMetrics.Counter(task.MetricRunSeconds).Value(), float64(0))
+	session.Close()
+	<-session.Done()
+	require.Equal(t, float64(0), appMetrics.Gauge("pending").Value())
+	require.Equal(t, float64(0), appMetrics.Gauge(task.MetricChildren).Value())
+	require.Equal(t, float64(3), appMetrics.Counter("tx_sent").Value())
+
+	// values recorded before a Metrics is attached roll up once it is
+	early := task.NewMetrics()
+	early.Counter("tx_sent").Add(2)
+	early.Histogram("tx_latency", []float64{0.01, 0.1}).Observe(0.005)
+	early.Histogram("tx_latency", nil).Observe(5)
+	late, _ := app.StartChild(&task.Task{
+		Info: task.Info{
+			Label:   "late",
+			Metrics: early,
+		},
+	})
+	defer late.Close()
+	require.Equal(t, float64(5), appMetrics.Counter("tx_sent").Value())
+	count, sum = appMetrics.Histogram("tx_latency", nil).Totals()
+	require.Equal(t, uint64(3), count)
+	require.Equal(t, 5.055, sum)
+	_, cumulative := appMetrics.Histogram("tx_latency", nil).Buckets()
+	require.Equal(t, []uint64{1, 2}, cumulative)
+}
+
+func TestBus(t *testing.T) {
+	host, err := task.Start(&task.Task{
+		Info: task.Info{
+			Label: "host",
+		},
+	})
+	require.NoError(t, err)
+	defer host.Close()
+
+	bus := task.NewBus()
+	fileChanged := task.NewTopic[string](bus, tag.FromString("file.changed"))
+	require.Panics(t, func() { task.NewTopic[int](bus, fileChanged.ID) })
+
+	watcher, _ := host.StartChild(&task.Task{Info: task.Info{Label: "watcher"}})
+	media, _ := host.StartChild(&task.Task{Info: task.Info{Label: "media"}})
+
+	// each drop policy keeps its buffer bounded
+	newest, err := fileChanged.Subscribe(media, task.SubscribeOpts{Buffer: 2, Policy: task.DropNewest})
+	require.NoError(t, err)
+	oldest, err := fileChanged.Subscribe(media, task.SubscribeOpts{Buffer: 2, Policy: task.DropOldest})
+	require.NoError(t, err)
+	require.Equal(t, 2, fileChanged.Subscribers())
+
+	for _, path := range []string{"a", "b", "c"} {
+		fileChanged.Publish(watcher, path)
+	}
+	require.Equal(t, "a", <-newest.C())
+	require.Equal(t, "b", <-newest.C())
+	require.Equal(t, uint64(1), newest.Dropped())
+	require.Equal(t, "b", <-oldest.C())
+	require.Equal(t, "c", <-oldest.C())
+	require.Equal(t, uint64(1), oldest.Dropped())
+
+	// a blocking subscriber applies backpressure until it reads or its owner closes
+	newest.Close()
+	oldest.Close()
+	require.Equal(t, 0, fileChanged.Subscribers())
+
+	var received []string
+	handled := make(chan struct{})
+	handler, err := fileChanged.Handle(media, "on-file-changed", task.SubscribeOpts{Buffer: 1, Policy: task.Block}, func(ctx task.Context, path string) {
+		received = append(received, path)
+		handled <- struct{}{}
+	})
+	require.NoError(t, err)
+
+	published := make(chan int)
+	go func() {
+		delivered := 0
+		for _, path := range []string{"x", "y", "z"} {
+			delivered += fileChanged.Publish(watcher, path)
+		}
+		published <- delivered
+	}()
+	for range 3 {
+		<-handled
+	}
+	require.Equal(t, 3, <-published)
+	require.Equal(t, []string{"x", "y", "z"}, received)
+
+	// subscriptions are removed when their owner closes, releasing blocked publishers
+	handler.Close()
+	<-handler.Done()
+	require.Equal(t, 0, fileChanged.Subscribers())
+
+	viewer, _ := media.StartChild(&task.Task{Info: task.Info{Label: "viewer"}})
+	blocked, err := fileChanged.Subscribe(viewer, task.SubscribeOpts{Buffer: 1, Policy: task.Block})
+	require.NoError(t, err)
+	require.Equal(t, 1, fileChanged.Publish(watcher, "1"))
+	go func() {
+		published <- fileChanged.Publish(watcher, "2") // blocks until viewer closes
+	}()
+	select {
+	case <-published:
+		t.Fatal("Publish() should block while the subscriber's buffer is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+	viewer.Close()
+	require.Equal(t, 0, <-published)
+	<-viewer.Done()
+	require.Equal(t, 0, fileChanged.Subscribers())
+	require.Equal(t, "1", <-blocked.C())
+	_, open := <-blocked.C()
+	require.False(t, open)
+	require.Equal(t, uint64(1), blocked.Dropped())
+
+	media.Close()
+	<-media.Done()
+	_, err = fileChanged.Subscribe(media, task.SubscribeOpts{})
+	require.ErrorIs(t, err, task.ErrClosed)
+	require.Equal(t, 0, fileChanged.Publish(nil, "3"))
+}
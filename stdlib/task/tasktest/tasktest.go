@@ -0,0 +1,103 @@
+// Package tasktest provides helpers for testing code built on task.Context, such as detecting leaked Contexts and
+// exercising idle-close timing deterministically with a FakeClock.
+package tasktest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/clock"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/task"
+)
+
+// FakeClock is a clock.Virtual, which a test passes as Info.Clock to exercise idle-close, deadline, and timeout timing deterministically.
+type FakeClock = clock.Virtual
+
+// NewFakeClock returns a FakeClock set to the given time (or to 2000-01-01 UTC if zero).
+func NewFakeClock(start time.Time) *FakeClock {
+	return clock.NewVirtual(start)
+}
+
+// WaitTimeout is the real time that helpers wait for Contexts to respond before failing a test.
+var WaitTimeout = 5 * time.Second
+
+// Start starts a root Context for the given test, labeled with the test's name unless the Task has a Label.
+//
+// During the test's cleanup (after cleanups registered later), the test fails if the root has any children still open,
+// printing the Context tree -- since a test should close what it starts, these are presumably leaked.
+// The root is then closed and the test fails if it is not done within WaitTimeout.
+func Start(t testing.TB, tsk *task.Task) task.Context {
+	t.Helper()
+	if tsk == nil {
+		tsk = &task.Task{}
+	}
+	if tsk.Info.Label == "" {
+		tsk.Info.Label = t.Name()
+	}
+	root, err := task.Start(tsk)
+	if err != nil {
+		t.Fatalf("tasktest.Start: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if children := root.GetChildren(nil); len(children) > 0 {
+			t.Errorf("tasktest: %d context(s) still open after test:\n%s", len(children), treeString(root))
+		}
+		root.Close()
+		select {
+		case <-root.Done():
+		case <-time.After(WaitTimeout):
+			t.Errorf("tasktest: root context not done %v after Close():\n%s", WaitTimeout, treeString(root))
+		}
+	})
+	return root
+}
+
+// RequireDone fails the test unless ctx is done within the given (real) duration.
+func RequireDone(t testing.TB, ctx task.Context, within time.Duration) {
+	t.Helper()
+	select {
+	case <-ctx.Done():
+	case <-time.After(within):
+		t.Fatalf("tasktest: context not done within %v:\n%s", within, treeString(ctx))
+	}
+}
+
+// RequireIdleClose advances the given FakeClock (which ctx must be using) through pending timers until ctx is done,
+// failing the test unless ctx closes within the given clock duration.
+func RequireIdleClose(t testing.TB, clk *FakeClock, ctx task.Context, within time.Duration) {
+	t.Helper()
+
+	limit := clk.Now().Add(within)
+	deadline := time.Now().Add(WaitTimeout)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if next, ok := clk.NextTimer(); ok && !next.After(limit) {
+			clk.AdvanceTo(next)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("tasktest: context did not idle-close within %v:\n%s", within, treeString(ctx))
+		}
+
+		// await ctx closing (e.g. in response to a timer just fired) or a new timer
+		select {
+		case <-ctx.Done():
+		case <-clk.TimerAdded():
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func treeString(ctx task.Context) string {
+	var buf strings.Builder
+	task.PrintContextTree(ctx, &buf, 0)
+	return buf.String()
+}
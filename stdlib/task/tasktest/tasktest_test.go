@@ -0,0 +1,98 @@
+package tasktest_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/art-media-platform/amp-sdk-go/stdlib/task"
+	"github.com/art-media-platform/amp-sdk-go/stdlib/task/tasktest"
+)
+
+// recorder captures the failures of a test run within another test.
+type recorder struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, format)
+}
+
+func (r *recorder) Cleanup(fn func()) {
+	r.cleanups = append(r.cleanups, fn)
+}
+
+func (r *recorder) runCleanups() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func TestFakeClockIdleClose(t *testing.T) {
+	clock := tasktest.NewFakeClock(time.Time{})
+	root := tasktest.Start(t, &task.Task{
+		Info: task.Info{
+			Clock: clock,
+		},
+	})
+
+	// an hour of idle-close is simulated without waiting
+	child, err := root.StartChild(&task.Task{
+		Info: task.Info{
+			Label:     "hourly",
+			IdleClose: time.Hour,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, clock, child.Info().Clock)
+	work, _ := child.Go("work", func(ctx task.Context) {})
+	tasktest.RequireIdleClose(t, clock, work, time.Nanosecond)
+
+	require.True(t, clock.WaitForTimers(1, time.Second))
+	clock.Advance(59 * time.Minute)
+	select {
+	case <-child.Done():
+		t.Fatal("closed early")
+	case <-time.After(10 * time.Millisecond):
+	}
+	tasktest.RequireIdleClose(t, clock, child, 2*time.Minute)
+
+	// PreventIdleClose() defers an idle close by clock time
+	child, _ = root.StartChild(&task.Task{})
+	child.PreventIdleClose(3 * time.Hour)
+	child.CloseWhenIdle(time.Minute)
+	start := clock.Now()
+	tasktest.RequireIdleClose(t, clock, child, 4*time.Hour)
+	require.GreaterOrEqual(t, clock.Now().Sub(start), 3*time.Hour)
+
+	// deadlines follow the clock
+	child, _ = root.StartChild(&task.Task{Info: task.Info{Timeout: 24 * time.Hour}})
+	dl, _ := child.Deadline()
+	require.Equal(t, clock.Now().Add(24*time.Hour), dl)
+	require.True(t, clock.WaitForTimers(1, time.Second))
+	clock.Advance(24 * time.Hour)
+	tasktest.RequireDone(t, child, time.Second)
+}
+
+func TestLeakDetection(t *testing.T) {
+	rec := &recorder{TB: t}
+	root := tasktest.Start(rec, &task.Task{})
+	leaked, _ := root.Go("leaked", func(ctx task.Context) {
+		<-ctx.Closing()
+	})
+	rec.runCleanups()
+	require.Len(t, rec.errors, 1)
+	require.True(t, strings.Contains(rec.errors[0], "still open"))
+	tasktest.RequireDone(t, leaked, time.Second)
+
+	rec = &recorder{TB: t}
+	root = tasktest.Start(rec, nil)
+	done, _ := root.Go("done", func(ctx task.Context) {})
+	tasktest.RequireDone(t, done, time.Second)
+	rec.runCleanups()
+	require.Empty(t, rec.errors)
+}